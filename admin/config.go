@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"industrialregistrysystem/base/config"
+)
+
+// Config настройки админки: config/admin.yaml, переменные IRS_ADMIN_* и флаги
+type Config struct {
+	// ListenAddress адрес REST API
+	ListenAddress string `yaml:"listen_address" usage:"REST API listen address"`
+
+	// MainServiceAddress адрес gRPC сервера mainservice
+	MainServiceAddress string `yaml:"mainservice_address" usage:"mainservice gRPC address"`
+
+	TLS config.TLSFiles `yaml:"tls"`
+
+	// ShutdownTimeout сколько при остановке ждать выполняемые HTTP запросы
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" usage:"how long to wait for in-flight HTTP requests on shutdown"`
+}
+
+// defaultConfig значения по умолчанию, совпадающие с прежними константами
+func defaultConfig() *Config {
+	files := config.ServiceTLSFiles("admin")
+	files.ServerName = "mainservice"
+
+	return &Config{
+		ListenAddress:      ":8080",
+		MainServiceAddress: "localhost:5051",
+		TLS:                files,
+		ShutdownTimeout:    15 * time.Second,
+	}
+}
+
+// Validate проверяет настройки при запуске
+func (configuration *Config) Validate() error {
+	if err := config.CheckAddress("listen_address", configuration.ListenAddress); err != nil {
+		return err
+	}
+	if err := config.CheckAddress("mainservice_address", configuration.MainServiceAddress); err != nil {
+		return err
+	}
+	if err := configuration.TLS.Validate(); err != nil {
+		return err
+	}
+	if err := config.CheckPositive("shutdown_timeout", configuration.ShutdownTimeout); err != nil {
+		return err
+	}
+	if configuration.TLS.ServerName == "" {
+		return fmt.Errorf("tls.server_name: must be set")
+	}
+	return nil
+}
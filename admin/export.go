@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"industrialregistrysystem/base/api"
+)
+
+// exportEntities выгружает таблицу целиком в NDJSON (по умолчанию) или CSV. Строки
+// передаются клиенту по мере получения фрагментов из mainservice и не собираются в памяти.
+// Параметры отбора и сортировки те же, что у списка: order_by, order, filter и
+// фильтры по равенству; chunk_size задает размер фрагмента. Если выгрузка прервалась
+// после начала ответа, NDJSON заканчивается строкой {"error": ...}, а CSV обрывается.
+func (s *AdminService) exportEntities(c *gin.Context) {
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	tableName := c.Param("table")
+	format := c.DefaultQuery("format", "ndjson")
+	if format != "ndjson" && format != "csv" {
+		state.Status = "error"
+		state.Error = "Unsupported export format: " + format + " (expected ndjson or csv)"
+		c.JSON(http.StatusBadRequest, state)
+		return
+	}
+
+	chunkSize, _ := strconv.Atoi(c.DefaultQuery("chunk_size", "0"))
+
+	// Собираем фильтры из query параметров
+	filters := make(map[string]string)
+	for key, values := range c.Request.URL.Query() {
+		if len(values) > 0 && !isReservedQueryParam(key) {
+			filters[key] = values[0]
+		}
+	}
+
+	filter, err := filterFromQuery(c)
+	if err != nil {
+		state.Status = "error"
+		state.Error = "Invalid filter: " + err.Error()
+		c.JSON(http.StatusBadRequest, state)
+		return
+	}
+
+	// Отключение HTTP клиента отменяет выгрузку вплоть до курсора в БД
+	stream, err := s.dataClient.Export(c.Request.Context(), &api.ExportRequest{
+		TableName: tableName,
+		Filters:   filters,
+		Filter:    filter,
+		OrderBy:   c.Query("order_by"),
+		OrderDesc: c.DefaultQuery("order", "asc") == "desc",
+		ChunkSize: int32(chunkSize),
+	})
+	if err == nil {
+		// Ошибки запроса приходят с первым фрагментом: до него статус ответа еще можно выбрать
+		var first *api.ExportChunk
+		first, err = stream.Recv()
+		if err == nil {
+			s.writeExport(c, tableName, format, first, stream)
+			return
+		}
+	}
+
+	state.Status = "error"
+	state.Error = err.Error()
+	c.JSON(httpStatusFromError(err), state)
+}
+
+// writeExport записывает фрагменты выгрузки в ответ, сбрасывая буфер после каждого
+func (s *AdminService) writeExport(c *gin.Context, tableName string, format string,
+	first *api.ExportChunk, stream api.DataService_ExportClient) {
+	contentType := "application/x-ndjson"
+	if format == "csv" {
+		contentType = "text/csv; charset=utf-8"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="`+tableName+"."+format+`"`)
+	c.Status(http.StatusOK)
+
+	// Колонки приходят в первом фрагменте и задают заголовок и порядок полей CSV
+	columns := first.Columns
+	csvWriter := csv.NewWriter(c.Writer)
+	jsonEncoder := json.NewEncoder(c.Writer)
+	if format == "csv" {
+		csvWriter.Write(columns)
+	}
+
+	var totalRows int
+	chunk := first
+	for {
+		for _, entity := range chunk.Entities {
+			var err error
+			if format == "csv" {
+				err = csvWriter.Write(exportRecord(entity, columns))
+			} else {
+				err = jsonEncoder.Encode(exportObject(entity))
+			}
+			if err != nil {
+				log.Printf("⚠️ Export of %s interrupted after %d rows: %v", tableName, totalRows, err)
+				return
+			}
+			totalRows++
+		}
+		csvWriter.Flush()
+		c.Writer.Flush()
+
+		if chunk.Last {
+			log.Printf("📤 Exported %d rows of %s as %s", totalRows, tableName, format)
+			return
+		}
+
+		var err error
+		chunk, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			// Поток без последнего фрагмента - такой же обрыв выгрузки, как ошибка
+			err = errors.New("export stream ended before the last chunk")
+		}
+		if err != nil {
+			log.Printf("❌ Export of %s failed after %d rows: %v", tableName, totalRows, err)
+			// Статус уже отправлен: в NDJSON ошибка передается последней строкой, а в CSV
+			// ее некуда записать, поэтому ответ обрывается и файл не выглядит полным
+			if format == "csv" {
+				abortResponse(c)
+				return
+			}
+			jsonEncoder.Encode(gin.H{"error": err.Error()})
+			c.Writer.Flush()
+			return
+		}
+	}
+}
+
+// abortResponse закрывает соединение, не завершая ответ: клиент получает ошибку
+// чтения вместо обрезанного файла. Паника http.ErrAbortHandler здесь не подходит:
+// ее перехватывает gin.Recovery и завершает ответ штатно. gin не отдает соединение
+// после начала ответа, поэтому оно перехватывается у исходного http.ResponseWriter.
+func abortResponse(c *gin.Context) {
+	c.Abort()
+	var writer http.ResponseWriter = c.Writer
+	if wrapped, ok := writer.(interface{ Unwrap() http.ResponseWriter }); ok {
+		writer = wrapped.Unwrap()
+	}
+	connection, _, err := http.NewResponseController(writer).Hijack()
+	if err != nil {
+		log.Printf("⚠️ Failed to abort response: %v", err)
+		return
+	}
+	connection.Close()
+}
+
+// exportObject строка NDJSON: колонки с типизированными значениями
+func exportObject(entity *api.Entity) map[string]interface{} {
+	if len(entity.Values) == 0 {
+		object := make(map[string]interface{}, len(entity.Fields))
+		for name, text := range entity.Fields {
+			object[name] = text
+		}
+		return object
+	}
+
+	object := make(map[string]interface{}, len(entity.Values))
+	for name, value := range entity.Values {
+		object[name] = valueToJSON(value)
+	}
+	return object
+}
+
+// exportRecord строка CSV в порядке columns; NULL - пустое поле
+func exportRecord(entity *api.Entity, columns []string) []string {
+	fields := entity.TextFields()
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = fields[column]
+	}
+	return record
+}
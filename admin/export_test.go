@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"industrialregistrysystem/base/api"
+)
+
+// fakeExportStream поток фрагментов выгрузки, после которых Recv возвращает err
+type fakeExportStream struct {
+	grpc.ClientStream
+	chunks []*api.ExportChunk
+	err    error
+}
+
+func (stream *fakeExportStream) Recv() (*api.ExportChunk, error) {
+	if len(stream.chunks) == 0 {
+		return nil, stream.err
+	}
+	chunk := stream.chunks[0]
+	stream.chunks = stream.chunks[1:]
+	return chunk, nil
+}
+
+func exportRow(id int64, name string) *api.Entity {
+	return &api.Entity{Values: map[string]*api.Value{
+		"id":   {Kind: &api.Value_Int64Value{Int64Value: id}},
+		"name": {Kind: &api.Value_StringValue{StringValue: name}},
+	}}
+}
+
+// TestWriteExportTruncated выгрузка, прерванная после отправки статуса, не должна
+// выглядеть для клиента полным файлом
+func TestWriteExportTruncated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	first := &api.ExportChunk{Columns: []string{"id", "name"}, Entities: []*api.Entity{exportRow(1, "Завод")}}
+	second := &api.ExportChunk{Sequence: 1, Entities: []*api.Entity{exportRow(2, "Комбинат")}}
+	last := &api.ExportChunk{Sequence: 2, Last: true, TotalRows: 2}
+	failure := status.Error(codes.Unavailable, "database disconnected")
+
+	tests := []struct {
+		name      string
+		format    string
+		chunks    []*api.ExportChunk
+		err       error
+		aborted   bool   // Клиент должен получить ошибку чтения ответа
+		body      string // Ожидаемое тело ответа, если он не оборван
+		lastLine  string // Фрагмент последней строки NDJSON
+		bodyStart string // Начало оборванного тела
+	}{
+		{
+			name:   "complete CSV",
+			format: "csv",
+			chunks: []*api.ExportChunk{second, last},
+			err:    io.EOF,
+			body:   "id,name\n1,Завод\n2,Комбинат\n",
+		},
+		{
+			name:      "CSV interrupted by an error",
+			format:    "csv",
+			chunks:    []*api.ExportChunk{second},
+			err:       failure,
+			aborted:   true,
+			bodyStart: "id,name\n1,Завод\n2,Комбинат\n",
+		},
+		{
+			name:      "CSV stream closed before the last chunk",
+			format:    "csv",
+			err:       io.EOF,
+			aborted:   true,
+			bodyStart: "id,name\n1,Завод\n",
+		},
+		{
+			name:     "NDJSON interrupted by an error",
+			format:   "ndjson",
+			chunks:   []*api.ExportChunk{second},
+			err:      failure,
+			lastLine: `{"error":"rpc error: code = Unavailable desc = database disconnected"}`,
+		},
+		{
+			name:     "NDJSON stream closed before the last chunk",
+			format:   "ndjson",
+			err:      io.EOF,
+			lastLine: `{"error":"export stream ended before the last chunk"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := &AdminService{}
+			router := gin.New()
+			router.GET("/export", func(c *gin.Context) {
+				stream := &fakeExportStream{chunks: test.chunks, err: test.err}
+				service.writeExport(c, "organizations", test.format, first, stream)
+			})
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			response, err := http.Get(server.URL + "/export")
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want 200", response.StatusCode)
+			}
+
+			body, err := io.ReadAll(response.Body)
+			if test.aborted {
+				if !errors.Is(err, io.ErrUnexpectedEOF) {
+					t.Fatalf("reading body: %v, want unexpected EOF", err)
+				}
+				if !strings.HasPrefix(string(body), test.bodyStart) {
+					t.Errorf("body = %q, want it to start with %q", body, test.bodyStart)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reading body: %v", err)
+			}
+
+			if test.body != "" && string(body) != test.body {
+				t.Errorf("body = %q, want %q", body, test.body)
+			}
+			if test.lastLine != "" {
+				var lastLine string
+				scanner := bufio.NewScanner(strings.NewReader(string(body)))
+				for scanner.Scan() {
+					lastLine = scanner.Text()
+				}
+				if lastLine != test.lastLine {
+					t.Errorf("last line = %s, want %s", lastLine, test.lastLine)
+				}
+			}
+		})
+	}
+}
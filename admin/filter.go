@@ -0,0 +1,417 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"industrialregistrysystem/base/api"
+)
+
+// Синтаксис параметра filter в GET /:table, /:table/search и /:table/export
+// (дерево условий ListRequest.filter, SearchRequest.filter и ExportRequest.filter).
+//
+//	выражение := и-выражение { OR и-выражение }
+//	и-выражение := отрицание { AND отрицание }
+//	отрицание := NOT отрицание | "(" выражение ")" | условие
+//	условие := колонка оператор литерал
+//	         | колонка [NOT] IN "(" литерал { "," литерал } ")"
+//	         | колонка BETWEEN литерал AND литерал
+//	         | колонка IS [NOT] NULL
+//	         | колонка PREFIX 'строка'
+//	         | колонка ILIKE 'шаблон'
+//	оператор := "=" | "!=" | "<>" | "<" | "<=" | ">" | ">="
+//	литерал := 'строка' | число | TRUE | FALSE
+//
+// Ключевые слова не зависят от регистра. Колонка - имя из букв, цифр и "_" либо имя
+// в двойных кавычках. Кавычка внутри строки удваивается: 'O''Brien'. Целые числа
+// передаются как int64, дробные - как numeric без округления; даты и время задаются
+// строкой, например '2024-01-31'. PREFIX ищет строки, начинающиеся с заданной,
+// ILIKE принимает шаблон с % и _ без учета регистра.
+//
+// Примеры:
+//
+//	revenue BETWEEN 1000000 AND 5000000 AND district IN ('Центральный', 'Северный')
+//	has_export_supplies = true AND latitude IS NOT NULL
+//	NOT (status = 'closed' OR name ILIKE '%тест%')
+
+// filterTokenKind тип лексемы выражения фильтра
+type filterTokenKind int
+
+const (
+	filterTokenEnd filterTokenKind = iota
+	filterTokenWord
+	filterTokenQuotedName
+	filterTokenString
+	filterTokenNumber
+	filterTokenOperator
+	filterTokenOpen
+	filterTokenClose
+	filterTokenComma
+)
+
+type filterToken struct {
+	kind     filterTokenKind
+	text     string
+	position int // Позиция в символах от начала выражения, для сообщений об ошибках
+}
+
+// filterParser разбирает выражение фильтра рекурсивным спуском
+type filterParser struct {
+	tokens  []filterToken
+	current int
+}
+
+// filterFromQuery разбирает параметр filter запроса; без параметра возвращает nil
+func filterFromQuery(c *gin.Context) (*api.Filter, error) {
+	expression := c.Query("filter")
+	if expression == "" {
+		return nil, nil
+	}
+	return parseFilter(expression)
+}
+
+// parseFilter переводит выражение параметра filter в дерево api.Filter
+func parseFilter(expression string) (*api.Filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &filterParser{tokens: tokens}
+	filter, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != filterTokenEnd {
+		return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.position)
+	}
+	return filter, nil
+}
+
+func (parser *filterParser) peek() filterToken {
+	return parser.tokens[parser.current]
+}
+
+func (parser *filterParser) next() filterToken {
+	token := parser.tokens[parser.current]
+	if token.kind != filterTokenEnd {
+		parser.current++
+	}
+	return token
+}
+
+// keyword проверяет, что текущая лексема - ключевое слово, и пропускает ее
+func (parser *filterParser) keyword(word string) bool {
+	token := parser.peek()
+	if token.kind == filterTokenWord && strings.EqualFold(token.text, word) {
+		parser.current++
+		return true
+	}
+	return false
+}
+
+func (parser *filterParser) expectKeyword(word string) error {
+	if !parser.keyword(word) {
+		token := parser.peek()
+		return fmt.Errorf("expected %s at position %d, got %q", strings.ToUpper(word), token.position, token.text)
+	}
+	return nil
+}
+
+func (parser *filterParser) parseOr() (*api.Filter, error) {
+	first, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []*api.Filter{first}
+	for parser.keyword("or") {
+		filter, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return &api.Filter{Node: &api.Filter_Or{Or: &api.FilterGroup{Filters: filters}}}, nil
+}
+
+func (parser *filterParser) parseAnd() (*api.Filter, error) {
+	first, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []*api.Filter{first}
+	for parser.keyword("and") {
+		filter, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return &api.Filter{Node: &api.Filter_And{And: &api.FilterGroup{Filters: filters}}}, nil
+}
+
+func (parser *filterParser) parseNot() (*api.Filter, error) {
+	if parser.keyword("not") {
+		filter, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &api.Filter{Node: &api.Filter_Not{Not: filter}}, nil
+	}
+
+	if parser.peek().kind == filterTokenOpen {
+		parser.next()
+		filter, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token := parser.next(); token.kind != filterTokenClose {
+			return nil, fmt.Errorf("expected ) at position %d, got %q", token.position, token.text)
+		}
+		return filter, nil
+	}
+
+	return parser.parseCondition()
+}
+
+func (parser *filterParser) parseCondition() (*api.Filter, error) {
+	columnToken := parser.next()
+	if columnToken.kind != filterTokenWord && columnToken.kind != filterTokenQuotedName {
+		return nil, fmt.Errorf("expected column name at position %d, got %q", columnToken.position, columnToken.text)
+	}
+
+	condition := &api.Condition{Column: columnToken.text}
+	operatorToken := parser.peek()
+
+	switch {
+	case operatorToken.kind == filterTokenOperator:
+		parser.next()
+		condition.Operator = comparisonFilterOperators[operatorToken.text]
+		value, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = []*api.Value{value}
+
+	case parser.keyword("in"):
+		condition.Operator = api.FilterOperator_IN
+		values, err := parser.parseList()
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = values
+
+	case parser.keyword("not"):
+		if err := parser.expectKeyword("in"); err != nil {
+			return nil, err
+		}
+		condition.Operator = api.FilterOperator_NOT_IN
+		values, err := parser.parseList()
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = values
+
+	case parser.keyword("between"):
+		condition.Operator = api.FilterOperator_BETWEEN
+		lower, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expectKeyword("and"); err != nil {
+			return nil, err
+		}
+		upper, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		condition.Values = []*api.Value{lower, upper}
+
+	case parser.keyword("is"):
+		condition.Operator = api.FilterOperator_IS_NULL
+		if parser.keyword("not") {
+			condition.Operator = api.FilterOperator_IS_NOT_NULL
+		}
+		if err := parser.expectKeyword("null"); err != nil {
+			return nil, err
+		}
+
+	case parser.keyword("prefix"), parser.keyword("ilike"):
+		condition.Operator = api.FilterOperator_PREFIX
+		if strings.EqualFold(operatorToken.text, "ilike") {
+			condition.Operator = api.FilterOperator_ILIKE
+		}
+		token := parser.next()
+		if token.kind != filterTokenString {
+			return nil, fmt.Errorf("%s expects a quoted string at position %d", strings.ToUpper(operatorToken.text), token.position)
+		}
+		condition.Values = []*api.Value{{Kind: &api.Value_StringValue{StringValue: token.text}}}
+
+	default:
+		return nil, fmt.Errorf("expected operator after %q at position %d, got %q",
+			columnToken.text, operatorToken.position, operatorToken.text)
+	}
+
+	return &api.Filter{Node: &api.Filter_Condition{Condition: condition}}, nil
+}
+
+// comparisonFilterOperators операторы сравнения выражения фильтра
+var comparisonFilterOperators = map[string]api.FilterOperator{
+	"=":  api.FilterOperator_EQ,
+	"!=": api.FilterOperator_NE,
+	"<>": api.FilterOperator_NE,
+	"<":  api.FilterOperator_LT,
+	"<=": api.FilterOperator_LE,
+	">":  api.FilterOperator_GT,
+	">=": api.FilterOperator_GE,
+}
+
+// parseList разбирает список значений IN: ( литерал, ... )
+func (parser *filterParser) parseList() ([]*api.Value, error) {
+	if token := parser.next(); token.kind != filterTokenOpen {
+		return nil, fmt.Errorf("expected ( at position %d, got %q", token.position, token.text)
+	}
+
+	var values []*api.Value
+	for {
+		value, err := parser.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		token := parser.next()
+		switch token.kind {
+		case filterTokenComma:
+			continue
+		case filterTokenClose:
+			return values, nil
+		default:
+			return nil, fmt.Errorf("expected , or ) at position %d, got %q", token.position, token.text)
+		}
+	}
+}
+
+func (parser *filterParser) parseLiteral() (*api.Value, error) {
+	token := parser.next()
+	switch token.kind {
+	case filterTokenString:
+		return &api.Value{Kind: &api.Value_StringValue{StringValue: token.text}}, nil
+	case filterTokenNumber:
+		if integer, err := strconv.ParseInt(token.text, 10, 64); err == nil {
+			return &api.Value{Kind: &api.Value_Int64Value{Int64Value: integer}}, nil
+		}
+		// Дробное или слишком большое для int64 число передается текстом numeric
+		if _, err := strconv.ParseFloat(token.text, 64); err != nil && errors.Is(err, strconv.ErrSyntax) {
+			return nil, fmt.Errorf("invalid number %q at position %d", token.text, token.position)
+		}
+		return &api.Value{Kind: &api.Value_DecimalValue{DecimalValue: token.text}}, nil
+	case filterTokenWord:
+		switch strings.ToLower(token.text) {
+		case "true":
+			return &api.Value{Kind: &api.Value_BoolValue{BoolValue: true}}, nil
+		case "false":
+			return &api.Value{Kind: &api.Value_BoolValue{BoolValue: false}}, nil
+		}
+	}
+	return nil, fmt.Errorf("expected value at position %d, got %q", token.position, token.text)
+}
+
+// tokenizeFilter разбивает выражение фильтра на лексемы
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	runes := []rune(expression)
+	var tokens []filterToken
+
+	for position := 0; position < len(runes); {
+		character := runes[position]
+		start := position
+
+		switch {
+		case unicode.IsSpace(character):
+			position++
+			continue
+
+		case character == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenOpen, text: "(", position: start})
+			position++
+
+		case character == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenClose, text: ")", position: start})
+			position++
+
+		case character == ',':
+			tokens = append(tokens, filterToken{kind: filterTokenComma, text: ",", position: start})
+			position++
+
+		case character == '\'' || character == '"':
+			// Строка или имя в кавычках; кавычка внутри удваивается
+			var text strings.Builder
+			position++
+			for {
+				if position >= len(runes) {
+					return nil, fmt.Errorf("unterminated quote at position %d", start)
+				}
+				if runes[position] == character {
+					if position+1 < len(runes) && runes[position+1] == character {
+						text.WriteRune(character)
+						position += 2
+						continue
+					}
+					position++
+					break
+				}
+				text.WriteRune(runes[position])
+				position++
+			}
+			kind := filterTokenString
+			if character == '"' {
+				kind = filterTokenQuotedName
+			}
+			tokens = append(tokens, filterToken{kind: kind, text: text.String(), position: start})
+
+		case strings.ContainsRune("=!<>", character):
+			position++
+			if position < len(runes) && (runes[position] == '=' || (character == '<' && runes[position] == '>')) {
+				position++
+			}
+			operator := string(runes[start:position])
+			if _, known := comparisonFilterOperators[operator]; !known {
+				return nil, fmt.Errorf("unknown operator %q at position %d", operator, start)
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenOperator, text: operator, position: start})
+
+		case unicode.IsDigit(character) || (character == '-' && position+1 < len(runes) && unicode.IsDigit(runes[position+1])):
+			position++
+			for position < len(runes) && (unicode.IsDigit(runes[position]) || strings.ContainsRune(".eE", runes[position]) ||
+				(strings.ContainsRune("+-", runes[position]) && strings.ContainsRune("eE", runes[position-1]))) {
+				position++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenNumber, text: string(runes[start:position]), position: start})
+
+		case unicode.IsLetter(character) || character == '_':
+			for position < len(runes) && (unicode.IsLetter(runes[position]) || unicode.IsDigit(runes[position]) || runes[position] == '_') {
+				position++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenWord, text: string(runes[start:position]), position: start})
+
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", character, start)
+		}
+	}
+
+	return append(tokens, filterToken{kind: filterTokenEnd, text: "end of filter", position: len(runes)}), nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"industrialregistrysystem/base/api"
+)
+
+// describeFilter компактная запись дерева условий для сравнения в тестах:
+// OR(...), AND(...), NOT(...) и условия вида колонка ОПЕРАТОР значения
+func describeFilter(filter *api.Filter) string {
+	switch node := filter.GetNode().(type) {
+	case *api.Filter_Condition:
+		parts := []string{node.Condition.Column, node.Condition.Operator.String()}
+		for _, value := range node.Condition.Values {
+			parts = append(parts, describeValue(value))
+		}
+		return strings.Join(parts, " ")
+	case *api.Filter_And:
+		return "AND(" + describeGroup(node.And) + ")"
+	case *api.Filter_Or:
+		return "OR(" + describeGroup(node.Or) + ")"
+	case *api.Filter_Not:
+		return "NOT(" + describeFilter(node.Not) + ")"
+	default:
+		return "<empty>"
+	}
+}
+
+func describeGroup(group *api.FilterGroup) string {
+	parts := make([]string, 0, len(group.Filters))
+	for _, filter := range group.Filters {
+		parts = append(parts, describeFilter(filter))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeValue значение с типом: строка в кавычках, int64 как есть, numeric с префиксом
+func describeValue(value *api.Value) string {
+	switch kind := value.GetKind().(type) {
+	case *api.Value_StringValue:
+		return fmt.Sprintf("%q", kind.StringValue)
+	case *api.Value_Int64Value:
+		return fmt.Sprintf("%d", kind.Int64Value)
+	case *api.Value_DecimalValue:
+		return "numeric:" + kind.DecimalValue
+	case *api.Value_BoolValue:
+		return fmt.Sprintf("%t", kind.BoolValue)
+	default:
+		return fmt.Sprintf("%T", kind)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+	}{
+		{
+			name:       "AND binds tighter than OR",
+			expression: "a = 1 OR b = 2 AND c = 3",
+			want:       "OR(a EQ 1, AND(b EQ 2, c EQ 3))",
+		},
+		{
+			name:       "NOT binds tighter than AND",
+			expression: "NOT a = 1 AND b = 2",
+			want:       "AND(NOT(a EQ 1), b EQ 2)",
+		},
+		{
+			name:       "NOT applies to a parenthesized expression",
+			expression: "not (a = 1 or b = 2)",
+			want:       "NOT(OR(a EQ 1, b EQ 2))",
+		},
+		{
+			name:       "parentheses override precedence",
+			expression: "(a = 1 OR b = 2) AND c = 3",
+			want:       "AND(OR(a EQ 1, b EQ 2), c EQ 3)",
+		},
+		{
+			name:       "BETWEEN takes its own AND inside an AND chain",
+			expression: "revenue BETWEEN 10 AND 20 AND district = 'Северный'",
+			want:       `AND(revenue BETWEEN 10 20, district EQ "Северный")`,
+		},
+		{
+			name:       "BETWEEN inside OR",
+			expression: "a = 1 OR b BETWEEN 1 AND 2 AND c IS NULL",
+			want:       "OR(a EQ 1, AND(b BETWEEN 1 2, c IS_NULL))",
+		},
+		{
+			name:       "IN, NOT IN and IS NOT NULL",
+			expression: "a IN (1, 'x') AND b NOT IN (2) AND c IS NOT NULL",
+			want:       `AND(a IN 1 "x", b NOT_IN 2, c IS_NOT_NULL)`,
+		},
+		{
+			name:       "comparison operators",
+			expression: "a != 1 AND b <> 2 AND c <= 3 AND d >= 4 AND e < 5 AND f > 6",
+			want:       "AND(a NE 1, b NE 2, c LE 3, d GE 4, e LT 5, f GT 6)",
+		},
+		{
+			name:       "PREFIX and ILIKE",
+			expression: "name PREFIX 'ООО' OR name ilike '%завод%'",
+			want:       `OR(name PREFIX "ООО", name ILIKE "%завод%")`,
+		},
+		{
+			name:       "doubled single quote inside a string",
+			expression: "name = 'O''Brien'",
+			want:       `name EQ "O'Brien"`,
+		},
+		{
+			name:       "doubled double quote inside a column name",
+			expression: `"a""b" = TRUE`,
+			want:       `a"b EQ true`,
+		},
+		{
+			name:       "largest int64 stays an integer",
+			expression: "a = 9223372036854775807",
+			want:       "a EQ 9223372036854775807",
+		},
+		{
+			name:       "int64 overflow falls back to numeric",
+			expression: "a = 9223372036854775808",
+			want:       "a EQ numeric:9223372036854775808",
+		},
+		{
+			name:       "negative int64 overflow falls back to numeric",
+			expression: "a > -99999999999999999999",
+			want:       "a GT numeric:-99999999999999999999",
+		},
+		{
+			name:       "fractions and exponents are numeric",
+			expression: "a IN (1.50, 2e-3)",
+			want:       "a IN numeric:1.50 numeric:2e-3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := parseFilter(test.expression)
+			if err != nil {
+				t.Fatalf("parseFilter(%q) error: %v", test.expression, err)
+			}
+			if got := describeFilter(filter); got != test.want {
+				t.Errorf("parseFilter(%q) = %s, want %s", test.expression, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		message    string // Фрагмент ожидаемого текста ошибки
+	}{
+		{name: "unterminated string", expression: "name = 'O''Brien", message: "unterminated quote at position 7"},
+		{name: "invalid number", expression: "a = 1.2.3", message: `invalid number "1.2.3"`},
+		{name: "BETWEEN without AND", expression: "a BETWEEN 1 OR 2", message: "expected AND"},
+		{name: "missing operator", expression: "a 1", message: `expected operator after "a"`},
+		{name: "trailing tokens", expression: "a = 1 b", message: `unexpected "b" at position 6`},
+		{name: "unclosed parenthesis", expression: "(a = 1", message: "expected )"},
+		{name: "unknown operator", expression: "a == 1", message: `unknown operator "==" at position 2`},
+		{name: "PREFIX with a number", expression: "a PREFIX 1", message: "PREFIX expects a quoted string"},
+		{name: "empty IN list", expression: "a IN ()", message: "expected value"},
+		{name: "unexpected character", expression: "a = 1; b = 2", message: "unexpected character ';'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := parseFilter(test.expression)
+			if err == nil {
+				t.Fatalf("parseFilter(%q) = %s, want error", test.expression, describeFilter(filter))
+			}
+			if !strings.Contains(err.Error(), test.message) {
+				t.Errorf("parseFilter(%q) error %q, want it to contain %q", test.expression, err, test.message)
+			}
+		})
+	}
+}
@@ -4,6 +4,10 @@ go 1.25.3
 
 replace industrialregistrysystem/base/api => ../base/api
 
+replace industrialregistrysystem/base/tlsreload => ../base/tlsreload
+
+replace industrialregistrysystem/base/config => ../base/config
+
 require (
 	github.com/gin-gonic/gin v1.11.0
 	google.golang.org/grpc v1.76.0
@@ -42,5 +46,8 @@ require (
 	golang.org/x/tools v0.34.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 	industrialregistrysystem/base/api v0.0.0
+	industrialregistrysystem/base/config v0.0.0
+	industrialregistrysystem/base/tlsreload v0.0.0
 )
@@ -5,41 +5,53 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 	"industrialregistrysystem/base/api"
+	"industrialregistrysystem/base/config"
+	"industrialregistrysystem/base/tlsreload"
 )
 
 type AdminService struct {
-	dataClient api.DataServiceClient
-	conn       *grpc.ClientConn
+	dataClient       api.DataServiceClient
+	managementClient api.ManagementServiceClient
+	conn             *grpc.ClientConn
+	configuration    *Config
 }
 
-func NewAdminService() *AdminService {
+func NewAdminService(configuration *Config) *AdminService {
 	// Загружаем TLS credentials для клиента
-	tlsCredentials, err := loadTLSCredentials()
+	tlsCredentials, err := loadTLSCredentials(configuration.TLS)
 	if err != nil {
 		log.Fatalf("❌ Failed to load TLS credentials: %v", err)
 	}
 
 	// Создаем gRPC соединение с TLS
-	conn, err := grpc.Dial("localhost:5051", grpc.WithTransportCredentials(tlsCredentials))
+	conn, err := grpc.Dial(configuration.MainServiceAddress, grpc.WithTransportCredentials(tlsCredentials))
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to main service: %v", err)
 	}
 
 	return &AdminService{
-		dataClient: api.NewDataServiceClient(conn),
-		conn:       conn,
+		dataClient:       api.NewDataServiceClient(conn),
+		managementClient: api.NewManagementServiceClient(conn),
+		conn:             conn,
+		configuration:    configuration,
 	}
 }
 
@@ -58,6 +70,8 @@ type ResponseState struct {
 }
 
 func (s *AdminService) StartRESTServer() {
+	// Числа из JSON тела остаются json.Number: большие целые и numeric не теряют точность
+	binding.EnableDecoderUseNumber = true
 	router := gin.Default()
 
 	// CORS middleware
@@ -75,6 +89,7 @@ func (s *AdminService) StartRESTServer() {
 		crudGroup.DELETE("/:id", s.deleteEntity) // DELETE
 		crudGroup.GET("", s.listEntities)        // LIST
 		crudGroup.GET("/search", s.searchEntities) // SEARCH
+		crudGroup.GET("/export", s.exportEntities) // EXPORT: NDJSON или CSV
 	}
 
 	// Пакетные операции
@@ -122,14 +137,45 @@ func (s *AdminService) StartRESTServer() {
 		adminGroup.POST("/cache/clear", s.clearCache)
 		adminGroup.GET("/cache/metrics", s.getCacheMetrics)
 		adminGroup.DELETE("/cache/:key", s.removeFromCache)
+		adminGroup.GET("/databases", s.listDatabases)
+		// ID сервиса БД содержит "/" (личность сертификата/экземпляр)
+		adminGroup.DELETE("/databases/*serviceID", s.disconnectDatabase)
+		adminGroup.GET("/schema", s.getSchema)
+		adminGroup.POST("/schema/refresh", s.refreshSchema)
 	}
 
-	log.Println("🔧 Admin Service (REST API) running on :8080")
-	log.Println("   Connected to mainservice: localhost:5051")
+	log.Printf("🔧 Admin Service (REST API) running on %s", s.configuration.ListenAddress)
+	log.Printf("   Connected to mainservice: %s", s.configuration.MainServiceAddress)
 	log.Println("   TLS: Enabled (mutual authentication)")
-	log.Println("   Available tables: organizations, users, invites, forms, etc.")
+	log.Println("   Available tables: see GET /admin/schema")
+
+	server := &http.Server{
+		Addr:    s.configuration.ListenAddress,
+		Handler: router,
+	}
+
+	// По SIGINT/SIGTERM перестаем принимать соединения и дожидаемся выполняемых запросов
+	shutdownComplete := make(chan struct{})
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		received := <-signals
+		log.Printf("🛑 Received %s, shutting down (timeout %s)", received, s.configuration.ShutdownTimeout)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.configuration.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("⚠️ HTTP requests did not finish in time: %v", err)
+			server.Close()
+		}
+		close(shutdownComplete)
+	}()
 
-	router.Run(":8080")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("❌ Admin REST server failed: %v", err)
+	}
+	<-shutdownComplete
+	log.Println("👋 Admin Service stopped")
 }
 
 func corsMiddleware() gin.HandlerFunc {
@@ -209,29 +255,12 @@ func (s *AdminService) createEntity(c *gin.Context) {
 	}
 
 	// Преобразуем данные в Entity
-	entity := &api.Entity{
-		TableName: tableName,
-		Fields:    make(map[string]string),
-	}
-
-	// Заполняем поля
-	for key, value := range entityData {
-		switch v := value.(type) {
-		case string:
-			entity.Fields[key] = v
-		case int, int32, int64, float32, float64, bool:
-			entity.Fields[key] = fmt.Sprintf("%v", v)
-		default:
-			// Для сложных типов сериализуем в JSON
-			jsonData, err := json.Marshal(v)
-			if err != nil {
-				state.Status = "error"
-				state.Error = fmt.Sprintf("Failed to serialize field %s: %v", key, err)
-				c.JSON(http.StatusBadRequest, state)
-				return
-			}
-			entity.Fields[key] = string(jsonData)
-		}
+	entity, err := entityFromJSON(tableName, entityData)
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusBadRequest, state)
+		return
 	}
 
 	resp, err := s.dataClient.Create(context.Background(), &api.CreateRequest{
@@ -242,7 +271,7 @@ func (s *AdminService) createEntity(c *gin.Context) {
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -290,7 +319,7 @@ func (s *AdminService) getEntity(c *gin.Context) {
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -330,29 +359,12 @@ func (s *AdminService) updateEntity(c *gin.Context) {
 	}
 
 	// Преобразуем данные в Entity
-	entity := &api.Entity{
-		TableName: tableName,
-		Fields:    make(map[string]string),
-	}
-
-	// Заполняем поля
-	for key, value := range entityData {
-		switch v := value.(type) {
-		case string:
-			entity.Fields[key] = v
-		case int, int32, int64, float32, float64, bool:
-			entity.Fields[key] = fmt.Sprintf("%v", v)
-		default:
-			// Для сложных типов сериализуем в JSON
-			jsonData, err := json.Marshal(v)
-			if err != nil {
-				state.Status = "error"
-				state.Error = fmt.Sprintf("Failed to serialize field %s: %v", key, err)
-				c.JSON(http.StatusBadRequest, state)
-				return
-			}
-			entity.Fields[key] = string(jsonData)
-		}
+	entity, err := entityFromJSON(tableName, entityData)
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusBadRequest, state)
+		return
 	}
 
 	resp, err := s.dataClient.Update(context.Background(), &api.UpdateRequest{
@@ -364,7 +376,7 @@ func (s *AdminService) updateEntity(c *gin.Context) {
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -406,7 +418,7 @@ func (s *AdminService) deleteEntity(c *gin.Context) {
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -440,6 +452,15 @@ func (s *AdminService) listEntities(c *gin.Context) {
 		}
 	}
 
+	// Дерево условий из параметра filter, синтаксис описан в filter.go
+	filter, err := filterFromQuery(c)
+	if err != nil {
+		state.Status = "error"
+		state.Error = "Invalid filter: " + err.Error()
+		c.JSON(http.StatusBadRequest, state)
+		return
+	}
+
 	resp, err := s.dataClient.List(context.Background(), &api.ListRequest{
 		TableName: tableName,
 		Page:      int32(page),
@@ -447,12 +468,16 @@ func (s *AdminService) listEntities(c *gin.Context) {
 		OrderBy:   orderBy,
 		OrderDesc: orderDesc,
 		Filters:   filters,
+		Filter:    filter,
+		// Токен из next_page_token предыдущего ответа; с ним page не используется
+		PageToken:      c.Query("page_token"),
+		SkipTotalCount: c.Query("skip_total_count") == "true",
 	})
 
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -484,18 +509,28 @@ func (s *AdminService) searchEntities(c *gin.Context) {
 		fields = strings.Split(fieldsStr, ",")
 	}
 
+	// Дерево условий сужает результат поиска
+	filter, err := filterFromQuery(c)
+	if err != nil {
+		state.Status = "error"
+		state.Error = "Invalid filter: " + err.Error()
+		c.JSON(http.StatusBadRequest, state)
+		return
+	}
+
 	resp, err := s.dataClient.Search(context.Background(), &api.SearchRequest{
 		TableName: tableName,
 		Query:     query,
 		Fields:    fields,
 		Limit:     int32(limit),
 		Offset:    int32(offset),
+		Filter:    filter,
 	})
 
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -530,28 +565,12 @@ func (s *AdminService) batchCreate(c *gin.Context) {
 
 	entities := make([]*api.Entity, len(entitiesData))
 	for i, entityData := range entitiesData {
-		entity := &api.Entity{
-			TableName: tableName,
-			Fields:    make(map[string]string),
-		}
-
-		// Заполняем поля
-		for key, value := range entityData {
-			switch v := value.(type) {
-			case string:
-				entity.Fields[key] = v
-			case int, int32, int64, float32, float64, bool:
-				entity.Fields[key] = fmt.Sprintf("%v", v)
-			default:
-				jsonData, err := json.Marshal(v)
-				if err != nil {
-					state.Status = "error"
-					state.Error = fmt.Sprintf("Failed to serialize field %s: %v", key, err)
-					c.JSON(http.StatusBadRequest, state)
-					return
-				}
-				entity.Fields[key] = string(jsonData)
-			}
+		entity, err := entityFromJSON(tableName, entityData)
+		if err != nil {
+			state.Status = "error"
+			state.Error = err.Error()
+			c.JSON(http.StatusBadRequest, state)
+			return
 		}
 		entities[i] = entity
 	}
@@ -564,7 +583,7 @@ func (s *AdminService) batchCreate(c *gin.Context) {
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -595,28 +614,12 @@ func (s *AdminService) batchUpdate(c *gin.Context) {
 
 	entities := make([]*api.Entity, len(entitiesData))
 	for i, entityData := range entitiesData {
-		entity := &api.Entity{
-			TableName: tableName,
-			Fields:    make(map[string]string),
-		}
-
-		// Заполняем поля
-		for key, value := range entityData {
-			switch v := value.(type) {
-			case string:
-				entity.Fields[key] = v
-			case int, int32, int64, float32, float64, bool:
-				entity.Fields[key] = fmt.Sprintf("%v", v)
-			default:
-				jsonData, err := json.Marshal(v)
-				if err != nil {
-					state.Status = "error"
-					state.Error = fmt.Sprintf("Failed to serialize field %s: %v", key, err)
-					c.JSON(http.StatusBadRequest, state)
-					return
-				}
-				entity.Fields[key] = string(jsonData)
-			}
+		entity, err := entityFromJSON(tableName, entityData)
+		if err != nil {
+			state.Status = "error"
+			state.Error = err.Error()
+			c.JSON(http.StatusBadRequest, state)
+			return
 		}
 		entities[i] = entity
 	}
@@ -629,7 +632,7 @@ func (s *AdminService) batchUpdate(c *gin.Context) {
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -678,14 +681,16 @@ func (s *AdminService) listOrganizations(c *gin.Context) {
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
 
 	resp, err := s.dataClient.ListOrganizations(context.Background(), &api.ListOrganizationsRequest{
-		Page:     int32(page),
-		PageSize: int32(pageSize),
+		Page:           int32(page),
+		PageSize:       int32(pageSize),
+		PageToken:      c.Query("page_token"),
+		SkipTotalCount: c.Query("skip_total_count") == "true",
 	})
 
 	if err != nil {
 		state.Status = "error"
 		state.Error = err.Error()
-		c.JSON(http.StatusInternalServerError, state)
+		c.JSON(httpStatusFromError(err), state)
 		return
 	}
 
@@ -819,10 +824,7 @@ func (s *AdminService) updateUser(c *gin.Context) {
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Phone:     req.Phone,
-	}
-
-	if req.IsActive != nil {
-		updateReq.IsActive = *req.IsActive
+		IsActive:  req.IsActive,
 	}
 
 	resp, err := s.dataClient.UpdateUser(context.Background(), updateReq)
@@ -1056,37 +1058,197 @@ func (s *AdminService) getStaffData(c *gin.Context) {
 // ============================================================================
 
 func (s *AdminService) clearCache(c *gin.Context) {
-	state := &ResponseState{
-		Status:    "success",
-		Data:      "Cache cleared successfully",
-		Timestamp: time.Now(),
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	resp, err := s.managementClient.ClearCache(context.Background(), &api.ClearCacheRequest{})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, state)
+		return
+	}
+
+	state.Status = "success"
+	state.Data = map[string]interface{}{
+		"message":         "Cache cleared successfully",
+		"removed_entries": resp.RemovedEntries,
 	}
 	c.JSON(http.StatusOK, state)
 }
 
 func (s *AdminService) getCacheMetrics(c *gin.Context) {
-	state := &ResponseState{
-		Status: "success",
-		Data: map[string]interface{}{
-			"level1_size": 150,
-			"level2_size": 300,
-			"level3_size": 550,
-			"total_size":  1000,
-			"max_size":    1000,
-			"hit_rate":    0.85,
-		},
-		Timestamp: time.Now(),
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	resp, err := s.managementClient.GetCacheMetrics(context.Background(), &api.GetCacheMetricsRequest{})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, state)
+		return
+	}
+
+	state.Status = "success"
+	state.Data = map[string]interface{}{
+		"type":                   resp.Type,
+		"level1_size":            resp.Level1Size,
+		"level2_size":            resp.Level2Size,
+		"level3_size":            resp.Level3Size,
+		"total_size":             resp.TotalSize,
+		"max_size":               resp.MaxSize,
+		"hit_rate":               resp.HitRate,
+		"hit_count":              resp.HitCount,
+		"miss_count":             resp.MissCount,
+		"eviction_count":         resp.EvictionCount,
+		"average_access_time_ns": resp.AverageAccessTimeNs,
+		"used_bytes":             resp.UsedBytes,
+		"max_bytes":              resp.MaxBytes,
 	}
 	c.JSON(http.StatusOK, state)
 }
 
 func (s *AdminService) removeFromCache(c *gin.Context) {
-	key := c.Param("key")
-	state := &ResponseState{
-		Status:    "success",
-		Data:      "Cache key removed: " + key,
-		Timestamp: time.Now(),
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	resp, err := s.managementClient.RemoveFromCache(context.Background(), &api.RemoveFromCacheRequest{
+		Key: c.Param("key"),
+	})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, state)
+		return
 	}
+
+	state.Status = "success"
+	state.Data = "Cache key removed: " + resp.Key
+	c.JSON(http.StatusOK, state)
+}
+
+func (s *AdminService) listDatabases(c *gin.Context) {
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	resp, err := s.managementClient.ListDatabases(context.Background(), &api.ListDatabasesRequest{})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, state)
+		return
+	}
+
+	databases := make([]map[string]interface{}, 0, len(resp.Databases))
+	for _, database := range resp.Databases {
+		// До первого успешного health_check времени последней проверки нет
+		var lastHeartbeatAt interface{}
+		if database.LastHeartbeatAt != nil {
+			lastHeartbeatAt = database.LastHeartbeatAt.AsTime()
+		}
+		databases = append(databases, map[string]interface{}{
+			"service_id":         database.ServiceId,
+			"instance_id":        database.InstanceId,
+			"dns_name":           database.DnsName,
+			"cert_serial":        database.CertSerial,
+			"peer_address":       database.PeerAddress,
+			"connected_at":       database.ConnectedAt.AsTime(),
+			"in_flight":          database.InFlight,
+			"queue_depth":        database.QueueDepth,
+			"healthy":            database.Healthy,
+			"average_latency_ms": database.AverageLatencyMs,
+			"last_heartbeat_at":  lastHeartbeatAt,
+			"missed_heartbeats":  database.MissedHeartbeats,
+			"health":             database.Health,
+		})
+	}
+
+	state.Status = "success"
+	state.Data = databases
+	c.JSON(http.StatusOK, state)
+}
+
+func (s *AdminService) disconnectDatabase(c *gin.Context) {
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	serviceID := strings.TrimPrefix(c.Param("serviceID"), "/")
+	if serviceID == "" {
+		state.Status = "error"
+		state.Error = "Database service ID is required"
+		c.JSON(http.StatusBadRequest, state)
+		return
+	}
+
+	_, err := s.managementClient.DisconnectDatabase(context.Background(), &api.DisconnectDatabaseRequest{
+		ServiceId: serviceID,
+	})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		if status.Code(err) == codes.NotFound {
+			c.JSON(http.StatusNotFound, state)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, state)
+		return
+	}
+
+	state.Status = "success"
+	state.Data = "Database disconnected: " + serviceID
+	c.JSON(http.StatusOK, state)
+}
+
+// getSchema возвращает таблицы и колонки, доступные универсальным CRUD операциям
+func (s *AdminService) getSchema(c *gin.Context) {
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	resp, err := s.managementClient.GetSchema(context.Background(), &api.GetSchemaRequest{})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(httpStatusFromError(err), state)
+		return
+	}
+
+	state.Status = "success"
+	state.Data = map[string]interface{}{
+		"service_id": resp.ServiceId,
+		"schema":     mapSchemaToResponse(resp.Schema),
+	}
+	c.JSON(http.StatusOK, state)
+}
+
+// refreshSchema перечитывает схему на всех БД, например после миграции
+func (s *AdminService) refreshSchema(c *gin.Context) {
+	state := &ResponseState{Status: "processing", Timestamp: time.Now()}
+
+	resp, err := s.managementClient.RefreshSchema(context.Background(), &api.RefreshSchemaRequest{})
+
+	if err != nil {
+		state.Status = "error"
+		state.Error = err.Error()
+		c.JSON(http.StatusInternalServerError, state)
+		return
+	}
+
+	databases := make([]map[string]interface{}, 0, len(resp.Databases))
+	for _, database := range resp.Databases {
+		result := map[string]interface{}{
+			"service_id": database.ServiceId,
+		}
+		if database.Error != "" {
+			result["error"] = database.Error
+		} else {
+			result["tables"] = len(database.Schema.Tables)
+			result["loaded_at"] = database.Schema.LoadedAt.AsTime()
+		}
+		databases = append(databases, result)
+	}
+
+	state.Status = "success"
+	state.Data = databases
 	c.JSON(http.StatusOK, state)
 }
 
@@ -1094,9 +1256,99 @@ func (s *AdminService) removeFromCache(c *gin.Context) {
 // ВСПОМОГАТЕЛЬНЫЕ ФУНКЦИИ
 // ============================================================================
 
+// entityFromJSON преобразует JSON объект запроса в Entity: текстовые fields для старых
+// сервисов БД и типизированные values. Числа приходят как json.Number и передаются без
+// округления: целые как int64, остальные как decimal.
+func entityFromJSON(tableName string, entityData map[string]interface{}) (*api.Entity, error) {
+	entity := &api.Entity{
+		TableName: tableName,
+		Fields:    make(map[string]string),
+		Values:    make(map[string]*api.Value),
+	}
+
+	for key, value := range entityData {
+		switch v := value.(type) {
+		case nil:
+			entity.Fields[key] = "null"
+			entity.Values[key] = api.NullValue()
+		case string:
+			entity.Fields[key] = v
+			entity.Values[key] = &api.Value{Kind: &api.Value_StringValue{StringValue: v}}
+		case bool:
+			entity.Fields[key] = strconv.FormatBool(v)
+			entity.Values[key] = &api.Value{Kind: &api.Value_BoolValue{BoolValue: v}}
+		case json.Number:
+			entity.Fields[key] = v.String()
+			if integer, err := v.Int64(); err == nil {
+				entity.Values[key] = &api.Value{Kind: &api.Value_Int64Value{Int64Value: integer}}
+			} else {
+				entity.Values[key] = &api.Value{Kind: &api.Value_DecimalValue{DecimalValue: v.String()}}
+			}
+		default:
+			// Для сложных типов сериализуем в JSON
+			jsonData, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize field %s: %v", key, err)
+			}
+			entity.Fields[key] = string(jsonData)
+			entity.Values[key] = &api.Value{Kind: &api.Value_JsonValue{JsonValue: string(jsonData)}}
+		}
+	}
+	return entity, nil
+}
+
+// valueToJSON представляет типизированное значение в JSON ответе: numeric - строкой
+// без потери точности, дата - YYYY-MM-DD, json - вложенным документом
+func valueToJSON(value *api.Value) interface{} {
+	switch kind := value.GetKind().(type) {
+	case *api.Value_BoolValue:
+		return kind.BoolValue
+	case *api.Value_Int64Value:
+		return kind.Int64Value
+	case *api.Value_DoubleValue:
+		return kind.DoubleValue
+	case *api.Value_DecimalValue:
+		return kind.DecimalValue
+	case *api.Value_TimestampValue:
+		return kind.TimestampValue.AsTime()
+	case *api.Value_DateValue:
+		return kind.DateValue.Text()
+	case *api.Value_BytesValue:
+		return kind.BytesValue
+	case *api.Value_JsonValue:
+		return json.RawMessage(kind.JsonValue)
+	case *api.Value_StringValue:
+		return kind.StringValue
+	default:
+		return nil
+	}
+}
+
+// httpStatusFromError сопоставляет gRPC статус ошибки mainservice с HTTP статусом ответа
+func httpStatusFromError(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusUnprocessableEntity
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // isReservedQueryParam проверяет, является ли параметр зарезервированным
 func isReservedQueryParam(param string) bool {
-	reserved := []string{"page", "page_size", "order_by", "order", "q", "limit", "offset", "fields", "soft"}
+	reserved := []string{"page", "page_size", "order_by", "order", "q", "limit", "offset", "fields", "soft", "filter", "page_token", "skip_total_count", "format", "chunk_size"}
 	for _, p := range reserved {
 		if param == p {
 			return true
@@ -1105,6 +1357,27 @@ func isReservedQueryParam(param string) bool {
 	return false
 }
 
+func mapSchemaToResponse(schema *api.SchemaResponse) map[string]interface{} {
+	tables := make(map[string]interface{}, len(schema.Tables))
+	for _, table := range schema.Tables {
+		columns := make([]map[string]interface{}, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			columns = append(columns, map[string]interface{}{
+				"name":      column.Name,
+				"data_type": column.DataType,
+				"nullable":  column.Nullable,
+			})
+		}
+		tables[table.Name] = columns
+	}
+
+	return map[string]interface{}{
+		"schema_name": schema.SchemaName,
+		"loaded_at":   schema.LoadedAt.AsTime(),
+		"tables":      tables,
+	}
+}
+
 func mapEntityToResponse(resp *api.EntityResponse) map[string]interface{} {
 	if resp == nil || resp.Entity == nil {
 		return nil
@@ -1112,10 +1385,31 @@ func mapEntityToResponse(resp *api.EntityResponse) map[string]interface{} {
 
 	return map[string]interface{}{
 		"table_name": resp.TableName,
-		"entity":     resp.Entity,
+		"entity":     mapEntity(resp.Entity),
 	}
 }
 
+// mapEntity возвращает запись с устаревшими текстовыми fields и типизированными values
+func mapEntity(entity *api.Entity) map[string]interface{} {
+	result := map[string]interface{}{
+		"fields": entity.Fields,
+	}
+	if entity.TableName != "" {
+		result["table_name"] = entity.TableName
+	}
+	if len(entity.BinaryFields) > 0 {
+		result["binary_fields"] = entity.BinaryFields
+	}
+	if len(entity.Values) > 0 {
+		values := make(map[string]interface{}, len(entity.Values))
+		for name, value := range entity.Values {
+			values[name] = valueToJSON(value)
+		}
+		result["values"] = values
+	}
+	return result
+}
+
 func mapListToResponse(resp *api.ListResponse) map[string]interface{} {
 	if resp == nil {
 		return nil
@@ -1123,11 +1417,20 @@ func mapListToResponse(resp *api.ListResponse) map[string]interface{} {
 
 	return map[string]interface{}{
 		"table_name":  resp.TableName,
-		"entities":    resp.Entities,
-		"total_count": resp.TotalCount,
-		"page":        resp.Page,
-		"page_size":   resp.PageSize,
+		"entities":    mapEntities(resp.Entities),
+		"total_count":     resp.TotalCount,
+		"page":            resp.Page,
+		"page_size":       resp.PageSize,
+		"next_page_token": resp.NextPageToken,
+	}
+}
+
+func mapEntities(entities []*api.Entity) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, mapEntity(entity))
 	}
+	return result
 }
 
 func mapDeleteToResponse(resp *api.DeleteResponse) map[string]interface{} {
@@ -1174,10 +1477,11 @@ func mapOrganizationsListToResponse(resp *api.ListOrganizationsResponse) map[str
 	}
 
 	return map[string]interface{}{
-		"organizations": resp.Organizations,
-		"total_count":   resp.TotalCount,
-		"page":          resp.Page,
-		"page_size":     resp.PageSize,
+		"organizations":   resp.Organizations,
+		"total_count":     resp.TotalCount,
+		"page":            resp.Page,
+		"page_size":       resp.PageSize,
+		"next_page_token": resp.NextPageToken,
 	}
 }
 
@@ -1237,15 +1541,16 @@ func mapStaffDataToResponse(resp *api.StaffDataResponse) map[string]interface{}
 }
 
 // loadTLSCredentials загружает TLS сертификаты для клиента
-func loadTLSCredentials() (credentials.TransportCredentials, error) {
-	// Загружаем клиентский сертификат
-	clientCertificate, err := tls.LoadX509KeyPair("certs/admin/admin-fullchain.crt", "certs/admin/admin.key")
+func loadTLSCredentials(files config.TLSFiles) (credentials.TransportCredentials, error) {
+	// Загружаем клиентский сертификат; при обновлении файлов он перечитывается без перезапуска
+	certificateReloader, err := tlsreload.NewCertificateReloader(files.Certificate, files.Key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load client certificates: %v", err)
 	}
+	go certificateReloader.Watch(files.ReloadInterval)
 
 	// Загружаем CA сертификат
-	caCertificate, err := os.ReadFile("certs/ca/ca.crt")
+	caCertificate, err := os.ReadFile(files.CA)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CA certificate: %v", err)
 	}
@@ -1257,17 +1562,31 @@ func loadTLSCredentials() (credentials.TransportCredentials, error) {
 
 	// Настраиваем TLS конфигурацию
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{clientCertificate},
-		RootCAs:      certificatePool,
-		ServerName:   "mainservice", // Должно совпадать с DNS Name в сертификате сервера
-		MinVersion:   tls.VersionTLS12,
+		// Сертификат запрашивается при каждом рукопожатии: переподключения используют актуальный
+		GetClientCertificate: certificateReloader.GetClientCertificate,
+		RootCAs:              certificatePool,
+		ServerName:           files.ServerName, // Должно совпадать с DNS Name в сертификате сервера
+		MinVersion:           tls.VersionTLS12,
 	}
 
 	return credentials.NewTLS(tlsConfig), nil
 }
 
 func main() {
-	adminService := NewAdminService()
+	// Загружаем настройки: файл, переменные окружения, флаги
+	configuration := defaultConfig()
+	printConfig, err := config.Load("admin", configuration, os.Args[1:])
+	if err != nil {
+		log.Fatalf("❌ Failed to load configuration: %v", err)
+	}
+	if printConfig {
+		if err := config.Print(os.Stdout, configuration); err != nil {
+			log.Fatalf("❌ Failed to print configuration: %v", err)
+		}
+		return
+	}
+
+	adminService := NewAdminService(configuration)
 	defer adminService.Close()
 
 	adminService.StartRESTServer()
@@ -22,12 +22,97 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type FilterOperator int32
+
+const (
+	FilterOperator_OPERATOR_UNSPECIFIED FilterOperator = 0
+	FilterOperator_EQ                   FilterOperator = 1
+	FilterOperator_NE                   FilterOperator = 2
+	FilterOperator_LT                   FilterOperator = 3
+	FilterOperator_LE                   FilterOperator = 4
+	FilterOperator_GT                   FilterOperator = 5
+	FilterOperator_GE                   FilterOperator = 6
+	FilterOperator_IN                   FilterOperator = 7
+	FilterOperator_NOT_IN               FilterOperator = 8
+	FilterOperator_BETWEEN              FilterOperator = 9
+	FilterOperator_IS_NULL              FilterOperator = 10
+	FilterOperator_IS_NOT_NULL          FilterOperator = 11
+	FilterOperator_PREFIX               FilterOperator = 12 // Строка начинается с заданной (LIKE 'value%', символы % и _ экранируются)
+	FilterOperator_ILIKE                FilterOperator = 13 // Шаблон ILIKE без учета регистра, % и _ задает клиент
+)
+
+// Enum value maps for FilterOperator.
+var (
+	FilterOperator_name = map[int32]string{
+		0:  "OPERATOR_UNSPECIFIED",
+		1:  "EQ",
+		2:  "NE",
+		3:  "LT",
+		4:  "LE",
+		5:  "GT",
+		6:  "GE",
+		7:  "IN",
+		8:  "NOT_IN",
+		9:  "BETWEEN",
+		10: "IS_NULL",
+		11: "IS_NOT_NULL",
+		12: "PREFIX",
+		13: "ILIKE",
+	}
+	FilterOperator_value = map[string]int32{
+		"OPERATOR_UNSPECIFIED": 0,
+		"EQ":                   1,
+		"NE":                   2,
+		"LT":                   3,
+		"LE":                   4,
+		"GT":                   5,
+		"GE":                   6,
+		"IN":                   7,
+		"NOT_IN":               8,
+		"BETWEEN":              9,
+		"IS_NULL":              10,
+		"IS_NOT_NULL":          11,
+		"PREFIX":               12,
+		"ILIKE":                13,
+	}
+)
+
+func (x FilterOperator) Enum() *FilterOperator {
+	p := new(FilterOperator)
+	*p = x
+	return p
+}
+
+func (x FilterOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FilterOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[0].Descriptor()
+}
+
+func (FilterOperator) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[0]
+}
+
+func (x FilterOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FilterOperator.Descriptor instead.
+func (FilterOperator) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{0}
+}
+
 // Базовые сообщения для CRUD операций
 type Entity struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
-	Fields        map[string]string      `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	BinaryFields  map[string][]byte      `protobuf:"bytes,3,rep,name=binary_fields,json=binaryFields,proto3" json:"binary_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TableName    string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	Fields       map[string]string      `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Устаревшее текстовое представление: NULL неотличим от пустой строки
+	BinaryFields map[string][]byte      `protobuf:"bytes,3,rep,name=binary_fields,json=binaryFields,proto3" json:"binary_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Типизированные значения колонок. Сервис БД заполняет их вместе с fields;
+	// в Create/Update значение из values важнее одноименного поля fields.
+	Values        map[string]*Value `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,6 +168,285 @@ func (x *Entity) GetBinaryFields() map[string][]byte {
 	return nil
 }
 
+func (x *Entity) GetValues() map[string]*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Value значение колонки с сохранением типа PostgreSQL
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Value_NullValue
+	//	*Value_BoolValue
+	//	*Value_Int64Value
+	//	*Value_DoubleValue
+	//	*Value_DecimalValue
+	//	*Value_TimestampValue
+	//	*Value_DateValue
+	//	*Value_BytesValue
+	//	*Value_JsonValue
+	//	*Value_StringValue
+	Kind          isValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Value) GetKind() isValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Value) GetNullValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetInt64Value() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Int64Value); ok {
+			return x.Int64Value
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetDecimalValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_DecimalValue); ok {
+			return x.DecimalValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetTimestampValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetDateValue() *Date {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_DateValue); ok {
+			return x.DateValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetJsonValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_JsonValue); ok {
+			return x.JsonValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_NullValue struct {
+	NullValue bool `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,oneof"` // NULL; само значение не используется
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_Int64Value struct {
+	Int64Value int64 `protobuf:"varint,3,opt,name=int64_value,json=int64Value,proto3,oneof"` // smallint, integer, bigint
+}
+
+type Value_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"` // real, double precision
+}
+
+type Value_DecimalValue struct {
+	DecimalValue string `protobuf:"bytes,5,opt,name=decimal_value,json=decimalValue,proto3,oneof"` // numeric без потери точности, например "12345.6700"
+}
+
+type Value_TimestampValue struct {
+	TimestampValue *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp_value,json=timestampValue,proto3,oneof"` // timestamp, timestamp with time zone
+}
+
+type Value_DateValue struct {
+	DateValue *Date `protobuf:"bytes,7,opt,name=date_value,json=dateValue,proto3,oneof"`
+}
+
+type Value_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,8,opt,name=bytes_value,json=bytesValue,proto3,oneof"` // bytea
+}
+
+type Value_JsonValue struct {
+	JsonValue string `protobuf:"bytes,9,opt,name=json_value,json=jsonValue,proto3,oneof"` // json, jsonb: текст документа
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,10,opt,name=string_value,json=stringValue,proto3,oneof"` // text, varchar, uuid и прочие типы в текстовом виде
+}
+
+func (*Value_NullValue) isValue_Kind() {}
+
+func (*Value_BoolValue) isValue_Kind() {}
+
+func (*Value_Int64Value) isValue_Kind() {}
+
+func (*Value_DoubleValue) isValue_Kind() {}
+
+func (*Value_DecimalValue) isValue_Kind() {}
+
+func (*Value_TimestampValue) isValue_Kind() {}
+
+func (*Value_DateValue) isValue_Kind() {}
+
+func (*Value_BytesValue) isValue_Kind() {}
+
+func (*Value_JsonValue) isValue_Kind() {}
+
+func (*Value_StringValue) isValue_Kind() {}
+
+// Date календарная дата без времени и часового пояса
+type Date struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Year          int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32                  `protobuf:"varint,2,opt,name=month,proto3" json:"month,omitempty"` // 1-12
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`     // 1-31
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Date) Reset() {
+	*x = Date{}
+	mi := &file_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Date) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Date) ProtoMessage() {}
+
+func (x *Date) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Date.ProtoReflect.Descriptor instead.
+func (*Date) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Date) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Date) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *Date) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
 type CreateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
@@ -93,7 +457,7 @@ type CreateRequest struct {
 
 func (x *CreateRequest) Reset() {
 	*x = CreateRequest{}
-	mi := &file_api_proto_msgTypes[1]
+	mi := &file_api_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +469,7 @@ func (x *CreateRequest) String() string {
 func (*CreateRequest) ProtoMessage() {}
 
 func (x *CreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[1]
+	mi := &file_api_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +482,7 @@ func (x *CreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateRequest) GetTableName() string {
@@ -146,7 +510,7 @@ type GetRequest struct {
 
 func (x *GetRequest) Reset() {
 	*x = GetRequest{}
-	mi := &file_api_proto_msgTypes[2]
+	mi := &file_api_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -158,7 +522,7 @@ func (x *GetRequest) String() string {
 func (*GetRequest) ProtoMessage() {}
 
 func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[2]
+	mi := &file_api_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +535,7 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetRequest) GetTableName() string {
@@ -206,7 +570,7 @@ type UpdateRequest struct {
 
 func (x *UpdateRequest) Reset() {
 	*x = UpdateRequest{}
-	mi := &file_api_proto_msgTypes[3]
+	mi := &file_api_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -218,7 +582,7 @@ func (x *UpdateRequest) String() string {
 func (*UpdateRequest) ProtoMessage() {}
 
 func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[3]
+	mi := &file_api_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -231,7 +595,7 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateRequest) GetTableName() string {
@@ -266,7 +630,7 @@ type DeleteRequest struct {
 
 func (x *DeleteRequest) Reset() {
 	*x = DeleteRequest{}
-	mi := &file_api_proto_msgTypes[4]
+	mi := &file_api_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -278,7 +642,7 @@ func (x *DeleteRequest) String() string {
 func (*DeleteRequest) ProtoMessage() {}
 
 func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[4]
+	mi := &file_api_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,7 +655,7 @@ func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteRequest) GetTableName() string {
@@ -325,7 +689,7 @@ type DeleteResponse struct {
 
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
-	mi := &file_api_proto_msgTypes[5]
+	mi := &file_api_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +701,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[5]
+	mi := &file_api_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +714,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteResponse) GetSuccess() bool {
@@ -368,20 +732,25 @@ func (x *DeleteResponse) GetAffectedRows() int32 {
 }
 
 type ListRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
-	OrderDesc     bool                   `protobuf:"varint,5,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`
-	Filters       map[string]string      `protobuf:"bytes,6,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TableName string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	Page      int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize  int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	OrderBy   string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderDesc bool                   `protobuf:"varint,5,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`
+	Filters   map[string]string      `protobuf:"bytes,6,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Равенства column = value, объединенные через AND
+	Filter    *Filter                `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`                                                                             // Дерево условий; объединяется с filters через AND
+	// Токен следующей страницы из ListResponse.next_page_token. С токеном page не
+	// используется: страница продолжается после последней строки предыдущей.
+	PageToken      string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	SkipTotalCount bool   `protobuf:"varint,9,opt,name=skip_total_count,json=skipTotalCount,proto3" json:"skip_total_count,omitempty"` // Не считать COUNT(*): total_count в ответе будет -1
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListRequest) Reset() {
 	*x = ListRequest{}
-	mi := &file_api_proto_msgTypes[6]
+	mi := &file_api_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -393,7 +762,7 @@ func (x *ListRequest) String() string {
 func (*ListRequest) ProtoMessage() {}
 
 func (x *ListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[6]
+	mi := &file_api_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +775,7 @@ func (x *ListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListRequest) GetTableName() string {
@@ -451,20 +820,264 @@ func (x *ListRequest) GetFilters() map[string]string {
 	return nil
 }
 
-type SearchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
-	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
-	Fields        []string               `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
 }
 
-func (x *SearchRequest) Reset() {
+func (x *ListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSkipTotalCount() bool {
+	if x != nil {
+		return x.SkipTotalCount
+	}
+	return false
+}
+
+// Filter узел дерева условий: сравнение колонки либо группа AND/OR/NOT
+type Filter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Node:
+	//
+	//	*Filter_Condition
+	//	*Filter_And
+	//	*Filter_Or
+	//	*Filter_Not
+	Node          isFilter_Node `protobuf_oneof:"node"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Filter) GetNode() isFilter_Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *Filter) GetCondition() *Condition {
+	if x != nil {
+		if x, ok := x.Node.(*Filter_Condition); ok {
+			return x.Condition
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetAnd() *FilterGroup {
+	if x != nil {
+		if x, ok := x.Node.(*Filter_And); ok {
+			return x.And
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetOr() *FilterGroup {
+	if x != nil {
+		if x, ok := x.Node.(*Filter_Or); ok {
+			return x.Or
+		}
+	}
+	return nil
+}
+
+func (x *Filter) GetNot() *Filter {
+	if x != nil {
+		if x, ok := x.Node.(*Filter_Not); ok {
+			return x.Not
+		}
+	}
+	return nil
+}
+
+type isFilter_Node interface {
+	isFilter_Node()
+}
+
+type Filter_Condition struct {
+	Condition *Condition `protobuf:"bytes,1,opt,name=condition,proto3,oneof"`
+}
+
+type Filter_And struct {
+	And *FilterGroup `protobuf:"bytes,2,opt,name=and,proto3,oneof"`
+}
+
+type Filter_Or struct {
+	Or *FilterGroup `protobuf:"bytes,3,opt,name=or,proto3,oneof"`
+}
+
+type Filter_Not struct {
+	Not *Filter `protobuf:"bytes,4,opt,name=not,proto3,oneof"`
+}
+
+func (*Filter_Condition) isFilter_Node() {}
+
+func (*Filter_And) isFilter_Node() {}
+
+func (*Filter_Or) isFilter_Node() {}
+
+func (*Filter_Not) isFilter_Node() {}
+
+type FilterGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filters       []*Filter              `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterGroup) Reset() {
+	*x = FilterGroup{}
+	mi := &file_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterGroup) ProtoMessage() {}
+
+func (x *FilterGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterGroup.ProtoReflect.Descriptor instead.
+func (*FilterGroup) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FilterGroup) GetFilters() []*Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+// Condition условие на одну колонку. Число значений зависит от оператора:
+// IS_NULL и IS_NOT_NULL - без значений, BETWEEN - два (границы включаются),
+// IN и NOT_IN - одно и больше, остальные - ровно одно.
+type Condition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Operator      FilterOperator         `protobuf:"varint,2,opt,name=operator,proto3,enum=api.FilterOperator" json:"operator,omitempty"`
+	Values        []*Value               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	mi := &file_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Condition) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *Condition) GetOperator() FilterOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return FilterOperator_OPERATOR_UNSPECIFIED
+}
+
+func (x *Condition) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Fields        []string               `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Filter        *Filter                `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"` // Дерево условий; объединяется с поиском через AND
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
 	*x = SearchRequest{}
-	mi := &file_api_proto_msgTypes[7]
+	mi := &file_api_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -476,7 +1089,7 @@ func (x *SearchRequest) String() string {
 func (*SearchRequest) ProtoMessage() {}
 
 func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[7]
+	mi := &file_api_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +1102,7 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SearchRequest) GetTableName() string {
@@ -527,6 +1140,13 @@ func (x *SearchRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *SearchRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
 type EntityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
@@ -537,7 +1157,7 @@ type EntityResponse struct {
 
 func (x *EntityResponse) Reset() {
 	*x = EntityResponse{}
-	mi := &file_api_proto_msgTypes[8]
+	mi := &file_api_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -548,63 +1168,242 @@ func (x *EntityResponse) String() string {
 
 func (*EntityResponse) ProtoMessage() {}
 
-func (x *EntityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[8]
+func (x *EntityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityResponse.ProtoReflect.Descriptor instead.
+func (*EntityResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EntityResponse) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *EntityResponse) GetEntity() *Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	Entities      []*Entity              `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // -1, если запрошен skip_total_count
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Пусто на последней странице
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListResponse) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *ListResponse) GetEntities() []*Entity {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+func (x *ListResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Выгрузка таблицы целиком: строки читаются курсором PostgreSQL и передаются
+// фрагментами по мере того, как клиент их принимает
+type ExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
+	Filters       map[string]string      `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Filter        *Filter                `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	OrderDesc     bool                   `protobuf:"varint,5,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`
+	ChunkSize     int32                  `protobuf:"varint,6,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"` // Строк во фрагменте: по умолчанию 500, не больше 5000
+	Window        int32                  `protobuf:"varint,7,opt,name=window,proto3" json:"window,omitempty"`                        // Фрагментов до первого ExportCredit; заполняет mainservice
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	mi := &file_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExportRequest) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetFilter() *Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ExportRequest) GetOrderBy() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OrderBy
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use EntityResponse.ProtoReflect.Descriptor instead.
-func (*EntityResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{8}
+func (x *ExportRequest) GetOrderDesc() bool {
+	if x != nil {
+		return x.OrderDesc
+	}
+	return false
 }
 
-func (x *EntityResponse) GetTableName() string {
+func (x *ExportRequest) GetChunkSize() int32 {
 	if x != nil {
-		return x.TableName
+		return x.ChunkSize
 	}
-	return ""
+	return 0
 }
 
-func (x *EntityResponse) GetEntity() *Entity {
+func (x *ExportRequest) GetWindow() int32 {
 	if x != nil {
-		return x.Entity
+		return x.Window
 	}
-	return nil
+	return 0
 }
 
-type ListResponse struct {
+type ExportChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TableName     string                 `protobuf:"bytes,1,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty"`
-	Entities      []*Entity              `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Columns       []string               `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`                       // Колонки в порядке таблицы; только в первом фрагменте
+	Entities      []*Entity              `protobuf:"bytes,3,rep,name=entities,proto3" json:"entities,omitempty"`                     // Только values: fields не заполняются
+	Sequence      int64                  `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`                    // Номер фрагмента, начиная с 0
+	Last          bool                   `protobuf:"varint,5,opt,name=last,proto3" json:"last,omitempty"`                            // Последний фрагмент выгрузки
+	TotalRows     int64                  `protobuf:"varint,6,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"` // Всего выгружено строк; только в последнем фрагменте
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListResponse) Reset() {
-	*x = ListResponse{}
-	mi := &file_api_proto_msgTypes[9]
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_api_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListResponse) String() string {
+func (x *ExportChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListResponse) ProtoMessage() {}
+func (*ExportChunk) ProtoMessage() {}
 
-func (x *ListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[9]
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,42 +1414,49 @@ func (x *ListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
-func (*ListResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListResponse) GetTableName() string {
+func (x *ExportChunk) GetTableName() string {
 	if x != nil {
 		return x.TableName
 	}
 	return ""
 }
 
-func (x *ListResponse) GetEntities() []*Entity {
+func (x *ExportChunk) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ExportChunk) GetEntities() []*Entity {
 	if x != nil {
 		return x.Entities
 	}
 	return nil
 }
 
-func (x *ListResponse) GetTotalCount() int32 {
+func (x *ExportChunk) GetSequence() int64 {
 	if x != nil {
-		return x.TotalCount
+		return x.Sequence
 	}
 	return 0
 }
 
-func (x *ListResponse) GetPage() int32 {
+func (x *ExportChunk) GetLast() bool {
 	if x != nil {
-		return x.Page
+		return x.Last
 	}
-	return 0
+	return false
 }
 
-func (x *ListResponse) GetPageSize() int32 {
+func (x *ExportChunk) GetTotalRows() int64 {
 	if x != nil {
-		return x.PageSize
+		return x.TotalRows
 	}
 	return 0
 }
@@ -666,7 +1472,7 @@ type BatchCreateRequest struct {
 
 func (x *BatchCreateRequest) Reset() {
 	*x = BatchCreateRequest{}
-	mi := &file_api_proto_msgTypes[10]
+	mi := &file_api_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -678,7 +1484,7 @@ func (x *BatchCreateRequest) String() string {
 func (*BatchCreateRequest) ProtoMessage() {}
 
 func (x *BatchCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[10]
+	mi := &file_api_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -691,7 +1497,7 @@ func (x *BatchCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchCreateRequest.ProtoReflect.Descriptor instead.
 func (*BatchCreateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BatchCreateRequest) GetTableName() string {
@@ -718,7 +1524,7 @@ type BatchUpdateRequest struct {
 
 func (x *BatchUpdateRequest) Reset() {
 	*x = BatchUpdateRequest{}
-	mi := &file_api_proto_msgTypes[11]
+	mi := &file_api_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -730,7 +1536,7 @@ func (x *BatchUpdateRequest) String() string {
 func (*BatchUpdateRequest) ProtoMessage() {}
 
 func (x *BatchUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[11]
+	mi := &file_api_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -743,7 +1549,7 @@ func (x *BatchUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchUpdateRequest.ProtoReflect.Descriptor instead.
 func (*BatchUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *BatchUpdateRequest) GetTableName() string {
@@ -772,7 +1578,7 @@ type BatchResponse struct {
 
 func (x *BatchResponse) Reset() {
 	*x = BatchResponse{}
-	mi := &file_api_proto_msgTypes[12]
+	mi := &file_api_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -784,7 +1590,7 @@ func (x *BatchResponse) String() string {
 func (*BatchResponse) ProtoMessage() {}
 
 func (x *BatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[12]
+	mi := &file_api_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -797,7 +1603,7 @@ func (x *BatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
 func (*BatchResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BatchResponse) GetSuccess() bool {
@@ -842,7 +1648,7 @@ type GetOrganizationRequest struct {
 
 func (x *GetOrganizationRequest) Reset() {
 	*x = GetOrganizationRequest{}
-	mi := &file_api_proto_msgTypes[13]
+	mi := &file_api_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -854,7 +1660,7 @@ func (x *GetOrganizationRequest) String() string {
 func (*GetOrganizationRequest) ProtoMessage() {}
 
 func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[13]
+	mi := &file_api_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +1673,7 @@ func (x *GetOrganizationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrganizationRequest.ProtoReflect.Descriptor instead.
 func (*GetOrganizationRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetOrganizationRequest) GetIdentifier() isGetOrganizationRequest_Identifier {
@@ -912,17 +1718,19 @@ func (*GetOrganizationRequest_Id) isGetOrganizationRequest_Identifier() {}
 func (*GetOrganizationRequest_Inn) isGetOrganizationRequest_Identifier() {}
 
 type ListOrganizationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Filter        string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Page           int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize       int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Filter         string                 `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	PageToken      string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                   // Токен следующей страницы, см. ListRequest.page_token
+	SkipTotalCount bool                   `protobuf:"varint,5,opt,name=skip_total_count,json=skipTotalCount,proto3" json:"skip_total_count,omitempty"` // Не считать COUNT(*): total_count в ответе будет -1
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListOrganizationsRequest) Reset() {
 	*x = ListOrganizationsRequest{}
-	mi := &file_api_proto_msgTypes[14]
+	mi := &file_api_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -934,7 +1742,7 @@ func (x *ListOrganizationsRequest) String() string {
 func (*ListOrganizationsRequest) ProtoMessage() {}
 
 func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[14]
+	mi := &file_api_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -947,7 +1755,7 @@ func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
 func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListOrganizationsRequest) GetPage() int32 {
@@ -971,6 +1779,20 @@ func (x *ListOrganizationsRequest) GetFilter() string {
 	return ""
 }
 
+func (x *ListOrganizationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrganizationsRequest) GetSkipTotalCount() bool {
+	if x != nil {
+		return x.SkipTotalCount
+	}
+	return false
+}
+
 type SearchOrganizationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
@@ -981,7 +1803,7 @@ type SearchOrganizationsRequest struct {
 
 func (x *SearchOrganizationsRequest) Reset() {
 	*x = SearchOrganizationsRequest{}
-	mi := &file_api_proto_msgTypes[15]
+	mi := &file_api_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -993,7 +1815,7 @@ func (x *SearchOrganizationsRequest) String() string {
 func (*SearchOrganizationsRequest) ProtoMessage() {}
 
 func (x *SearchOrganizationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[15]
+	mi := &file_api_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1006,7 +1828,7 @@ func (x *SearchOrganizationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchOrganizationsRequest.ProtoReflect.Descriptor instead.
 func (*SearchOrganizationsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{15}
+	return file_api_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *SearchOrganizationsRequest) GetQuery() string {
@@ -1036,7 +1858,7 @@ type OrganizationResponse struct {
 
 func (x *OrganizationResponse) Reset() {
 	*x = OrganizationResponse{}
-	mi := &file_api_proto_msgTypes[16]
+	mi := &file_api_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1048,7 +1870,7 @@ func (x *OrganizationResponse) String() string {
 func (*OrganizationResponse) ProtoMessage() {}
 
 func (x *OrganizationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[16]
+	mi := &file_api_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1061,7 +1883,7 @@ func (x *OrganizationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OrganizationResponse.ProtoReflect.Descriptor instead.
 func (*OrganizationResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{16}
+	return file_api_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *OrganizationResponse) GetOrganization() *Organization {
@@ -1121,7 +1943,7 @@ type Organization struct {
 
 func (x *Organization) Reset() {
 	*x = Organization{}
-	mi := &file_api_proto_msgTypes[17]
+	mi := &file_api_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1133,7 +1955,7 @@ func (x *Organization) String() string {
 func (*Organization) ProtoMessage() {}
 
 func (x *Organization) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[17]
+	mi := &file_api_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1146,7 +1968,7 @@ func (x *Organization) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Organization.ProtoReflect.Descriptor instead.
 func (*Organization) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{17}
+	return file_api_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Organization) GetId() int32 {
@@ -1260,7 +2082,7 @@ type Address struct {
 
 func (x *Address) Reset() {
 	*x = Address{}
-	mi := &file_api_proto_msgTypes[18]
+	mi := &file_api_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1272,7 +2094,7 @@ func (x *Address) String() string {
 func (*Address) ProtoMessage() {}
 
 func (x *Address) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[18]
+	mi := &file_api_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1285,7 +2107,7 @@ func (x *Address) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Address.ProtoReflect.Descriptor instead.
 func (*Address) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{18}
+	return file_api_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Address) GetId() int32 {
@@ -1337,7 +2159,7 @@ type Contact struct {
 
 func (x *Contact) Reset() {
 	*x = Contact{}
-	mi := &file_api_proto_msgTypes[19]
+	mi := &file_api_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1349,7 +2171,7 @@ func (x *Contact) String() string {
 func (*Contact) ProtoMessage() {}
 
 func (x *Contact) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[19]
+	mi := &file_api_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,7 +2184,7 @@ func (x *Contact) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Contact.ProtoReflect.Descriptor instead.
 func (*Contact) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{19}
+	return file_api_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Contact) GetId() int32 {
@@ -1421,7 +2243,7 @@ type FinancialIndicator struct {
 
 func (x *FinancialIndicator) Reset() {
 	*x = FinancialIndicator{}
-	mi := &file_api_proto_msgTypes[20]
+	mi := &file_api_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1433,7 +2255,7 @@ func (x *FinancialIndicator) String() string {
 func (*FinancialIndicator) ProtoMessage() {}
 
 func (x *FinancialIndicator) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[20]
+	mi := &file_api_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1446,7 +2268,7 @@ func (x *FinancialIndicator) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinancialIndicator.ProtoReflect.Descriptor instead.
 func (*FinancialIndicator) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{20}
+	return file_api_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *FinancialIndicator) GetId() int32 {
@@ -1507,7 +2329,7 @@ type StaffIndicator struct {
 
 func (x *StaffIndicator) Reset() {
 	*x = StaffIndicator{}
-	mi := &file_api_proto_msgTypes[21]
+	mi := &file_api_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1519,7 +2341,7 @@ func (x *StaffIndicator) String() string {
 func (*StaffIndicator) ProtoMessage() {}
 
 func (x *StaffIndicator) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[21]
+	mi := &file_api_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1532,7 +2354,7 @@ func (x *StaffIndicator) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StaffIndicator.ProtoReflect.Descriptor instead.
 func (*StaffIndicator) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{21}
+	return file_api_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *StaffIndicator) GetId() int32 {
@@ -1604,7 +2426,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_api_proto_msgTypes[22]
+	mi := &file_api_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1616,7 +2438,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[22]
+	mi := &file_api_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1629,7 +2451,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{22}
+	return file_api_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetUserRequest) GetIdentifier() isGetUserRequest_Identifier {
@@ -1688,7 +2510,7 @@ type CreateUserRequest struct {
 
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_api_proto_msgTypes[23]
+	mi := &file_api_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1700,7 +2522,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[23]
+	mi := &file_api_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1713,7 +2535,7 @@ func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
 func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{23}
+	return file_api_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CreateUserRequest) GetEmail() string {
@@ -1771,14 +2593,14 @@ type UpdateUserRequest struct {
 	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
 	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
 	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
-	IsActive      bool                   `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	IsActive      *bool                  `protobuf:"varint,5,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"` // не задано - статус активности не меняется
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_api_proto_msgTypes[24]
+	mi := &file_api_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1790,7 +2612,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[24]
+	mi := &file_api_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1803,7 +2625,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{24}
+	return file_api_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *UpdateUserRequest) GetId() int32 {
@@ -1835,8 +2657,8 @@ func (x *UpdateUserRequest) GetPhone() string {
 }
 
 func (x *UpdateUserRequest) GetIsActive() bool {
-	if x != nil {
-		return x.IsActive
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
 	}
 	return false
 }
@@ -1850,7 +2672,7 @@ type UserResponse struct {
 
 func (x *UserResponse) Reset() {
 	*x = UserResponse{}
-	mi := &file_api_proto_msgTypes[25]
+	mi := &file_api_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1862,7 +2684,7 @@ func (x *UserResponse) String() string {
 func (*UserResponse) ProtoMessage() {}
 
 func (x *UserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[25]
+	mi := &file_api_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1875,7 +2697,7 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
 func (*UserResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{25}
+	return file_api_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *UserResponse) GetUser() *User {
@@ -1905,7 +2727,7 @@ type User struct {
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_api_proto_msgTypes[26]
+	mi := &file_api_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1917,7 +2739,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[26]
+	mi := &file_api_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,7 +2752,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{26}
+	return file_api_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *User) GetId() int32 {
@@ -2030,7 +2852,7 @@ type CreateInviteRequest struct {
 
 func (x *CreateInviteRequest) Reset() {
 	*x = CreateInviteRequest{}
-	mi := &file_api_proto_msgTypes[27]
+	mi := &file_api_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2042,7 +2864,7 @@ func (x *CreateInviteRequest) String() string {
 func (*CreateInviteRequest) ProtoMessage() {}
 
 func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[27]
+	mi := &file_api_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2055,7 +2877,7 @@ func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
 func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{27}
+	return file_api_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *CreateInviteRequest) GetEmail() string {
@@ -2102,7 +2924,7 @@ type ValidateInviteRequest struct {
 
 func (x *ValidateInviteRequest) Reset() {
 	*x = ValidateInviteRequest{}
-	mi := &file_api_proto_msgTypes[28]
+	mi := &file_api_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2114,7 +2936,7 @@ func (x *ValidateInviteRequest) String() string {
 func (*ValidateInviteRequest) ProtoMessage() {}
 
 func (x *ValidateInviteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[28]
+	mi := &file_api_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2127,7 +2949,7 @@ func (x *ValidateInviteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateInviteRequest.ProtoReflect.Descriptor instead.
 func (*ValidateInviteRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{28}
+	return file_api_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ValidateInviteRequest) GetCode() string {
@@ -2151,7 +2973,7 @@ type UseInviteRequest struct {
 
 func (x *UseInviteRequest) Reset() {
 	*x = UseInviteRequest{}
-	mi := &file_api_proto_msgTypes[29]
+	mi := &file_api_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2163,7 +2985,7 @@ func (x *UseInviteRequest) String() string {
 func (*UseInviteRequest) ProtoMessage() {}
 
 func (x *UseInviteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[29]
+	mi := &file_api_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2176,7 +2998,7 @@ func (x *UseInviteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UseInviteRequest.ProtoReflect.Descriptor instead.
 func (*UseInviteRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{29}
+	return file_api_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *UseInviteRequest) GetCode() string {
@@ -2230,7 +3052,7 @@ type InviteResponse struct {
 
 func (x *InviteResponse) Reset() {
 	*x = InviteResponse{}
-	mi := &file_api_proto_msgTypes[30]
+	mi := &file_api_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2242,7 +3064,7 @@ func (x *InviteResponse) String() string {
 func (*InviteResponse) ProtoMessage() {}
 
 func (x *InviteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[30]
+	mi := &file_api_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2255,7 +3077,7 @@ func (x *InviteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InviteResponse.ProtoReflect.Descriptor instead.
 func (*InviteResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{30}
+	return file_api_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *InviteResponse) GetInvite() *Invite {
@@ -2281,7 +3103,7 @@ type Invite struct {
 
 func (x *Invite) Reset() {
 	*x = Invite{}
-	mi := &file_api_proto_msgTypes[31]
+	mi := &file_api_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2293,7 +3115,7 @@ func (x *Invite) String() string {
 func (*Invite) ProtoMessage() {}
 
 func (x *Invite) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[31]
+	mi := &file_api_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2306,7 +3128,7 @@ func (x *Invite) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Invite.ProtoReflect.Descriptor instead.
 func (*Invite) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{31}
+	return file_api_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *Invite) GetId() int32 {
@@ -2377,7 +3199,7 @@ type SubmitFormRequest struct {
 
 func (x *SubmitFormRequest) Reset() {
 	*x = SubmitFormRequest{}
-	mi := &file_api_proto_msgTypes[32]
+	mi := &file_api_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2389,7 +3211,7 @@ func (x *SubmitFormRequest) String() string {
 func (*SubmitFormRequest) ProtoMessage() {}
 
 func (x *SubmitFormRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[32]
+	mi := &file_api_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2402,7 +3224,7 @@ func (x *SubmitFormRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubmitFormRequest.ProtoReflect.Descriptor instead.
 func (*SubmitFormRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{32}
+	return file_api_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *SubmitFormRequest) GetFormId() int32 {
@@ -2443,7 +3265,7 @@ type GetFormRequest struct {
 
 func (x *GetFormRequest) Reset() {
 	*x = GetFormRequest{}
-	mi := &file_api_proto_msgTypes[33]
+	mi := &file_api_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2455,7 +3277,7 @@ func (x *GetFormRequest) String() string {
 func (*GetFormRequest) ProtoMessage() {}
 
 func (x *GetFormRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[33]
+	mi := &file_api_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2468,7 +3290,7 @@ func (x *GetFormRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFormRequest.ProtoReflect.Descriptor instead.
 func (*GetFormRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{33}
+	return file_api_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *GetFormRequest) GetFormId() int32 {
@@ -2499,7 +3321,7 @@ type FormResponse struct {
 
 func (x *FormResponse) Reset() {
 	*x = FormResponse{}
-	mi := &file_api_proto_msgTypes[34]
+	mi := &file_api_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2511,7 +3333,7 @@ func (x *FormResponse) String() string {
 func (*FormResponse) ProtoMessage() {}
 
 func (x *FormResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[34]
+	mi := &file_api_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2524,7 +3346,7 @@ func (x *FormResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FormResponse.ProtoReflect.Descriptor instead.
 func (*FormResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{34}
+	return file_api_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *FormResponse) GetId() int32 {
@@ -2579,7 +3401,7 @@ type GetFinancialDataRequest struct {
 
 func (x *GetFinancialDataRequest) Reset() {
 	*x = GetFinancialDataRequest{}
-	mi := &file_api_proto_msgTypes[35]
+	mi := &file_api_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2591,7 +3413,7 @@ func (x *GetFinancialDataRequest) String() string {
 func (*GetFinancialDataRequest) ProtoMessage() {}
 
 func (x *GetFinancialDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[35]
+	mi := &file_api_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2604,7 +3426,7 @@ func (x *GetFinancialDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFinancialDataRequest.ProtoReflect.Descriptor instead.
 func (*GetFinancialDataRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{35}
+	return file_api_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *GetFinancialDataRequest) GetOrganizationId() int32 {
@@ -2630,7 +3452,7 @@ type FinancialDataResponse struct {
 
 func (x *FinancialDataResponse) Reset() {
 	*x = FinancialDataResponse{}
-	mi := &file_api_proto_msgTypes[36]
+	mi := &file_api_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2642,7 +3464,7 @@ func (x *FinancialDataResponse) String() string {
 func (*FinancialDataResponse) ProtoMessage() {}
 
 func (x *FinancialDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[36]
+	mi := &file_api_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2655,7 +3477,7 @@ func (x *FinancialDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinancialDataResponse.ProtoReflect.Descriptor instead.
 func (*FinancialDataResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{36}
+	return file_api_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *FinancialDataResponse) GetIndicators() []*FinancialIndicator {
@@ -2675,7 +3497,7 @@ type GetStaffDataRequest struct {
 
 func (x *GetStaffDataRequest) Reset() {
 	*x = GetStaffDataRequest{}
-	mi := &file_api_proto_msgTypes[37]
+	mi := &file_api_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2687,7 +3509,7 @@ func (x *GetStaffDataRequest) String() string {
 func (*GetStaffDataRequest) ProtoMessage() {}
 
 func (x *GetStaffDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[37]
+	mi := &file_api_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2700,7 +3522,7 @@ func (x *GetStaffDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStaffDataRequest.ProtoReflect.Descriptor instead.
 func (*GetStaffDataRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{37}
+	return file_api_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *GetStaffDataRequest) GetOrganizationId() int32 {
@@ -2726,7 +3548,7 @@ type StaffDataResponse struct {
 
 func (x *StaffDataResponse) Reset() {
 	*x = StaffDataResponse{}
-	mi := &file_api_proto_msgTypes[38]
+	mi := &file_api_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2738,7 +3560,7 @@ func (x *StaffDataResponse) String() string {
 func (*StaffDataResponse) ProtoMessage() {}
 
 func (x *StaffDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[38]
+	mi := &file_api_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2751,7 +3573,7 @@ func (x *StaffDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StaffDataResponse.ProtoReflect.Descriptor instead.
 func (*StaffDataResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{38}
+	return file_api_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *StaffDataResponse) GetIndicators() []*StaffIndicator {
@@ -2764,16 +3586,17 @@ func (x *StaffDataResponse) GetIndicators() []*StaffIndicator {
 type ListOrganizationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // -1, если запрошен skip_total_count
 	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Пусто на последней странице
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOrganizationsResponse) Reset() {
 	*x = ListOrganizationsResponse{}
-	mi := &file_api_proto_msgTypes[39]
+	mi := &file_api_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2785,7 +3608,7 @@ func (x *ListOrganizationsResponse) String() string {
 func (*ListOrganizationsResponse) ProtoMessage() {}
 
 func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[39]
+	mi := &file_api_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2798,7 +3621,7 @@ func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
 func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{39}
+	return file_api_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
@@ -2829,22 +3652,57 @@ func (x *ListOrganizationsResponse) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListOrganizationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_api_proto protoreflect.FileDescriptor
 
 const file_api_proto_rawDesc = "" +
 	"\n" +
-	"\tapi.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x02\n" +
+	"\tapi.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"\x90\x03\n" +
 	"\x06Entity\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12/\n" +
 	"\x06fields\x18\x02 \x03(\v2\x17.api.Entity.FieldsEntryR\x06fields\x12B\n" +
-	"\rbinary_fields\x18\x03 \x03(\v2\x1d.api.Entity.BinaryFieldsEntryR\fbinaryFields\x1a9\n" +
+	"\rbinary_fields\x18\x03 \x03(\v2\x1d.api.Entity.BinaryFieldsEntryR\fbinaryFields\x12/\n" +
+	"\x06values\x18\x04 \x03(\v2\x17.api.Entity.ValuesEntryR\x06values\x1a9\n" +
 	"\vFieldsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a?\n" +
 	"\x11BinaryFieldsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"S\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\x1aE\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\x05value\x18\x02 \x01(\v2\n" +
+	".api.ValueR\x05value:\x028\x01\"\x9c\x03\n" +
+	"\x05Value\x12\x1f\n" +
+	"\n" +
+	"null_value\x18\x01 \x01(\bH\x00R\tnullValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x02 \x01(\bH\x00R\tboolValue\x12!\n" +
+	"\vint64_value\x18\x03 \x01(\x03H\x00R\n" +
+	"int64Value\x12#\n" +
+	"\fdouble_value\x18\x04 \x01(\x01H\x00R\vdoubleValue\x12%\n" +
+	"\rdecimal_value\x18\x05 \x01(\tH\x00R\fdecimalValue\x12E\n" +
+	"\x0ftimestamp_value\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0etimestampValue\x12*\n" +
+	"\n" +
+	"date_value\x18\a \x01(\v2\t.api.DateH\x00R\tdateValue\x12!\n" +
+	"\vbytes_value\x18\b \x01(\fH\x00R\n" +
+	"bytesValue\x12\x1f\n" +
+	"\n" +
+	"json_value\x18\t \x01(\tH\x00R\tjsonValue\x12#\n" +
+	"\fstring_value\x18\n" +
+	" \x01(\tH\x00R\vstringValueB\x06\n" +
+	"\x04kind\"B\n" +
+	"\x04Date\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\"S\n" +
 	"\rCreateRequest\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12#\n" +
@@ -2871,7 +3729,7 @@ const file_api_proto_rawDesc = "" +
 	"softDelete\"O\n" +
 	"\x0eDeleteResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\raffected_rows\x18\x02 \x01(\x05R\faffectedRows\"\x8c\x02\n" +
+	"\raffected_rows\x18\x02 \x01(\x05R\faffectedRows\"\xfa\x02\n" +
 	"\vListRequest\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12\x12\n" +
@@ -2880,21 +3738,39 @@ const file_api_proto_rawDesc = "" +
 	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x1d\n" +
 	"\n" +
 	"order_desc\x18\x05 \x01(\bR\torderDesc\x127\n" +
-	"\afilters\x18\x06 \x03(\v2\x1d.api.ListRequest.FiltersEntryR\afilters\x1a:\n" +
+	"\afilters\x18\x06 \x03(\v2\x1d.api.ListRequest.FiltersEntryR\afilters\x12#\n" +
+	"\x06filter\x18\a \x01(\v2\v.api.FilterR\x06filter\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\x12(\n" +
+	"\x10skip_total_count\x18\t \x01(\bR\x0eskipTotalCount\x1a:\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8a\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xab\x01\n" +
+	"\x06Filter\x12.\n" +
+	"\tcondition\x18\x01 \x01(\v2\x0e.api.ConditionH\x00R\tcondition\x12$\n" +
+	"\x03and\x18\x02 \x01(\v2\x10.api.FilterGroupH\x00R\x03and\x12\"\n" +
+	"\x02or\x18\x03 \x01(\v2\x10.api.FilterGroupH\x00R\x02or\x12\x1f\n" +
+	"\x03not\x18\x04 \x01(\v2\v.api.FilterH\x00R\x03notB\x06\n" +
+	"\x04node\"4\n" +
+	"\vFilterGroup\x12%\n" +
+	"\afilters\x18\x01 \x03(\v2\v.api.FilterR\afilters\"x\n" +
+	"\tCondition\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12/\n" +
+	"\boperator\x18\x02 \x01(\x0e2\x13.api.FilterOperatorR\boperator\x12\"\n" +
+	"\x06values\x18\x03 \x03(\v2\n" +
+	".api.ValueR\x06values\"\xaf\x01\n" +
 	"\rSearchRequest\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12\x14\n" +
 	"\x05query\x18\x02 \x01(\tR\x05query\x12\x16\n" +
 	"\x06fields\x18\x03 \x03(\tR\x06fields\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"T\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12#\n" +
+	"\x06filter\x18\x06 \x01(\v2\v.api.FilterR\x06filter\"T\n" +
 	"\x0eEntityResponse\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12#\n" +
-	"\x06entity\x18\x02 \x01(\v2\v.api.EntityR\x06entity\"\xa8\x01\n" +
+	"\x06entity\x18\x02 \x01(\v2\v.api.EntityR\x06entity\"\xd0\x01\n" +
 	"\fListResponse\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12'\n" +
@@ -2902,7 +3778,31 @@ const file_api_proto_rawDesc = "" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"\\\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x06 \x01(\tR\rnextPageToken\"\xbb\x02\n" +
+	"\rExportRequest\x12\x1d\n" +
+	"\n" +
+	"table_name\x18\x01 \x01(\tR\ttableName\x129\n" +
+	"\afilters\x18\x02 \x03(\v2\x1f.api.ExportRequest.FiltersEntryR\afilters\x12#\n" +
+	"\x06filter\x18\x03 \x01(\v2\v.api.FilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12\x1d\n" +
+	"\n" +
+	"order_desc\x18\x05 \x01(\bR\torderDesc\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x06 \x01(\x05R\tchunkSize\x12\x16\n" +
+	"\x06window\x18\a \x01(\x05R\x06window\x1a:\n" +
+	"\fFiltersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbe\x01\n" +
+	"\vExportChunk\x12\x1d\n" +
+	"\n" +
+	"table_name\x18\x01 \x01(\tR\ttableName\x12\x18\n" +
+	"\acolumns\x18\x02 \x03(\tR\acolumns\x12'\n" +
+	"\bentities\x18\x03 \x03(\v2\v.api.EntityR\bentities\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x03R\bsequence\x12\x12\n" +
+	"\x04last\x18\x05 \x01(\bR\x04last\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x06 \x01(\x03R\ttotalRows\"\\\n" +
 	"\x12BatchCreateRequest\x12\x1d\n" +
 	"\n" +
 	"table_name\x18\x01 \x01(\tR\ttableName\x12'\n" +
@@ -2920,11 +3820,14 @@ const file_api_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\x05H\x00R\x02id\x12\x12\n" +
 	"\x03inn\x18\x02 \x01(\tH\x00R\x03innB\f\n" +
 	"\n" +
-	"identifier\"c\n" +
+	"identifier\"\xac\x01\n" +
 	"\x18ListOrganizationsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x16\n" +
-	"\x06filter\x18\x03 \x01(\tR\x06filter\"H\n" +
+	"\x06filter\x18\x03 \x01(\tR\x06filter\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12(\n" +
+	"\x10skip_total_count\x18\x05 \x01(\bR\x0eskipTotalCount\"H\n" +
 	"\x1aSearchOrganizationsRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xaf\x02\n" +
@@ -2998,14 +3901,16 @@ const file_api_proto_rawDesc = "" +
 	"\tlast_name\x18\x04 \x01(\tR\blastName\x12\x14\n" +
 	"\x05phone\x18\x05 \x01(\tR\x05phone\x12'\n" +
 	"\x0forganization_id\x18\x06 \x01(\x05R\x0eorganizationId\x12\x17\n" +
-	"\arole_id\x18\a \x01(\x05R\x06roleId\"\x92\x01\n" +
+	"\arole_id\x18\a \x01(\x05R\x06roleId\"\xa5\x01\n" +
 	"\x11UpdateUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
 	"\n" +
 	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
 	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
-	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x1b\n" +
-	"\tis_active\x18\x05 \x01(\bR\bisActive\"-\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12 \n" +
+	"\tis_active\x18\x05 \x01(\bH\x00R\bisActive\x88\x01\x01B\f\n" +
+	"\n" +
+	"_is_active\"-\n" +
 	"\fUserResponse\x12\x1d\n" +
 	"\x04user\x18\x01 \x01(\v2\t.api.UserR\x04user\"\xaf\x03\n" +
 	"\x04User\x12\x0e\n" +
@@ -3086,13 +3991,32 @@ const file_api_proto_rawDesc = "" +
 	"\x11StaffDataResponse\x123\n" +
 	"\n" +
 	"indicators\x18\x01 \x03(\v2\x13.api.StaffIndicatorR\n" +
-	"indicators\"\xa6\x01\n" +
+	"indicators\"\xce\x01\n" +
 	"\x19ListOrganizationsResponse\x127\n" +
 	"\rorganizations\x18\x01 \x03(\v2\x11.api.OrganizationR\rorganizations\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xc9\t\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken*\xb0\x01\n" +
+	"\x0eFilterOperator\x12\x18\n" +
+	"\x14OPERATOR_UNSPECIFIED\x10\x00\x12\x06\n" +
+	"\x02EQ\x10\x01\x12\x06\n" +
+	"\x02NE\x10\x02\x12\x06\n" +
+	"\x02LT\x10\x03\x12\x06\n" +
+	"\x02LE\x10\x04\x12\x06\n" +
+	"\x02GT\x10\x05\x12\x06\n" +
+	"\x02GE\x10\x06\x12\x06\n" +
+	"\x02IN\x10\a\x12\n" +
+	"\n" +
+	"\x06NOT_IN\x10\b\x12\v\n" +
+	"\aBETWEEN\x10\t\x12\v\n" +
+	"\aIS_NULL\x10\n" +
+	"\x12\x0f\n" +
+	"\vIS_NOT_NULL\x10\v\x12\n" +
+	"\n" +
+	"\x06PREFIX\x10\f\x12\t\n" +
+	"\x05ILIKE\x10\r2\xfb\t\n" +
 	"\vDataService\x121\n" +
 	"\x06Create\x12\x12.api.CreateRequest\x1a\x13.api.EntityResponse\x12+\n" +
 	"\x03Get\x12\x0f.api.GetRequest\x1a\x13.api.EntityResponse\x121\n" +
@@ -3116,7 +4040,8 @@ const file_api_proto_rawDesc = "" +
 	"\x10GetFinancialData\x12\x1c.api.GetFinancialDataRequest\x1a\x1a.api.FinancialDataResponse\x12@\n" +
 	"\fGetStaffData\x12\x18.api.GetStaffDataRequest\x1a\x16.api.StaffDataResponse\x12:\n" +
 	"\vBatchCreate\x12\x17.api.BatchCreateRequest\x1a\x12.api.BatchResponse\x12:\n" +
-	"\vBatchUpdate\x12\x17.api.BatchUpdateRequest\x1a\x12.api.BatchResponseB\aZ\x05./apib\x06proto3"
+	"\vBatchUpdate\x12\x17.api.BatchUpdateRequest\x1a\x12.api.BatchResponse\x120\n" +
+	"\x06Export\x12\x12.api.ExportRequest\x1a\x10.api.ExportChunk0\x01B\aZ\x05./apib\x06proto3"
 
 var (
 	file_api_proto_rawDescOnce sync.Once
@@ -3130,128 +4055,157 @@ func file_api_proto_rawDescGZIP() []byte {
 	return file_api_proto_rawDescData
 }
 
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_api_proto_goTypes = []any{
-	(*Entity)(nil),                     // 0: api.Entity
-	(*CreateRequest)(nil),              // 1: api.CreateRequest
-	(*GetRequest)(nil),                 // 2: api.GetRequest
-	(*UpdateRequest)(nil),              // 3: api.UpdateRequest
-	(*DeleteRequest)(nil),              // 4: api.DeleteRequest
-	(*DeleteResponse)(nil),             // 5: api.DeleteResponse
-	(*ListRequest)(nil),                // 6: api.ListRequest
-	(*SearchRequest)(nil),              // 7: api.SearchRequest
-	(*EntityResponse)(nil),             // 8: api.EntityResponse
-	(*ListResponse)(nil),               // 9: api.ListResponse
-	(*BatchCreateRequest)(nil),         // 10: api.BatchCreateRequest
-	(*BatchUpdateRequest)(nil),         // 11: api.BatchUpdateRequest
-	(*BatchResponse)(nil),              // 12: api.BatchResponse
-	(*GetOrganizationRequest)(nil),     // 13: api.GetOrganizationRequest
-	(*ListOrganizationsRequest)(nil),   // 14: api.ListOrganizationsRequest
-	(*SearchOrganizationsRequest)(nil), // 15: api.SearchOrganizationsRequest
-	(*OrganizationResponse)(nil),       // 16: api.OrganizationResponse
-	(*Organization)(nil),               // 17: api.Organization
-	(*Address)(nil),                    // 18: api.Address
-	(*Contact)(nil),                    // 19: api.Contact
-	(*FinancialIndicator)(nil),         // 20: api.FinancialIndicator
-	(*StaffIndicator)(nil),             // 21: api.StaffIndicator
-	(*GetUserRequest)(nil),             // 22: api.GetUserRequest
-	(*CreateUserRequest)(nil),          // 23: api.CreateUserRequest
-	(*UpdateUserRequest)(nil),          // 24: api.UpdateUserRequest
-	(*UserResponse)(nil),               // 25: api.UserResponse
-	(*User)(nil),                       // 26: api.User
-	(*CreateInviteRequest)(nil),        // 27: api.CreateInviteRequest
-	(*ValidateInviteRequest)(nil),      // 28: api.ValidateInviteRequest
-	(*UseInviteRequest)(nil),           // 29: api.UseInviteRequest
-	(*InviteResponse)(nil),             // 30: api.InviteResponse
-	(*Invite)(nil),                     // 31: api.Invite
-	(*SubmitFormRequest)(nil),          // 32: api.SubmitFormRequest
-	(*GetFormRequest)(nil),             // 33: api.GetFormRequest
-	(*FormResponse)(nil),               // 34: api.FormResponse
-	(*GetFinancialDataRequest)(nil),    // 35: api.GetFinancialDataRequest
-	(*FinancialDataResponse)(nil),      // 36: api.FinancialDataResponse
-	(*GetStaffDataRequest)(nil),        // 37: api.GetStaffDataRequest
-	(*StaffDataResponse)(nil),          // 38: api.StaffDataResponse
-	(*ListOrganizationsResponse)(nil),  // 39: api.ListOrganizationsResponse
-	nil,                                // 40: api.Entity.FieldsEntry
-	nil,                                // 41: api.Entity.BinaryFieldsEntry
-	nil,                                // 42: api.GetRequest.FiltersEntry
-	nil,                                // 43: api.ListRequest.FiltersEntry
-	(*timestamppb.Timestamp)(nil),      // 44: google.protobuf.Timestamp
+	(FilterOperator)(0),                // 0: api.FilterOperator
+	(*Entity)(nil),                     // 1: api.Entity
+	(*Value)(nil),                      // 2: api.Value
+	(*Date)(nil),                       // 3: api.Date
+	(*CreateRequest)(nil),              // 4: api.CreateRequest
+	(*GetRequest)(nil),                 // 5: api.GetRequest
+	(*UpdateRequest)(nil),              // 6: api.UpdateRequest
+	(*DeleteRequest)(nil),              // 7: api.DeleteRequest
+	(*DeleteResponse)(nil),             // 8: api.DeleteResponse
+	(*ListRequest)(nil),                // 9: api.ListRequest
+	(*Filter)(nil),                     // 10: api.Filter
+	(*FilterGroup)(nil),                // 11: api.FilterGroup
+	(*Condition)(nil),                  // 12: api.Condition
+	(*SearchRequest)(nil),              // 13: api.SearchRequest
+	(*EntityResponse)(nil),             // 14: api.EntityResponse
+	(*ListResponse)(nil),               // 15: api.ListResponse
+	(*ExportRequest)(nil),              // 16: api.ExportRequest
+	(*ExportChunk)(nil),                // 17: api.ExportChunk
+	(*BatchCreateRequest)(nil),         // 18: api.BatchCreateRequest
+	(*BatchUpdateRequest)(nil),         // 19: api.BatchUpdateRequest
+	(*BatchResponse)(nil),              // 20: api.BatchResponse
+	(*GetOrganizationRequest)(nil),     // 21: api.GetOrganizationRequest
+	(*ListOrganizationsRequest)(nil),   // 22: api.ListOrganizationsRequest
+	(*SearchOrganizationsRequest)(nil), // 23: api.SearchOrganizationsRequest
+	(*OrganizationResponse)(nil),       // 24: api.OrganizationResponse
+	(*Organization)(nil),               // 25: api.Organization
+	(*Address)(nil),                    // 26: api.Address
+	(*Contact)(nil),                    // 27: api.Contact
+	(*FinancialIndicator)(nil),         // 28: api.FinancialIndicator
+	(*StaffIndicator)(nil),             // 29: api.StaffIndicator
+	(*GetUserRequest)(nil),             // 30: api.GetUserRequest
+	(*CreateUserRequest)(nil),          // 31: api.CreateUserRequest
+	(*UpdateUserRequest)(nil),          // 32: api.UpdateUserRequest
+	(*UserResponse)(nil),               // 33: api.UserResponse
+	(*User)(nil),                       // 34: api.User
+	(*CreateInviteRequest)(nil),        // 35: api.CreateInviteRequest
+	(*ValidateInviteRequest)(nil),      // 36: api.ValidateInviteRequest
+	(*UseInviteRequest)(nil),           // 37: api.UseInviteRequest
+	(*InviteResponse)(nil),             // 38: api.InviteResponse
+	(*Invite)(nil),                     // 39: api.Invite
+	(*SubmitFormRequest)(nil),          // 40: api.SubmitFormRequest
+	(*GetFormRequest)(nil),             // 41: api.GetFormRequest
+	(*FormResponse)(nil),               // 42: api.FormResponse
+	(*GetFinancialDataRequest)(nil),    // 43: api.GetFinancialDataRequest
+	(*FinancialDataResponse)(nil),      // 44: api.FinancialDataResponse
+	(*GetStaffDataRequest)(nil),        // 45: api.GetStaffDataRequest
+	(*StaffDataResponse)(nil),          // 46: api.StaffDataResponse
+	(*ListOrganizationsResponse)(nil),  // 47: api.ListOrganizationsResponse
+	nil,                                // 48: api.Entity.FieldsEntry
+	nil,                                // 49: api.Entity.BinaryFieldsEntry
+	nil,                                // 50: api.Entity.ValuesEntry
+	nil,                                // 51: api.GetRequest.FiltersEntry
+	nil,                                // 52: api.ListRequest.FiltersEntry
+	nil,                                // 53: api.ExportRequest.FiltersEntry
+	(*timestamppb.Timestamp)(nil),      // 54: google.protobuf.Timestamp
 }
 var file_api_proto_depIdxs = []int32{
-	40, // 0: api.Entity.fields:type_name -> api.Entity.FieldsEntry
-	41, // 1: api.Entity.binary_fields:type_name -> api.Entity.BinaryFieldsEntry
-	0,  // 2: api.CreateRequest.entity:type_name -> api.Entity
-	42, // 3: api.GetRequest.filters:type_name -> api.GetRequest.FiltersEntry
-	0,  // 4: api.UpdateRequest.entity:type_name -> api.Entity
-	43, // 5: api.ListRequest.filters:type_name -> api.ListRequest.FiltersEntry
-	0,  // 6: api.EntityResponse.entity:type_name -> api.Entity
-	0,  // 7: api.ListResponse.entities:type_name -> api.Entity
-	0,  // 8: api.BatchCreateRequest.entities:type_name -> api.Entity
-	0,  // 9: api.BatchUpdateRequest.entities:type_name -> api.Entity
-	17, // 10: api.OrganizationResponse.organization:type_name -> api.Organization
-	18, // 11: api.OrganizationResponse.addresses:type_name -> api.Address
-	19, // 12: api.OrganizationResponse.contacts:type_name -> api.Contact
-	20, // 13: api.OrganizationResponse.financial_indicators:type_name -> api.FinancialIndicator
-	21, // 14: api.OrganizationResponse.staff_indicators:type_name -> api.StaffIndicator
-	44, // 15: api.Organization.created_at:type_name -> google.protobuf.Timestamp
-	44, // 16: api.Organization.updated_at:type_name -> google.protobuf.Timestamp
-	26, // 17: api.UserResponse.user:type_name -> api.User
-	44, // 18: api.User.last_login:type_name -> google.protobuf.Timestamp
-	44, // 19: api.User.created_at:type_name -> google.protobuf.Timestamp
-	44, // 20: api.User.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 21: api.InviteResponse.invite:type_name -> api.Invite
-	44, // 22: api.Invite.expires_at:type_name -> google.protobuf.Timestamp
-	44, // 23: api.Invite.created_at:type_name -> google.protobuf.Timestamp
-	44, // 24: api.FormResponse.created_at:type_name -> google.protobuf.Timestamp
-	20, // 25: api.FinancialDataResponse.indicators:type_name -> api.FinancialIndicator
-	21, // 26: api.StaffDataResponse.indicators:type_name -> api.StaffIndicator
-	17, // 27: api.ListOrganizationsResponse.organizations:type_name -> api.Organization
-	1,  // 28: api.DataService.Create:input_type -> api.CreateRequest
-	2,  // 29: api.DataService.Get:input_type -> api.GetRequest
-	3,  // 30: api.DataService.Update:input_type -> api.UpdateRequest
-	4,  // 31: api.DataService.Delete:input_type -> api.DeleteRequest
-	6,  // 32: api.DataService.List:input_type -> api.ListRequest
-	7,  // 33: api.DataService.Search:input_type -> api.SearchRequest
-	13, // 34: api.DataService.GetOrganization:input_type -> api.GetOrganizationRequest
-	14, // 35: api.DataService.ListOrganizations:input_type -> api.ListOrganizationsRequest
-	15, // 36: api.DataService.SearchOrganizations:input_type -> api.SearchOrganizationsRequest
-	22, // 37: api.DataService.GetUser:input_type -> api.GetUserRequest
-	23, // 38: api.DataService.CreateUser:input_type -> api.CreateUserRequest
-	24, // 39: api.DataService.UpdateUser:input_type -> api.UpdateUserRequest
-	27, // 40: api.DataService.CreateInvite:input_type -> api.CreateInviteRequest
-	28, // 41: api.DataService.ValidateInvite:input_type -> api.ValidateInviteRequest
-	29, // 42: api.DataService.UseInvite:input_type -> api.UseInviteRequest
-	32, // 43: api.DataService.SubmitForm:input_type -> api.SubmitFormRequest
-	35, // 44: api.DataService.GetFinancialData:input_type -> api.GetFinancialDataRequest
-	37, // 45: api.DataService.GetStaffData:input_type -> api.GetStaffDataRequest
-	10, // 46: api.DataService.BatchCreate:input_type -> api.BatchCreateRequest
-	11, // 47: api.DataService.BatchUpdate:input_type -> api.BatchUpdateRequest
-	8,  // 48: api.DataService.Create:output_type -> api.EntityResponse
-	8,  // 49: api.DataService.Get:output_type -> api.EntityResponse
-	8,  // 50: api.DataService.Update:output_type -> api.EntityResponse
-	5,  // 51: api.DataService.Delete:output_type -> api.DeleteResponse
-	9,  // 52: api.DataService.List:output_type -> api.ListResponse
-	9,  // 53: api.DataService.Search:output_type -> api.ListResponse
-	16, // 54: api.DataService.GetOrganization:output_type -> api.OrganizationResponse
-	39, // 55: api.DataService.ListOrganizations:output_type -> api.ListOrganizationsResponse
-	39, // 56: api.DataService.SearchOrganizations:output_type -> api.ListOrganizationsResponse
-	25, // 57: api.DataService.GetUser:output_type -> api.UserResponse
-	25, // 58: api.DataService.CreateUser:output_type -> api.UserResponse
-	25, // 59: api.DataService.UpdateUser:output_type -> api.UserResponse
-	30, // 60: api.DataService.CreateInvite:output_type -> api.InviteResponse
-	30, // 61: api.DataService.ValidateInvite:output_type -> api.InviteResponse
-	30, // 62: api.DataService.UseInvite:output_type -> api.InviteResponse
-	34, // 63: api.DataService.SubmitForm:output_type -> api.FormResponse
-	36, // 64: api.DataService.GetFinancialData:output_type -> api.FinancialDataResponse
-	38, // 65: api.DataService.GetStaffData:output_type -> api.StaffDataResponse
-	12, // 66: api.DataService.BatchCreate:output_type -> api.BatchResponse
-	12, // 67: api.DataService.BatchUpdate:output_type -> api.BatchResponse
-	48, // [48:68] is the sub-list for method output_type
-	28, // [28:48] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	48, // 0: api.Entity.fields:type_name -> api.Entity.FieldsEntry
+	49, // 1: api.Entity.binary_fields:type_name -> api.Entity.BinaryFieldsEntry
+	50, // 2: api.Entity.values:type_name -> api.Entity.ValuesEntry
+	54, // 3: api.Value.timestamp_value:type_name -> google.protobuf.Timestamp
+	3,  // 4: api.Value.date_value:type_name -> api.Date
+	1,  // 5: api.CreateRequest.entity:type_name -> api.Entity
+	51, // 6: api.GetRequest.filters:type_name -> api.GetRequest.FiltersEntry
+	1,  // 7: api.UpdateRequest.entity:type_name -> api.Entity
+	52, // 8: api.ListRequest.filters:type_name -> api.ListRequest.FiltersEntry
+	10, // 9: api.ListRequest.filter:type_name -> api.Filter
+	12, // 10: api.Filter.condition:type_name -> api.Condition
+	11, // 11: api.Filter.and:type_name -> api.FilterGroup
+	11, // 12: api.Filter.or:type_name -> api.FilterGroup
+	10, // 13: api.Filter.not:type_name -> api.Filter
+	10, // 14: api.FilterGroup.filters:type_name -> api.Filter
+	0,  // 15: api.Condition.operator:type_name -> api.FilterOperator
+	2,  // 16: api.Condition.values:type_name -> api.Value
+	10, // 17: api.SearchRequest.filter:type_name -> api.Filter
+	1,  // 18: api.EntityResponse.entity:type_name -> api.Entity
+	1,  // 19: api.ListResponse.entities:type_name -> api.Entity
+	53, // 20: api.ExportRequest.filters:type_name -> api.ExportRequest.FiltersEntry
+	10, // 21: api.ExportRequest.filter:type_name -> api.Filter
+	1,  // 22: api.ExportChunk.entities:type_name -> api.Entity
+	1,  // 23: api.BatchCreateRequest.entities:type_name -> api.Entity
+	1,  // 24: api.BatchUpdateRequest.entities:type_name -> api.Entity
+	25, // 25: api.OrganizationResponse.organization:type_name -> api.Organization
+	26, // 26: api.OrganizationResponse.addresses:type_name -> api.Address
+	27, // 27: api.OrganizationResponse.contacts:type_name -> api.Contact
+	28, // 28: api.OrganizationResponse.financial_indicators:type_name -> api.FinancialIndicator
+	29, // 29: api.OrganizationResponse.staff_indicators:type_name -> api.StaffIndicator
+	54, // 30: api.Organization.created_at:type_name -> google.protobuf.Timestamp
+	54, // 31: api.Organization.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 32: api.UserResponse.user:type_name -> api.User
+	54, // 33: api.User.last_login:type_name -> google.protobuf.Timestamp
+	54, // 34: api.User.created_at:type_name -> google.protobuf.Timestamp
+	54, // 35: api.User.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 36: api.InviteResponse.invite:type_name -> api.Invite
+	54, // 37: api.Invite.expires_at:type_name -> google.protobuf.Timestamp
+	54, // 38: api.Invite.created_at:type_name -> google.protobuf.Timestamp
+	54, // 39: api.FormResponse.created_at:type_name -> google.protobuf.Timestamp
+	28, // 40: api.FinancialDataResponse.indicators:type_name -> api.FinancialIndicator
+	29, // 41: api.StaffDataResponse.indicators:type_name -> api.StaffIndicator
+	25, // 42: api.ListOrganizationsResponse.organizations:type_name -> api.Organization
+	2,  // 43: api.Entity.ValuesEntry.value:type_name -> api.Value
+	4,  // 44: api.DataService.Create:input_type -> api.CreateRequest
+	5,  // 45: api.DataService.Get:input_type -> api.GetRequest
+	6,  // 46: api.DataService.Update:input_type -> api.UpdateRequest
+	7,  // 47: api.DataService.Delete:input_type -> api.DeleteRequest
+	9,  // 48: api.DataService.List:input_type -> api.ListRequest
+	13, // 49: api.DataService.Search:input_type -> api.SearchRequest
+	21, // 50: api.DataService.GetOrganization:input_type -> api.GetOrganizationRequest
+	22, // 51: api.DataService.ListOrganizations:input_type -> api.ListOrganizationsRequest
+	23, // 52: api.DataService.SearchOrganizations:input_type -> api.SearchOrganizationsRequest
+	30, // 53: api.DataService.GetUser:input_type -> api.GetUserRequest
+	31, // 54: api.DataService.CreateUser:input_type -> api.CreateUserRequest
+	32, // 55: api.DataService.UpdateUser:input_type -> api.UpdateUserRequest
+	35, // 56: api.DataService.CreateInvite:input_type -> api.CreateInviteRequest
+	36, // 57: api.DataService.ValidateInvite:input_type -> api.ValidateInviteRequest
+	37, // 58: api.DataService.UseInvite:input_type -> api.UseInviteRequest
+	40, // 59: api.DataService.SubmitForm:input_type -> api.SubmitFormRequest
+	43, // 60: api.DataService.GetFinancialData:input_type -> api.GetFinancialDataRequest
+	45, // 61: api.DataService.GetStaffData:input_type -> api.GetStaffDataRequest
+	18, // 62: api.DataService.BatchCreate:input_type -> api.BatchCreateRequest
+	19, // 63: api.DataService.BatchUpdate:input_type -> api.BatchUpdateRequest
+	16, // 64: api.DataService.Export:input_type -> api.ExportRequest
+	14, // 65: api.DataService.Create:output_type -> api.EntityResponse
+	14, // 66: api.DataService.Get:output_type -> api.EntityResponse
+	14, // 67: api.DataService.Update:output_type -> api.EntityResponse
+	8,  // 68: api.DataService.Delete:output_type -> api.DeleteResponse
+	15, // 69: api.DataService.List:output_type -> api.ListResponse
+	15, // 70: api.DataService.Search:output_type -> api.ListResponse
+	24, // 71: api.DataService.GetOrganization:output_type -> api.OrganizationResponse
+	47, // 72: api.DataService.ListOrganizations:output_type -> api.ListOrganizationsResponse
+	47, // 73: api.DataService.SearchOrganizations:output_type -> api.ListOrganizationsResponse
+	33, // 74: api.DataService.GetUser:output_type -> api.UserResponse
+	33, // 75: api.DataService.CreateUser:output_type -> api.UserResponse
+	33, // 76: api.DataService.UpdateUser:output_type -> api.UserResponse
+	38, // 77: api.DataService.CreateInvite:output_type -> api.InviteResponse
+	38, // 78: api.DataService.ValidateInvite:output_type -> api.InviteResponse
+	38, // 79: api.DataService.UseInvite:output_type -> api.InviteResponse
+	42, // 80: api.DataService.SubmitForm:output_type -> api.FormResponse
+	44, // 81: api.DataService.GetFinancialData:output_type -> api.FinancialDataResponse
+	46, // 82: api.DataService.GetStaffData:output_type -> api.StaffDataResponse
+	20, // 83: api.DataService.BatchCreate:output_type -> api.BatchResponse
+	20, // 84: api.DataService.BatchUpdate:output_type -> api.BatchResponse
+	17, // 85: api.DataService.Export:output_type -> api.ExportChunk
+	65, // [65:86] is the sub-list for method output_type
+	44, // [44:65] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_init() }
@@ -3259,26 +4213,46 @@ func file_api_proto_init() {
 	if File_api_proto != nil {
 		return
 	}
-	file_api_proto_msgTypes[13].OneofWrappers = []any{
+	file_api_proto_msgTypes[1].OneofWrappers = []any{
+		(*Value_NullValue)(nil),
+		(*Value_BoolValue)(nil),
+		(*Value_Int64Value)(nil),
+		(*Value_DoubleValue)(nil),
+		(*Value_DecimalValue)(nil),
+		(*Value_TimestampValue)(nil),
+		(*Value_DateValue)(nil),
+		(*Value_BytesValue)(nil),
+		(*Value_JsonValue)(nil),
+		(*Value_StringValue)(nil),
+	}
+	file_api_proto_msgTypes[9].OneofWrappers = []any{
+		(*Filter_Condition)(nil),
+		(*Filter_And)(nil),
+		(*Filter_Or)(nil),
+		(*Filter_Not)(nil),
+	}
+	file_api_proto_msgTypes[20].OneofWrappers = []any{
 		(*GetOrganizationRequest_Id)(nil),
 		(*GetOrganizationRequest_Inn)(nil),
 	}
-	file_api_proto_msgTypes[22].OneofWrappers = []any{
+	file_api_proto_msgTypes[29].OneofWrappers = []any{
 		(*GetUserRequest_Id)(nil),
 		(*GetUserRequest_Email)(nil),
 	}
+	file_api_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   44,
+			NumEnums:      1,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_proto_goTypes,
 		DependencyIndexes: file_api_proto_depIdxs,
+		EnumInfos:         file_api_proto_enumTypes,
 		MessageInfos:      file_api_proto_msgTypes,
 	}.Build()
 	File_api_proto = out.File
@@ -39,6 +39,7 @@ const (
 	DataService_GetStaffData_FullMethodName        = "/api.DataService/GetStaffData"
 	DataService_BatchCreate_FullMethodName         = "/api.DataService/BatchCreate"
 	DataService_BatchUpdate_FullMethodName         = "/api.DataService/BatchUpdate"
+	DataService_Export_FullMethodName              = "/api.DataService/Export"
 )
 
 // DataServiceClient is the client API for DataService service.
@@ -68,6 +69,8 @@ type DataServiceClient interface {
 	// Пакетные операции
 	BatchCreate(ctx context.Context, in *BatchCreateRequest, opts ...grpc.CallOption) (*BatchResponse, error)
 	BatchUpdate(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	// Потоковая выгрузка таблицы
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
 }
 
 type dataServiceClient struct {
@@ -278,6 +281,25 @@ func (c *dataServiceClient) BatchUpdate(ctx context.Context, in *BatchUpdateRequ
 	return out, nil
 }
 
+func (c *dataServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataService_ServiceDesc.Streams[0], DataService_Export_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataService_ExportClient = grpc.ServerStreamingClient[ExportChunk]
+
 // DataServiceServer is the server API for DataService service.
 // All implementations must embed UnimplementedDataServiceServer
 // for forward compatibility.
@@ -305,6 +327,8 @@ type DataServiceServer interface {
 	// Пакетные операции
 	BatchCreate(context.Context, *BatchCreateRequest) (*BatchResponse, error)
 	BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchResponse, error)
+	// Потоковая выгрузка таблицы
+	Export(*ExportRequest, grpc.ServerStreamingServer[ExportChunk]) error
 	mustEmbedUnimplementedDataServiceServer()
 }
 
@@ -375,6 +399,9 @@ func (UnimplementedDataServiceServer) BatchCreate(context.Context, *BatchCreateR
 func (UnimplementedDataServiceServer) BatchUpdate(context.Context, *BatchUpdateRequest) (*BatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdate not implemented")
 }
+func (UnimplementedDataServiceServer) Export(*ExportRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 func (UnimplementedDataServiceServer) mustEmbedUnimplementedDataServiceServer() {}
 func (UnimplementedDataServiceServer) testEmbeddedByValue()                     {}
 
@@ -756,6 +783,17 @@ func _DataService_BatchUpdate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataService_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataServiceServer).Export(m, &grpc.GenericServerStream[ExportRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DataService_ExportServer = grpc.ServerStreamingServer[ExportChunk]
+
 // DataService_ServiceDesc is the grpc.ServiceDesc for DataService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -844,6 +882,12 @@ var DataService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _DataService_BatchUpdate_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Export",
+			Handler:       _DataService_Export_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "api.proto",
 }
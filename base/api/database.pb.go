@@ -9,6 +9,7 @@ package api
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -169,6 +170,8 @@ type CommandRequest struct {
 	//	*CommandRequest_GetFinancialData
 	//	*CommandRequest_GetStaffData
 	//	*CommandRequest_SystemCommand
+	//	*CommandRequest_Export
+	//	*CommandRequest_ExportCredit
 	Command       isCommandRequest_Command `protobuf_oneof:"command"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -407,6 +410,24 @@ func (x *CommandRequest) GetSystemCommand() string {
 	return ""
 }
 
+func (x *CommandRequest) GetExport() *ExportRequest {
+	if x != nil {
+		if x, ok := x.Command.(*CommandRequest_Export); ok {
+			return x.Export
+		}
+	}
+	return nil
+}
+
+func (x *CommandRequest) GetExportCredit() *ExportCredit {
+	if x != nil {
+		if x, ok := x.Command.(*CommandRequest_ExportCredit); ok {
+			return x.ExportCredit
+		}
+	}
+	return nil
+}
+
 type isCommandRequest_Command interface {
 	isCommandRequest_Command()
 }
@@ -498,6 +519,15 @@ type CommandRequest_SystemCommand struct {
 	SystemCommand string `protobuf:"bytes,22,opt,name=system_command,json=systemCommand,proto3,oneof"`
 }
 
+type CommandRequest_Export struct {
+	// Потоковые команды
+	Export *ExportRequest `protobuf:"bytes,23,opt,name=export,proto3,oneof"`
+}
+
+type CommandRequest_ExportCredit struct {
+	ExportCredit *ExportCredit `protobuf:"bytes,24,opt,name=export_credit,json=exportCredit,proto3,oneof"`
+}
+
 func (*CommandRequest_Create) isCommandRequest_Command() {}
 
 func (*CommandRequest_Get) isCommandRequest_Command() {}
@@ -540,6 +570,10 @@ func (*CommandRequest_GetStaffData) isCommandRequest_Command() {}
 
 func (*CommandRequest_SystemCommand) isCommandRequest_Command() {}
 
+func (*CommandRequest_Export) isCommandRequest_Command() {}
+
+func (*CommandRequest_ExportCredit) isCommandRequest_Command() {}
+
 type CommandResponse struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
 	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
@@ -559,6 +593,8 @@ type CommandResponse struct {
 	//	*CommandResponse_Error
 	//	*CommandResponse_Ready
 	//	*CommandResponse_System
+	//	*CommandResponse_Schema
+	//	*CommandResponse_ExportChunk
 	Response      isCommandResponse_Response `protobuf_oneof:"response"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -734,6 +770,24 @@ func (x *CommandResponse) GetSystem() *SystemResponse {
 	return nil
 }
 
+func (x *CommandResponse) GetSchema() *SchemaResponse {
+	if x != nil {
+		if x, ok := x.Response.(*CommandResponse_Schema); ok {
+			return x.Schema
+		}
+	}
+	return nil
+}
+
+func (x *CommandResponse) GetExportChunk() *ExportChunk {
+	if x != nil {
+		if x, ok := x.Response.(*CommandResponse_ExportChunk); ok {
+			return x.ExportChunk
+		}
+	}
+	return nil
+}
+
 type isCommandResponse_Response interface {
 	isCommandResponse_Response()
 }
@@ -797,6 +851,15 @@ type CommandResponse_System struct {
 	System *SystemResponse `protobuf:"bytes,15,opt,name=system,proto3,oneof"`
 }
 
+type CommandResponse_Schema struct {
+	Schema *SchemaResponse `protobuf:"bytes,16,opt,name=schema,proto3,oneof"`
+}
+
+type CommandResponse_ExportChunk struct {
+	// Потоковые ответы: несколько фрагментов с одним request_id
+	ExportChunk *ExportChunk `protobuf:"bytes,17,opt,name=export_chunk,json=exportChunk,proto3,oneof"`
+}
+
 func (*CommandResponse_Entity) isCommandResponse_Response() {}
 
 func (*CommandResponse_List) isCommandResponse_Response() {}
@@ -825,6 +888,66 @@ func (*CommandResponse_Ready) isCommandResponse_Response() {}
 
 func (*CommandResponse_System) isCommandResponse_Response() {}
 
+func (*CommandResponse_Schema) isCommandResponse_Response() {}
+
+func (*CommandResponse_ExportChunk) isCommandResponse_Response() {}
+
+// ExportCredit управление потоком выгрузки: request_id совпадает с командой export.
+// Воркер отправляет не больше фрагментов, чем ему разрешено, поэтому медленный
+// клиент не переполняет память mainservice и не задерживает ответы на другие команды.
+// Первые фрагменты разрешает ExportRequest.window, дальше - по одному на принятый.
+type ExportCredit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunks        int32                  `protobuf:"varint,1,opt,name=chunks,proto3" json:"chunks,omitempty"` // Сколько еще фрагментов можно отправить
+	Cancel        bool                   `protobuf:"varint,2,opt,name=cancel,proto3" json:"cancel,omitempty"` // Клиент отключился: закрыть курсор и прекратить выгрузку
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCredit) Reset() {
+	*x = ExportCredit{}
+	mi := &file_database_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCredit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCredit) ProtoMessage() {}
+
+func (x *ExportCredit) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCredit.ProtoReflect.Descriptor instead.
+func (*ExportCredit) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportCredit) GetChunks() int32 {
+	if x != nil {
+		return x.Chunks
+	}
+	return 0
+}
+
+func (x *ExportCredit) GetCancel() bool {
+	if x != nil {
+		return x.Cancel
+	}
+	return false
+}
+
 type SystemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -836,7 +959,7 @@ type SystemResponse struct {
 
 func (x *SystemResponse) Reset() {
 	*x = SystemResponse{}
-	mi := &file_database_proto_msgTypes[4]
+	mi := &file_database_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -848,7 +971,7 @@ func (x *SystemResponse) String() string {
 func (*SystemResponse) ProtoMessage() {}
 
 func (x *SystemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[4]
+	mi := &file_database_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -861,7 +984,7 @@ func (x *SystemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemResponse.ProtoReflect.Descriptor instead.
 func (*SystemResponse) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{4}
+	return file_database_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SystemResponse) GetSuccess() bool {
@@ -885,16 +1008,193 @@ func (x *SystemResponse) GetData() map[string]string {
 	return nil
 }
 
-type ReadyMessage struct {
+// Схема БД, доступная универсальным CRUD командам: ответ на системные команды
+// schema и schema_refresh
+type SchemaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	SchemaName    string                 `protobuf:"bytes,1,opt,name=schema_name,json=schemaName,proto3" json:"schema_name,omitempty"`
+	Tables        []*TableSchema         `protobuf:"bytes,2,rep,name=tables,proto3" json:"tables,omitempty"`
+	LoadedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=loaded_at,json=loadedAt,proto3" json:"loaded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaResponse) Reset() {
+	*x = SchemaResponse{}
+	mi := &file_database_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaResponse) ProtoMessage() {}
+
+func (x *SchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaResponse.ProtoReflect.Descriptor instead.
+func (*SchemaResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SchemaResponse) GetSchemaName() string {
+	if x != nil {
+		return x.SchemaName
+	}
+	return ""
+}
+
+func (x *SchemaResponse) GetTables() []*TableSchema {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *SchemaResponse) GetLoadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LoadedAt
+	}
+	return nil
+}
+
+type TableSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Columns       []*ColumnSchema        `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableSchema) Reset() {
+	*x = TableSchema{}
+	mi := &file_database_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableSchema) ProtoMessage() {}
+
+func (x *TableSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableSchema.ProtoReflect.Descriptor instead.
+func (*TableSchema) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TableSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TableSchema) GetColumns() []*ColumnSchema {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+type ColumnSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DataType      string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"` // information_schema.columns.data_type, например "numeric" или "timestamp with time zone"
+	Nullable      bool                   `protobuf:"varint,3,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnSchema) Reset() {
+	*x = ColumnSchema{}
+	mi := &file_database_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnSchema) ProtoMessage() {}
+
+func (x *ColumnSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnSchema.ProtoReflect.Descriptor instead.
+func (*ColumnSchema) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ColumnSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ColumnSchema) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *ColumnSchema) GetNullable() bool {
+	if x != nil {
+		return x.Nullable
+	}
+	return false
+}
+
+type ReadyMessage struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Уникальный идентификатор экземпляра сервиса БД (несколько воркеров могут
+	// использовать один и тот же сертификат)
+	InstanceId    string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ReadyMessage) Reset() {
 	*x = ReadyMessage{}
-	mi := &file_database_proto_msgTypes[5]
+	mi := &file_database_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -906,7 +1206,7 @@ func (x *ReadyMessage) String() string {
 func (*ReadyMessage) ProtoMessage() {}
 
 func (x *ReadyMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[5]
+	mi := &file_database_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,7 +1219,7 @@ func (x *ReadyMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadyMessage.ProtoReflect.Descriptor instead.
 func (*ReadyMessage) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{5}
+	return file_database_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ReadyMessage) GetServiceName() string {
@@ -929,6 +1229,13 @@ func (x *ReadyMessage) GetServiceName() string {
 	return ""
 }
 
+func (x *ReadyMessage) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
 type ErrorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -940,7 +1247,7 @@ type ErrorResponse struct {
 
 func (x *ErrorResponse) Reset() {
 	*x = ErrorResponse{}
-	mi := &file_database_proto_msgTypes[6]
+	mi := &file_database_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -952,7 +1259,7 @@ func (x *ErrorResponse) String() string {
 func (*ErrorResponse) ProtoMessage() {}
 
 func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[6]
+	mi := &file_database_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -965,7 +1272,7 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{6}
+	return file_database_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ErrorResponse) GetMessage() string {
@@ -993,7 +1300,7 @@ var File_database_proto protoreflect.FileDescriptor
 
 const file_database_proto_rawDesc = "" +
 	"\n" +
-	"\x0edatabase.proto\x12\x03api\x1a\tapi.proto\"<\n" +
+	"\x0edatabase.proto\x12\x03api\x1a\tapi.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"<\n" +
 	"\x1bDatabaseRegistrationRequest\x12\x1d\n" +
 	"\n" +
 	"service_id\x18\x01 \x01(\tR\tserviceId\"\xbb\x01\n" +
@@ -1004,7 +1311,7 @@ const file_database_proto_rawDesc = "" +
 	"\vcommon_name\x18\x03 \x01(\tR\n" +
 	"commonName\x12\x1c\n" +
 	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\x12#\n" +
-	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"\x87\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"\xef\n" +
 	"\n" +
 	"\x0eCommandRequest\x12\x1d\n" +
 	"\n" +
@@ -1034,8 +1341,10 @@ const file_database_proto_rawDesc = "" +
 	"submitForm\x12L\n" +
 	"\x12get_financial_data\x18\x14 \x01(\v2\x1c.api.GetFinancialDataRequestH\x00R\x10getFinancialData\x12@\n" +
 	"\x0eget_staff_data\x18\x15 \x01(\v2\x18.api.GetStaffDataRequestH\x00R\fgetStaffData\x12'\n" +
-	"\x0esystem_command\x18\x16 \x01(\tH\x00R\rsystemCommandB\t\n" +
-	"\acommand\"\xfd\x05\n" +
+	"\x0esystem_command\x18\x16 \x01(\tH\x00R\rsystemCommand\x12,\n" +
+	"\x06export\x18\x17 \x01(\v2\x12.api.ExportRequestH\x00R\x06export\x128\n" +
+	"\rexport_credit\x18\x18 \x01(\v2\x11.api.ExportCreditH\x00R\fexportCreditB\t\n" +
+	"\acommand\"\xe3\x06\n" +
 	"\x0fCommandResponse\x12\x1d\n" +
 	"\n" +
 	"request_id\x18\x01 \x01(\tR\trequestId\x12-\n" +
@@ -1054,18 +1363,37 @@ const file_database_proto_rawDesc = "" +
 	"staff_data\x18\f \x01(\v2\x16.api.StaffDataResponseH\x00R\tstaffData\x12*\n" +
 	"\x05error\x18\r \x01(\v2\x12.api.ErrorResponseH\x00R\x05error\x12)\n" +
 	"\x05ready\x18\x0e \x01(\v2\x11.api.ReadyMessageH\x00R\x05ready\x12-\n" +
-	"\x06system\x18\x0f \x01(\v2\x13.api.SystemResponseH\x00R\x06systemB\n" +
+	"\x06system\x18\x0f \x01(\v2\x13.api.SystemResponseH\x00R\x06system\x12-\n" +
+	"\x06schema\x18\x10 \x01(\v2\x13.api.SchemaResponseH\x00R\x06schema\x125\n" +
+	"\fexport_chunk\x18\x11 \x01(\v2\x10.api.ExportChunkH\x00R\vexportChunkB\n" +
 	"\n" +
-	"\bresponse\"\xb0\x01\n" +
+	"\bresponse\">\n" +
+	"\fExportCredit\x12\x16\n" +
+	"\x06chunks\x18\x01 \x01(\x05R\x06chunks\x12\x16\n" +
+	"\x06cancel\x18\x02 \x01(\bR\x06cancel\"\xb0\x01\n" +
 	"\x0eSystemResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x121\n" +
 	"\x04data\x18\x03 \x03(\v2\x1d.api.SystemResponse.DataEntryR\x04data\x1a7\n" +
 	"\tDataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"1\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x94\x01\n" +
+	"\x0eSchemaResponse\x12\x1f\n" +
+	"\vschema_name\x18\x01 \x01(\tR\n" +
+	"schemaName\x12(\n" +
+	"\x06tables\x18\x02 \x03(\v2\x10.api.TableSchemaR\x06tables\x127\n" +
+	"\tloaded_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bloadedAt\"N\n" +
+	"\vTableSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\acolumns\x18\x02 \x03(\v2\x11.api.ColumnSchemaR\acolumns\"[\n" +
+	"\fColumnSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tdata_type\x18\x02 \x01(\tR\bdataType\x12\x1a\n" +
+	"\bnullable\x18\x03 \x01(\bR\bnullable\"R\n" +
 	"\fReadyMessage\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"W\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\"W\n" +
 	"\rErrorResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
@@ -1086,93 +1414,107 @@ func file_database_proto_rawDescGZIP() []byte {
 	return file_database_proto_rawDescData
 }
 
-var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_database_proto_goTypes = []any{
 	(*DatabaseRegistrationRequest)(nil),  // 0: api.DatabaseRegistrationRequest
 	(*DatabaseRegistrationResponse)(nil), // 1: api.DatabaseRegistrationResponse
 	(*CommandRequest)(nil),               // 2: api.CommandRequest
 	(*CommandResponse)(nil),              // 3: api.CommandResponse
-	(*SystemResponse)(nil),               // 4: api.SystemResponse
-	(*ReadyMessage)(nil),                 // 5: api.ReadyMessage
-	(*ErrorResponse)(nil),                // 6: api.ErrorResponse
-	nil,                                  // 7: api.SystemResponse.DataEntry
-	(*CreateRequest)(nil),                // 8: api.CreateRequest
-	(*GetRequest)(nil),                   // 9: api.GetRequest
-	(*UpdateRequest)(nil),                // 10: api.UpdateRequest
-	(*DeleteRequest)(nil),                // 11: api.DeleteRequest
-	(*ListRequest)(nil),                  // 12: api.ListRequest
-	(*SearchRequest)(nil),                // 13: api.SearchRequest
-	(*BatchCreateRequest)(nil),           // 14: api.BatchCreateRequest
-	(*BatchUpdateRequest)(nil),           // 15: api.BatchUpdateRequest
-	(*GetOrganizationRequest)(nil),       // 16: api.GetOrganizationRequest
-	(*ListOrganizationsRequest)(nil),     // 17: api.ListOrganizationsRequest
-	(*SearchOrganizationsRequest)(nil),   // 18: api.SearchOrganizationsRequest
-	(*GetUserRequest)(nil),               // 19: api.GetUserRequest
-	(*CreateUserRequest)(nil),            // 20: api.CreateUserRequest
-	(*UpdateUserRequest)(nil),            // 21: api.UpdateUserRequest
-	(*CreateInviteRequest)(nil),          // 22: api.CreateInviteRequest
-	(*ValidateInviteRequest)(nil),        // 23: api.ValidateInviteRequest
-	(*UseInviteRequest)(nil),             // 24: api.UseInviteRequest
-	(*SubmitFormRequest)(nil),            // 25: api.SubmitFormRequest
-	(*GetFinancialDataRequest)(nil),      // 26: api.GetFinancialDataRequest
-	(*GetStaffDataRequest)(nil),          // 27: api.GetStaffDataRequest
-	(*EntityResponse)(nil),               // 28: api.EntityResponse
-	(*ListResponse)(nil),                 // 29: api.ListResponse
-	(*DeleteResponse)(nil),               // 30: api.DeleteResponse
-	(*BatchResponse)(nil),                // 31: api.BatchResponse
-	(*OrganizationResponse)(nil),         // 32: api.OrganizationResponse
-	(*ListOrganizationsResponse)(nil),    // 33: api.ListOrganizationsResponse
-	(*UserResponse)(nil),                 // 34: api.UserResponse
-	(*InviteResponse)(nil),               // 35: api.InviteResponse
-	(*FormResponse)(nil),                 // 36: api.FormResponse
-	(*FinancialDataResponse)(nil),        // 37: api.FinancialDataResponse
-	(*StaffDataResponse)(nil),            // 38: api.StaffDataResponse
+	(*ExportCredit)(nil),                 // 4: api.ExportCredit
+	(*SystemResponse)(nil),               // 5: api.SystemResponse
+	(*SchemaResponse)(nil),               // 6: api.SchemaResponse
+	(*TableSchema)(nil),                  // 7: api.TableSchema
+	(*ColumnSchema)(nil),                 // 8: api.ColumnSchema
+	(*ReadyMessage)(nil),                 // 9: api.ReadyMessage
+	(*ErrorResponse)(nil),                // 10: api.ErrorResponse
+	nil,                                  // 11: api.SystemResponse.DataEntry
+	(*CreateRequest)(nil),                // 12: api.CreateRequest
+	(*GetRequest)(nil),                   // 13: api.GetRequest
+	(*UpdateRequest)(nil),                // 14: api.UpdateRequest
+	(*DeleteRequest)(nil),                // 15: api.DeleteRequest
+	(*ListRequest)(nil),                  // 16: api.ListRequest
+	(*SearchRequest)(nil),                // 17: api.SearchRequest
+	(*BatchCreateRequest)(nil),           // 18: api.BatchCreateRequest
+	(*BatchUpdateRequest)(nil),           // 19: api.BatchUpdateRequest
+	(*GetOrganizationRequest)(nil),       // 20: api.GetOrganizationRequest
+	(*ListOrganizationsRequest)(nil),     // 21: api.ListOrganizationsRequest
+	(*SearchOrganizationsRequest)(nil),   // 22: api.SearchOrganizationsRequest
+	(*GetUserRequest)(nil),               // 23: api.GetUserRequest
+	(*CreateUserRequest)(nil),            // 24: api.CreateUserRequest
+	(*UpdateUserRequest)(nil),            // 25: api.UpdateUserRequest
+	(*CreateInviteRequest)(nil),          // 26: api.CreateInviteRequest
+	(*ValidateInviteRequest)(nil),        // 27: api.ValidateInviteRequest
+	(*UseInviteRequest)(nil),             // 28: api.UseInviteRequest
+	(*SubmitFormRequest)(nil),            // 29: api.SubmitFormRequest
+	(*GetFinancialDataRequest)(nil),      // 30: api.GetFinancialDataRequest
+	(*GetStaffDataRequest)(nil),          // 31: api.GetStaffDataRequest
+	(*ExportRequest)(nil),                // 32: api.ExportRequest
+	(*EntityResponse)(nil),               // 33: api.EntityResponse
+	(*ListResponse)(nil),                 // 34: api.ListResponse
+	(*DeleteResponse)(nil),               // 35: api.DeleteResponse
+	(*BatchResponse)(nil),                // 36: api.BatchResponse
+	(*OrganizationResponse)(nil),         // 37: api.OrganizationResponse
+	(*ListOrganizationsResponse)(nil),    // 38: api.ListOrganizationsResponse
+	(*UserResponse)(nil),                 // 39: api.UserResponse
+	(*InviteResponse)(nil),               // 40: api.InviteResponse
+	(*FormResponse)(nil),                 // 41: api.FormResponse
+	(*FinancialDataResponse)(nil),        // 42: api.FinancialDataResponse
+	(*StaffDataResponse)(nil),            // 43: api.StaffDataResponse
+	(*ExportChunk)(nil),                  // 44: api.ExportChunk
+	(*timestamppb.Timestamp)(nil),        // 45: google.protobuf.Timestamp
 }
 var file_database_proto_depIdxs = []int32{
-	8,  // 0: api.CommandRequest.create:type_name -> api.CreateRequest
-	9,  // 1: api.CommandRequest.get:type_name -> api.GetRequest
-	10, // 2: api.CommandRequest.update:type_name -> api.UpdateRequest
-	11, // 3: api.CommandRequest.delete:type_name -> api.DeleteRequest
-	12, // 4: api.CommandRequest.list:type_name -> api.ListRequest
-	13, // 5: api.CommandRequest.search:type_name -> api.SearchRequest
-	14, // 6: api.CommandRequest.batch_create:type_name -> api.BatchCreateRequest
-	15, // 7: api.CommandRequest.batch_update:type_name -> api.BatchUpdateRequest
-	16, // 8: api.CommandRequest.get_organization:type_name -> api.GetOrganizationRequest
-	17, // 9: api.CommandRequest.list_organizations:type_name -> api.ListOrganizationsRequest
-	18, // 10: api.CommandRequest.search_organizations:type_name -> api.SearchOrganizationsRequest
-	19, // 11: api.CommandRequest.get_user:type_name -> api.GetUserRequest
-	20, // 12: api.CommandRequest.create_user:type_name -> api.CreateUserRequest
-	21, // 13: api.CommandRequest.update_user:type_name -> api.UpdateUserRequest
-	22, // 14: api.CommandRequest.create_invite:type_name -> api.CreateInviteRequest
-	23, // 15: api.CommandRequest.validate_invite:type_name -> api.ValidateInviteRequest
-	24, // 16: api.CommandRequest.use_invite:type_name -> api.UseInviteRequest
-	25, // 17: api.CommandRequest.submit_form:type_name -> api.SubmitFormRequest
-	26, // 18: api.CommandRequest.get_financial_data:type_name -> api.GetFinancialDataRequest
-	27, // 19: api.CommandRequest.get_staff_data:type_name -> api.GetStaffDataRequest
-	28, // 20: api.CommandResponse.entity:type_name -> api.EntityResponse
-	29, // 21: api.CommandResponse.list:type_name -> api.ListResponse
-	30, // 22: api.CommandResponse.delete:type_name -> api.DeleteResponse
-	31, // 23: api.CommandResponse.batch:type_name -> api.BatchResponse
-	32, // 24: api.CommandResponse.organization:type_name -> api.OrganizationResponse
-	33, // 25: api.CommandResponse.organizations:type_name -> api.ListOrganizationsResponse
-	34, // 26: api.CommandResponse.user:type_name -> api.UserResponse
-	35, // 27: api.CommandResponse.invite:type_name -> api.InviteResponse
-	36, // 28: api.CommandResponse.form:type_name -> api.FormResponse
-	37, // 29: api.CommandResponse.financial_data:type_name -> api.FinancialDataResponse
-	38, // 30: api.CommandResponse.staff_data:type_name -> api.StaffDataResponse
-	6,  // 31: api.CommandResponse.error:type_name -> api.ErrorResponse
-	5,  // 32: api.CommandResponse.ready:type_name -> api.ReadyMessage
-	4,  // 33: api.CommandResponse.system:type_name -> api.SystemResponse
-	7,  // 34: api.SystemResponse.data:type_name -> api.SystemResponse.DataEntry
-	3,  // 35: api.DatabaseService.CommandStream:input_type -> api.CommandResponse
-	0,  // 36: api.DatabaseService.RegisterDatabase:input_type -> api.DatabaseRegistrationRequest
-	2,  // 37: api.DatabaseService.CommandStream:output_type -> api.CommandRequest
-	1,  // 38: api.DatabaseService.RegisterDatabase:output_type -> api.DatabaseRegistrationResponse
-	37, // [37:39] is the sub-list for method output_type
-	35, // [35:37] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	12, // 0: api.CommandRequest.create:type_name -> api.CreateRequest
+	13, // 1: api.CommandRequest.get:type_name -> api.GetRequest
+	14, // 2: api.CommandRequest.update:type_name -> api.UpdateRequest
+	15, // 3: api.CommandRequest.delete:type_name -> api.DeleteRequest
+	16, // 4: api.CommandRequest.list:type_name -> api.ListRequest
+	17, // 5: api.CommandRequest.search:type_name -> api.SearchRequest
+	18, // 6: api.CommandRequest.batch_create:type_name -> api.BatchCreateRequest
+	19, // 7: api.CommandRequest.batch_update:type_name -> api.BatchUpdateRequest
+	20, // 8: api.CommandRequest.get_organization:type_name -> api.GetOrganizationRequest
+	21, // 9: api.CommandRequest.list_organizations:type_name -> api.ListOrganizationsRequest
+	22, // 10: api.CommandRequest.search_organizations:type_name -> api.SearchOrganizationsRequest
+	23, // 11: api.CommandRequest.get_user:type_name -> api.GetUserRequest
+	24, // 12: api.CommandRequest.create_user:type_name -> api.CreateUserRequest
+	25, // 13: api.CommandRequest.update_user:type_name -> api.UpdateUserRequest
+	26, // 14: api.CommandRequest.create_invite:type_name -> api.CreateInviteRequest
+	27, // 15: api.CommandRequest.validate_invite:type_name -> api.ValidateInviteRequest
+	28, // 16: api.CommandRequest.use_invite:type_name -> api.UseInviteRequest
+	29, // 17: api.CommandRequest.submit_form:type_name -> api.SubmitFormRequest
+	30, // 18: api.CommandRequest.get_financial_data:type_name -> api.GetFinancialDataRequest
+	31, // 19: api.CommandRequest.get_staff_data:type_name -> api.GetStaffDataRequest
+	32, // 20: api.CommandRequest.export:type_name -> api.ExportRequest
+	4,  // 21: api.CommandRequest.export_credit:type_name -> api.ExportCredit
+	33, // 22: api.CommandResponse.entity:type_name -> api.EntityResponse
+	34, // 23: api.CommandResponse.list:type_name -> api.ListResponse
+	35, // 24: api.CommandResponse.delete:type_name -> api.DeleteResponse
+	36, // 25: api.CommandResponse.batch:type_name -> api.BatchResponse
+	37, // 26: api.CommandResponse.organization:type_name -> api.OrganizationResponse
+	38, // 27: api.CommandResponse.organizations:type_name -> api.ListOrganizationsResponse
+	39, // 28: api.CommandResponse.user:type_name -> api.UserResponse
+	40, // 29: api.CommandResponse.invite:type_name -> api.InviteResponse
+	41, // 30: api.CommandResponse.form:type_name -> api.FormResponse
+	42, // 31: api.CommandResponse.financial_data:type_name -> api.FinancialDataResponse
+	43, // 32: api.CommandResponse.staff_data:type_name -> api.StaffDataResponse
+	10, // 33: api.CommandResponse.error:type_name -> api.ErrorResponse
+	9,  // 34: api.CommandResponse.ready:type_name -> api.ReadyMessage
+	5,  // 35: api.CommandResponse.system:type_name -> api.SystemResponse
+	6,  // 36: api.CommandResponse.schema:type_name -> api.SchemaResponse
+	44, // 37: api.CommandResponse.export_chunk:type_name -> api.ExportChunk
+	11, // 38: api.SystemResponse.data:type_name -> api.SystemResponse.DataEntry
+	7,  // 39: api.SchemaResponse.tables:type_name -> api.TableSchema
+	45, // 40: api.SchemaResponse.loaded_at:type_name -> google.protobuf.Timestamp
+	8,  // 41: api.TableSchema.columns:type_name -> api.ColumnSchema
+	3,  // 42: api.DatabaseService.CommandStream:input_type -> api.CommandResponse
+	0,  // 43: api.DatabaseService.RegisterDatabase:input_type -> api.DatabaseRegistrationRequest
+	2,  // 44: api.DatabaseService.CommandStream:output_type -> api.CommandRequest
+	1,  // 45: api.DatabaseService.RegisterDatabase:output_type -> api.DatabaseRegistrationResponse
+	44, // [44:46] is the sub-list for method output_type
+	42, // [42:44] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_database_proto_init() }
@@ -1203,6 +1545,8 @@ func file_database_proto_init() {
 		(*CommandRequest_GetFinancialData)(nil),
 		(*CommandRequest_GetStaffData)(nil),
 		(*CommandRequest_SystemCommand)(nil),
+		(*CommandRequest_Export)(nil),
+		(*CommandRequest_ExportCredit)(nil),
 	}
 	file_database_proto_msgTypes[3].OneofWrappers = []any{
 		(*CommandResponse_Entity)(nil),
@@ -1219,6 +1563,8 @@ func file_database_proto_init() {
 		(*CommandResponse_Error)(nil),
 		(*CommandResponse_Ready)(nil),
 		(*CommandResponse_System)(nil),
+		(*CommandResponse_Schema)(nil),
+		(*CommandResponse_ExportChunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1226,7 +1572,7 @@ func file_database_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_database_proto_rawDesc), len(file_database_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
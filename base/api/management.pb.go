@@ -0,0 +1,1082 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: management.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Cache
+type ClearCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearCacheRequest) Reset() {
+	*x = ClearCacheRequest{}
+	mi := &file_management_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheRequest) ProtoMessage() {}
+
+func (x *ClearCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheRequest.ProtoReflect.Descriptor instead.
+func (*ClearCacheRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{0}
+}
+
+type ClearCacheResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RemovedEntries int32                  `protobuf:"varint,1,opt,name=removed_entries,json=removedEntries,proto3" json:"removed_entries,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClearCacheResponse) Reset() {
+	*x = ClearCacheResponse{}
+	mi := &file_management_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheResponse) ProtoMessage() {}
+
+func (x *ClearCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheResponse.ProtoReflect.Descriptor instead.
+func (*ClearCacheResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ClearCacheResponse) GetRemovedEntries() int32 {
+	if x != nil {
+		return x.RemovedEntries
+	}
+	return 0
+}
+
+type RemoveFromCacheRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFromCacheRequest) Reset() {
+	*x = RemoveFromCacheRequest{}
+	mi := &file_management_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFromCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromCacheRequest) ProtoMessage() {}
+
+func (x *RemoveFromCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromCacheRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFromCacheRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemoveFromCacheRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type RemoveFromCacheResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFromCacheResponse) Reset() {
+	*x = RemoveFromCacheResponse{}
+	mi := &file_management_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFromCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromCacheResponse) ProtoMessage() {}
+
+func (x *RemoveFromCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromCacheResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFromCacheResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoveFromCacheResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetCacheMetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCacheMetricsRequest) Reset() {
+	*x = GetCacheMetricsRequest{}
+	mi := &file_management_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCacheMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheMetricsRequest) ProtoMessage() {}
+
+func (x *GetCacheMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheMetricsRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{4}
+}
+
+type CacheMetricsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Type                string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Level1Size          int32                  `protobuf:"varint,2,opt,name=level1_size,json=level1Size,proto3" json:"level1_size,omitempty"`
+	Level2Size          int32                  `protobuf:"varint,3,opt,name=level2_size,json=level2Size,proto3" json:"level2_size,omitempty"`
+	Level3Size          int32                  `protobuf:"varint,4,opt,name=level3_size,json=level3Size,proto3" json:"level3_size,omitempty"`
+	TotalSize           int32                  `protobuf:"varint,5,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	MaxSize             int32                  `protobuf:"varint,6,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	HitRate             float64                `protobuf:"fixed64,7,opt,name=hit_rate,json=hitRate,proto3" json:"hit_rate,omitempty"`
+	HitCount            int64                  `protobuf:"varint,8,opt,name=hit_count,json=hitCount,proto3" json:"hit_count,omitempty"`
+	MissCount           int64                  `protobuf:"varint,9,opt,name=miss_count,json=missCount,proto3" json:"miss_count,omitempty"`
+	EvictionCount       int64                  `protobuf:"varint,10,opt,name=eviction_count,json=evictionCount,proto3" json:"eviction_count,omitempty"`
+	AverageAccessTimeNs int64                  `protobuf:"varint,11,opt,name=average_access_time_ns,json=averageAccessTimeNs,proto3" json:"average_access_time_ns,omitempty"`
+	UsedBytes           int64                  `protobuf:"varint,12,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`
+	MaxBytes            int64                  `protobuf:"varint,13,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"` // 0 - без ограничения
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CacheMetricsResponse) Reset() {
+	*x = CacheMetricsResponse{}
+	mi := &file_management_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CacheMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CacheMetricsResponse) ProtoMessage() {}
+
+func (x *CacheMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CacheMetricsResponse.ProtoReflect.Descriptor instead.
+func (*CacheMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CacheMetricsResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CacheMetricsResponse) GetLevel1Size() int32 {
+	if x != nil {
+		return x.Level1Size
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetLevel2Size() int32 {
+	if x != nil {
+		return x.Level2Size
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetLevel3Size() int32 {
+	if x != nil {
+		return x.Level3Size
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetHitRate() float64 {
+	if x != nil {
+		return x.HitRate
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetHitCount() int64 {
+	if x != nil {
+		return x.HitCount
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetMissCount() int64 {
+	if x != nil {
+		return x.MissCount
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetEvictionCount() int64 {
+	if x != nil {
+		return x.EvictionCount
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetAverageAccessTimeNs() int64 {
+	if x != nil {
+		return x.AverageAccessTimeNs
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetUsedBytes() int64 {
+	if x != nil {
+		return x.UsedBytes
+	}
+	return 0
+}
+
+func (x *CacheMetricsResponse) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+// Databases
+type ListDatabasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDatabasesRequest) Reset() {
+	*x = ListDatabasesRequest{}
+	mi := &file_management_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDatabasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDatabasesRequest) ProtoMessage() {}
+
+func (x *ListDatabasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDatabasesRequest.ProtoReflect.Descriptor instead.
+func (*ListDatabasesRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{6}
+}
+
+type DatabaseInfo struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId        string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	InstanceId       string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	DnsName          string                 `protobuf:"bytes,3,opt,name=dns_name,json=dnsName,proto3" json:"dns_name,omitempty"`
+	CertSerial       string                 `protobuf:"bytes,4,opt,name=cert_serial,json=certSerial,proto3" json:"cert_serial,omitempty"`
+	PeerAddress      string                 `protobuf:"bytes,5,opt,name=peer_address,json=peerAddress,proto3" json:"peer_address,omitempty"`
+	ConnectedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	InFlight         int64                  `protobuf:"varint,7,opt,name=in_flight,json=inFlight,proto3" json:"in_flight,omitempty"`
+	QueueDepth       int32                  `protobuf:"varint,8,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	Healthy          bool                   `protobuf:"varint,9,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	AverageLatencyMs float64                `protobuf:"fixed64,10,opt,name=average_latency_ms,json=averageLatencyMs,proto3" json:"average_latency_ms,omitempty"`
+	LastHeartbeatAt  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_heartbeat_at,json=lastHeartbeatAt,proto3" json:"last_heartbeat_at,omitempty"`
+	MissedHeartbeats int32                  `protobuf:"varint,12,opt,name=missed_heartbeats,json=missedHeartbeats,proto3" json:"missed_heartbeats,omitempty"`
+	Health           map[string]string      `protobuf:"bytes,13,rep,name=health,proto3" json:"health,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Результат последнего health_check: пинг и пул соединений
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DatabaseInfo) Reset() {
+	*x = DatabaseInfo{}
+	mi := &file_management_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DatabaseInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseInfo) ProtoMessage() {}
+
+func (x *DatabaseInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseInfo.ProtoReflect.Descriptor instead.
+func (*DatabaseInfo) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DatabaseInfo) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *DatabaseInfo) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *DatabaseInfo) GetDnsName() string {
+	if x != nil {
+		return x.DnsName
+	}
+	return ""
+}
+
+func (x *DatabaseInfo) GetCertSerial() string {
+	if x != nil {
+		return x.CertSerial
+	}
+	return ""
+}
+
+func (x *DatabaseInfo) GetPeerAddress() string {
+	if x != nil {
+		return x.PeerAddress
+	}
+	return ""
+}
+
+func (x *DatabaseInfo) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *DatabaseInfo) GetInFlight() int64 {
+	if x != nil {
+		return x.InFlight
+	}
+	return 0
+}
+
+func (x *DatabaseInfo) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *DatabaseInfo) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *DatabaseInfo) GetAverageLatencyMs() float64 {
+	if x != nil {
+		return x.AverageLatencyMs
+	}
+	return 0
+}
+
+func (x *DatabaseInfo) GetLastHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeatAt
+	}
+	return nil
+}
+
+func (x *DatabaseInfo) GetMissedHeartbeats() int32 {
+	if x != nil {
+		return x.MissedHeartbeats
+	}
+	return 0
+}
+
+func (x *DatabaseInfo) GetHealth() map[string]string {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+type ListDatabasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Databases     []*DatabaseInfo        `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDatabasesResponse) Reset() {
+	*x = ListDatabasesResponse{}
+	mi := &file_management_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDatabasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDatabasesResponse) ProtoMessage() {}
+
+func (x *ListDatabasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDatabasesResponse.ProtoReflect.Descriptor instead.
+func (*ListDatabasesResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDatabasesResponse) GetDatabases() []*DatabaseInfo {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+type DisconnectDatabaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectDatabaseRequest) Reset() {
+	*x = DisconnectDatabaseRequest{}
+	mi := &file_management_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectDatabaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectDatabaseRequest) ProtoMessage() {}
+
+func (x *DisconnectDatabaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectDatabaseRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectDatabaseRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DisconnectDatabaseRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type DisconnectDatabaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disconnected  bool                   `protobuf:"varint,1,opt,name=disconnected,proto3" json:"disconnected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectDatabaseResponse) Reset() {
+	*x = DisconnectDatabaseResponse{}
+	mi := &file_management_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectDatabaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectDatabaseResponse) ProtoMessage() {}
+
+func (x *DisconnectDatabaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectDatabaseResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectDatabaseResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DisconnectDatabaseResponse) GetDisconnected() bool {
+	if x != nil {
+		return x.Disconnected
+	}
+	return false
+}
+
+// Schema
+type GetSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaRequest) Reset() {
+	*x = GetSchemaRequest{}
+	mi := &file_management_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaRequest) ProtoMessage() {}
+
+func (x *GetSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{11}
+}
+
+type GetSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"` // БД, вернувшая схему
+	Schema        *SchemaResponse        `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSchemaResponse) Reset() {
+	*x = GetSchemaResponse{}
+	mi := &file_management_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaResponse) ProtoMessage() {}
+
+func (x *GetSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetSchemaResponse) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *GetSchemaResponse) GetSchema() *SchemaResponse {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+// RefreshSchemaRequest перечитывает information_schema на всех подключенных БД
+type RefreshSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSchemaRequest) Reset() {
+	*x = RefreshSchemaRequest{}
+	mi := &file_management_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSchemaRequest) ProtoMessage() {}
+
+func (x *RefreshSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSchemaRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{13}
+}
+
+type RefreshSchemaResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Schema        *SchemaResponse        `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSchemaResult) Reset() {
+	*x = RefreshSchemaResult{}
+	mi := &file_management_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSchemaResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSchemaResult) ProtoMessage() {}
+
+func (x *RefreshSchemaResult) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSchemaResult.ProtoReflect.Descriptor instead.
+func (*RefreshSchemaResult) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefreshSchemaResult) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *RefreshSchemaResult) GetSchema() *SchemaResponse {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *RefreshSchemaResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RefreshSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Databases     []*RefreshSchemaResult `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSchemaResponse) Reset() {
+	*x = RefreshSchemaResponse{}
+	mi := &file_management_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSchemaResponse) ProtoMessage() {}
+
+func (x *RefreshSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_management_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSchemaResponse.ProtoReflect.Descriptor instead.
+func (*RefreshSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_management_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RefreshSchemaResponse) GetDatabases() []*RefreshSchemaResult {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+var File_management_proto protoreflect.FileDescriptor
+
+const file_management_proto_rawDesc = "" +
+	"\n" +
+	"\x10management.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x0edatabase.proto\"\x13\n" +
+	"\x11ClearCacheRequest\"=\n" +
+	"\x12ClearCacheResponse\x12'\n" +
+	"\x0fremoved_entries\x18\x01 \x01(\x05R\x0eremovedEntries\"*\n" +
+	"\x16RemoveFromCacheRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"+\n" +
+	"\x17RemoveFromCacheResponse\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\x18\n" +
+	"\x16GetCacheMetricsRequest\"\xb6\x03\n" +
+	"\x14CacheMetricsResponse\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1f\n" +
+	"\vlevel1_size\x18\x02 \x01(\x05R\n" +
+	"level1Size\x12\x1f\n" +
+	"\vlevel2_size\x18\x03 \x01(\x05R\n" +
+	"level2Size\x12\x1f\n" +
+	"\vlevel3_size\x18\x04 \x01(\x05R\n" +
+	"level3Size\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x05 \x01(\x05R\ttotalSize\x12\x19\n" +
+	"\bmax_size\x18\x06 \x01(\x05R\amaxSize\x12\x19\n" +
+	"\bhit_rate\x18\a \x01(\x01R\ahitRate\x12\x1b\n" +
+	"\thit_count\x18\b \x01(\x03R\bhitCount\x12\x1d\n" +
+	"\n" +
+	"miss_count\x18\t \x01(\x03R\tmissCount\x12%\n" +
+	"\x0eeviction_count\x18\n" +
+	" \x01(\x03R\revictionCount\x123\n" +
+	"\x16average_access_time_ns\x18\v \x01(\x03R\x13averageAccessTimeNs\x12\x1d\n" +
+	"\n" +
+	"used_bytes\x18\f \x01(\x03R\tusedBytes\x12\x1b\n" +
+	"\tmax_bytes\x18\r \x01(\x03R\bmaxBytes\"\x16\n" +
+	"\x14ListDatabasesRequest\"\xd9\x04\n" +
+	"\fDatabaseInfo\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\x12\x19\n" +
+	"\bdns_name\x18\x03 \x01(\tR\adnsName\x12\x1f\n" +
+	"\vcert_serial\x18\x04 \x01(\tR\n" +
+	"certSerial\x12!\n" +
+	"\fpeer_address\x18\x05 \x01(\tR\vpeerAddress\x12=\n" +
+	"\fconnected_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\x12\x1b\n" +
+	"\tin_flight\x18\a \x01(\x03R\binFlight\x12\x1f\n" +
+	"\vqueue_depth\x18\b \x01(\x05R\n" +
+	"queueDepth\x12\x18\n" +
+	"\ahealthy\x18\t \x01(\bR\ahealthy\x12,\n" +
+	"\x12average_latency_ms\x18\n" +
+	" \x01(\x01R\x10averageLatencyMs\x12F\n" +
+	"\x11last_heartbeat_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0flastHeartbeatAt\x12+\n" +
+	"\x11missed_heartbeats\x18\f \x01(\x05R\x10missedHeartbeats\x125\n" +
+	"\x06health\x18\r \x03(\v2\x1d.api.DatabaseInfo.HealthEntryR\x06health\x1a9\n" +
+	"\vHealthEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"H\n" +
+	"\x15ListDatabasesResponse\x12/\n" +
+	"\tdatabases\x18\x01 \x03(\v2\x11.api.DatabaseInfoR\tdatabases\":\n" +
+	"\x19DisconnectDatabaseRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"@\n" +
+	"\x1aDisconnectDatabaseResponse\x12\"\n" +
+	"\fdisconnected\x18\x01 \x01(\bR\fdisconnected\"\x12\n" +
+	"\x10GetSchemaRequest\"_\n" +
+	"\x11GetSchemaResponse\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12+\n" +
+	"\x06schema\x18\x02 \x01(\v2\x13.api.SchemaResponseR\x06schema\"\x16\n" +
+	"\x14RefreshSchemaRequest\"w\n" +
+	"\x13RefreshSchemaResult\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12+\n" +
+	"\x06schema\x18\x02 \x01(\v2\x13.api.SchemaResponseR\x06schema\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"O\n" +
+	"\x15RefreshSchemaResponse\x126\n" +
+	"\tdatabases\x18\x01 \x03(\v2\x18.api.RefreshSchemaResultR\tdatabases2\x8e\x04\n" +
+	"\x11ManagementService\x12=\n" +
+	"\n" +
+	"ClearCache\x12\x16.api.ClearCacheRequest\x1a\x17.api.ClearCacheResponse\x12L\n" +
+	"\x0fRemoveFromCache\x12\x1b.api.RemoveFromCacheRequest\x1a\x1c.api.RemoveFromCacheResponse\x12I\n" +
+	"\x0fGetCacheMetrics\x12\x1b.api.GetCacheMetricsRequest\x1a\x19.api.CacheMetricsResponse\x12F\n" +
+	"\rListDatabases\x12\x19.api.ListDatabasesRequest\x1a\x1a.api.ListDatabasesResponse\x12U\n" +
+	"\x12DisconnectDatabase\x12\x1e.api.DisconnectDatabaseRequest\x1a\x1f.api.DisconnectDatabaseResponse\x12:\n" +
+	"\tGetSchema\x12\x15.api.GetSchemaRequest\x1a\x16.api.GetSchemaResponse\x12F\n" +
+	"\rRefreshSchema\x12\x19.api.RefreshSchemaRequest\x1a\x1a.api.RefreshSchemaResponseB\aZ\x05./apib\x06proto3"
+
+var (
+	file_management_proto_rawDescOnce sync.Once
+	file_management_proto_rawDescData []byte
+)
+
+func file_management_proto_rawDescGZIP() []byte {
+	file_management_proto_rawDescOnce.Do(func() {
+		file_management_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_management_proto_rawDesc), len(file_management_proto_rawDesc)))
+	})
+	return file_management_proto_rawDescData
+}
+
+var file_management_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_management_proto_goTypes = []any{
+	(*ClearCacheRequest)(nil),          // 0: api.ClearCacheRequest
+	(*ClearCacheResponse)(nil),         // 1: api.ClearCacheResponse
+	(*RemoveFromCacheRequest)(nil),     // 2: api.RemoveFromCacheRequest
+	(*RemoveFromCacheResponse)(nil),    // 3: api.RemoveFromCacheResponse
+	(*GetCacheMetricsRequest)(nil),     // 4: api.GetCacheMetricsRequest
+	(*CacheMetricsResponse)(nil),       // 5: api.CacheMetricsResponse
+	(*ListDatabasesRequest)(nil),       // 6: api.ListDatabasesRequest
+	(*DatabaseInfo)(nil),               // 7: api.DatabaseInfo
+	(*ListDatabasesResponse)(nil),      // 8: api.ListDatabasesResponse
+	(*DisconnectDatabaseRequest)(nil),  // 9: api.DisconnectDatabaseRequest
+	(*DisconnectDatabaseResponse)(nil), // 10: api.DisconnectDatabaseResponse
+	(*GetSchemaRequest)(nil),           // 11: api.GetSchemaRequest
+	(*GetSchemaResponse)(nil),          // 12: api.GetSchemaResponse
+	(*RefreshSchemaRequest)(nil),       // 13: api.RefreshSchemaRequest
+	(*RefreshSchemaResult)(nil),        // 14: api.RefreshSchemaResult
+	(*RefreshSchemaResponse)(nil),      // 15: api.RefreshSchemaResponse
+	nil,                                // 16: api.DatabaseInfo.HealthEntry
+	(*timestamppb.Timestamp)(nil),      // 17: google.protobuf.Timestamp
+	(*SchemaResponse)(nil),             // 18: api.SchemaResponse
+}
+var file_management_proto_depIdxs = []int32{
+	17, // 0: api.DatabaseInfo.connected_at:type_name -> google.protobuf.Timestamp
+	17, // 1: api.DatabaseInfo.last_heartbeat_at:type_name -> google.protobuf.Timestamp
+	16, // 2: api.DatabaseInfo.health:type_name -> api.DatabaseInfo.HealthEntry
+	7,  // 3: api.ListDatabasesResponse.databases:type_name -> api.DatabaseInfo
+	18, // 4: api.GetSchemaResponse.schema:type_name -> api.SchemaResponse
+	18, // 5: api.RefreshSchemaResult.schema:type_name -> api.SchemaResponse
+	14, // 6: api.RefreshSchemaResponse.databases:type_name -> api.RefreshSchemaResult
+	0,  // 7: api.ManagementService.ClearCache:input_type -> api.ClearCacheRequest
+	2,  // 8: api.ManagementService.RemoveFromCache:input_type -> api.RemoveFromCacheRequest
+	4,  // 9: api.ManagementService.GetCacheMetrics:input_type -> api.GetCacheMetricsRequest
+	6,  // 10: api.ManagementService.ListDatabases:input_type -> api.ListDatabasesRequest
+	9,  // 11: api.ManagementService.DisconnectDatabase:input_type -> api.DisconnectDatabaseRequest
+	11, // 12: api.ManagementService.GetSchema:input_type -> api.GetSchemaRequest
+	13, // 13: api.ManagementService.RefreshSchema:input_type -> api.RefreshSchemaRequest
+	1,  // 14: api.ManagementService.ClearCache:output_type -> api.ClearCacheResponse
+	3,  // 15: api.ManagementService.RemoveFromCache:output_type -> api.RemoveFromCacheResponse
+	5,  // 16: api.ManagementService.GetCacheMetrics:output_type -> api.CacheMetricsResponse
+	8,  // 17: api.ManagementService.ListDatabases:output_type -> api.ListDatabasesResponse
+	10, // 18: api.ManagementService.DisconnectDatabase:output_type -> api.DisconnectDatabaseResponse
+	12, // 19: api.ManagementService.GetSchema:output_type -> api.GetSchemaResponse
+	15, // 20: api.ManagementService.RefreshSchema:output_type -> api.RefreshSchemaResponse
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_management_proto_init() }
+func file_management_proto_init() {
+	if File_management_proto != nil {
+		return
+	}
+	file_database_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_management_proto_rawDesc), len(file_management_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_management_proto_goTypes,
+		DependencyIndexes: file_management_proto_depIdxs,
+		MessageInfos:      file_management_proto_msgTypes,
+	}.Build()
+	File_management_proto = out.File
+	file_management_proto_goTypes = nil
+	file_management_proto_depIdxs = nil
+}
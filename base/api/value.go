@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Text возвращает текстовое представление значения: числа без округления, дата в виде
+// YYYY-MM-DD, время в RFC 3339, bytea в base64. Для NULL и пустого Value возвращает false.
+func (value *Value) Text() (string, bool) {
+	switch kind := value.GetKind().(type) {
+	case *Value_BoolValue:
+		return strconv.FormatBool(kind.BoolValue), true
+	case *Value_Int64Value:
+		return strconv.FormatInt(kind.Int64Value, 10), true
+	case *Value_DoubleValue:
+		return strconv.FormatFloat(kind.DoubleValue, 'g', -1, 64), true
+	case *Value_DecimalValue:
+		return kind.DecimalValue, true
+	case *Value_TimestampValue:
+		return kind.TimestampValue.AsTime().Format(time.RFC3339Nano), true
+	case *Value_DateValue:
+		return kind.DateValue.Text(), true
+	case *Value_BytesValue:
+		return base64.StdEncoding.EncodeToString(kind.BytesValue), true
+	case *Value_JsonValue:
+		return kind.JsonValue, true
+	case *Value_StringValue:
+		return kind.StringValue, true
+	default:
+		return "", false
+	}
+}
+
+// Text возвращает дату в формате YYYY-MM-DD
+func (date *Date) Text() string {
+	return fmt.Sprintf("%04d-%02d-%02d", date.GetYear(), date.GetMonth(), date.GetDay())
+}
+
+// TextFields возвращает поля записи в текстовом виде: устаревшие fields, дополненные
+// типизированными values. NULL дает пустую строку, как в fields.
+func (entity *Entity) TextFields() map[string]string {
+	if len(entity.GetValues()) == 0 {
+		return entity.GetFields()
+	}
+
+	fields := make(map[string]string, len(entity.GetFields())+len(entity.GetValues()))
+	for name, text := range entity.GetFields() {
+		fields[name] = text
+	}
+	for name, value := range entity.GetValues() {
+		fields[name], _ = value.Text()
+	}
+	return fields
+}
+
+// NullValue возвращает Value со значением NULL
+func NullValue() *Value {
+	return &Value{Kind: &Value_NullValue{NullValue: true}}
+}
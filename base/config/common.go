@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// TLSFiles пути к сертификату сервиса, его ключу и сертификату CA
+type TLSFiles struct {
+	Certificate string `yaml:"certificate" usage:"service certificate chain (PEM)"`
+	Key         string `yaml:"key" usage:"service private key (PEM)"`
+	CA          string `yaml:"ca" usage:"CA certificate (PEM)"`
+	// ServerName имя, с которым клиент сверяет сертификат сервера
+	ServerName string `yaml:"server_name,omitempty" usage:"expected server name in the mainservice certificate"`
+	// ReloadInterval как часто проверять изменение файлов сертификата
+	ReloadInterval time.Duration `yaml:"reload_interval" usage:"how often to check certificate files for changes"`
+}
+
+// ServiceTLSFiles стандартные пути сертификатов сервиса в certs/
+func ServiceTLSFiles(service string) TLSFiles {
+	return TLSFiles{
+		Certificate:    "certs/" + service + "/" + service + "-fullchain.crt",
+		Key:            "certs/" + service + "/" + service + ".key",
+		CA:             "certs/ca/ca.crt",
+		ReloadInterval: 30 * time.Second,
+	}
+}
+
+// Validate проверяет наличие файлов
+func (files TLSFiles) Validate() error {
+	if err := CheckFile("tls.certificate", files.Certificate); err != nil {
+		return err
+	}
+	if err := CheckFile("tls.key", files.Key); err != nil {
+		return err
+	}
+	if err := CheckFile("tls.ca", files.CA); err != nil {
+		return err
+	}
+	return CheckPositive("tls.reload_interval", files.ReloadInterval)
+}
+
+// Postgres параметры подключения к PostgreSQL.
+// Если задан DSN, он используется целиком, иначе строка собирается из полей.
+type Postgres struct {
+	DSN      string `yaml:"dsn" secret:"true" usage:"full connection string, overrides the fields below"`
+	Host     string `yaml:"host" usage:"PostgreSQL host"`
+	Port     int    `yaml:"port" usage:"PostgreSQL port"`
+	User     string `yaml:"user" usage:"PostgreSQL user"`
+	Password string `yaml:"password" secret:"true" usage:"PostgreSQL password"`
+	Database string `yaml:"database" usage:"PostgreSQL database name"`
+	SSLMode  string `yaml:"sslmode" usage:"PostgreSQL sslmode"`
+}
+
+// ConnectionString строка подключения для lib/pq
+func (postgres Postgres) ConnectionString() string {
+	if postgres.DSN != "" {
+		return postgres.DSN
+	}
+
+	parameters := []string{
+		"host=" + quoteParameter(postgres.Host),
+		fmt.Sprintf("port=%d", postgres.Port),
+		"user=" + quoteParameter(postgres.User),
+		"dbname=" + quoteParameter(postgres.Database),
+		"sslmode=" + quoteParameter(postgres.SSLMode),
+	}
+	if postgres.Password != "" {
+		parameters = append(parameters, "password="+quoteParameter(postgres.Password))
+	}
+	return strings.Join(parameters, " ")
+}
+
+// Validate проверяет, что подключение описано полностью
+func (postgres Postgres) Validate() error {
+	if postgres.DSN != "" {
+		return nil
+	}
+	if postgres.Host == "" || postgres.User == "" || postgres.Database == "" {
+		return fmt.Errorf("postgres: either dsn or host, user and database must be set")
+	}
+	if postgres.Port <= 0 || postgres.Port > 65535 {
+		return fmt.Errorf("postgres.port: invalid port %d", postgres.Port)
+	}
+	return nil
+}
+
+// quoteParameter экранирует значение параметра строки подключения libpq
+func quoteParameter(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// CheckAddress проверяет адрес вида host:port (host может быть пустым для прослушивания)
+func CheckAddress(name, address string) error {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%s: invalid address %q: %v", name, address, err)
+	}
+	if port == "" {
+		return fmt.Errorf("%s: address %q has no port", name, address)
+	}
+	return nil
+}
+
+// CheckFile проверяет, что файл существует
+func CheckFile(name, path string) error {
+	if path == "" {
+		return fmt.Errorf("%s: path is empty", name)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s: %s is a directory", name, path)
+	}
+	return nil
+}
+
+// CheckPositive проверяет, что длительность больше нуля
+func CheckPositive(name string, duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("%s: must be positive, got %s", name, duration)
+	}
+	return nil
+}
+
+// RedactDSN скрывает пароль в строке подключения для журналов
+func RedactDSN(dsn string) string {
+	if parsed, err := url.Parse(dsn); err == nil && parsed.Scheme != "" && parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+		}
+		return parsed.String()
+	}
+
+	// Строка вида key=value: значение в кавычках может содержать пробелы
+	var parameters []string
+	rest := strings.TrimSpace(dsn)
+	for rest != "" {
+		key, afterKey, found := strings.Cut(rest, "=")
+		if !found {
+			parameters = append(parameters, rest)
+			break
+		}
+		key = strings.TrimSpace(key)
+		value, afterValue := cutParameterValue(strings.TrimLeft(afterKey, " \t"))
+		if key == "password" {
+			value = redacted
+		}
+		parameters = append(parameters, key+"="+value)
+		rest = strings.TrimSpace(afterValue)
+	}
+	return strings.Join(parameters, " ")
+}
+
+// cutParameterValue отделяет значение параметра libpq от остатка строки:
+// значение в одинарных кавычках (с экранированием \) или до пробела
+func cutParameterValue(text string) (string, string) {
+	if !strings.HasPrefix(text, "'") {
+		if end := strings.IndexAny(text, " \t\n"); end >= 0 {
+			return text[:end], text[end:]
+		}
+		return text, ""
+	}
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '\'':
+			return text[:i+1], text[i+1:]
+		}
+	}
+	return text, ""
+}
@@ -0,0 +1,364 @@
+// Package config загружает настройки сервисов системы из единого источника:
+// значения по умолчанию → файл YAML → переменные окружения → флаги командной строки.
+//
+// Имена настроек выводятся из yaml тегов структуры конфигурации. Для поля tls.certificate
+// сервиса database это ключ tls.certificate в файле, переменная IRS_DATABASE_TLS_CERTIFICATE
+// и флаг --tls.certificate. Поля с тегом secret:"true" скрываются в --print-config.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validator конфигурация, проверяющая себя после загрузки
+type Validator interface {
+	Validate() error
+}
+
+// redacted заменяет значения секретов при выводе
+const redacted = "******"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setting одна настройка: путь из yaml тегов и поле структуры
+type setting struct {
+	path   string
+	value  reflect.Value
+	secret bool
+	usage  string
+}
+
+// override значение из командной строки, применяемое после файла и окружения
+type override struct {
+	setting *setting
+	raw     string
+}
+
+// Load заполняет target, в котором уже установлены значения по умолчанию.
+// Файл берется из --config, переменной IRS_<PROGRAM>_CONFIG или config/<program>.yaml
+// (последний может отсутствовать). Возвращает true, если запрошен --print-config:
+// тогда вызывающий выводит конфигурацию через Print и завершается.
+func Load(program string, target Validator, arguments []string) (bool, error) {
+	settings, err := collectSettings(target)
+	if err != nil {
+		return false, err
+	}
+	prefix := envPrefix(program)
+
+	// Ошибка разбора флагов возвращается вызывающему, как и прочие ошибки загрузки
+	flags := flag.NewFlagSet(program, flag.ContinueOnError)
+	configPath := flags.String("config", "", "configuration file (env "+prefix+"CONFIG)")
+	printConfig := flags.Bool("print-config", false, "print effective configuration with secrets redacted and exit")
+
+	var overrides []override
+	for _, current := range settings {
+		current := current
+		usage := current.usage
+		if usage == "" {
+			usage = current.path
+		}
+		flags.Func(current.path, fmt.Sprintf("%s (env %s)", usage, envName(prefix, current.path)), func(raw string) error {
+			// Проверяем формат сразу, чтобы ошибка указывала на флаг
+			if err := setFromString(reflect.New(current.value.Type()).Elem(), raw); err != nil {
+				return err
+			}
+			overrides = append(overrides, override{setting: current, raw: raw})
+			return nil
+		})
+	}
+	if err := flags.Parse(arguments); err != nil {
+		// --help уже вывел справку: завершаемся успешно, как при flag.ExitOnError
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		return false, err
+	}
+
+	// Файл конфигурации
+	path, required := *configPath, true
+	if path == "" {
+		path = os.Getenv(prefix + "CONFIG")
+	}
+	if path == "" {
+		path, required = "config/"+program+".yaml", false
+	}
+	if err := loadFile(path, required, target); err != nil {
+		return false, err
+	}
+
+	// Переменные окружения
+	for _, current := range settings {
+		name := envName(prefix, current.path)
+		if raw, exists := os.LookupEnv(name); exists {
+			if err := setFromString(current.value, raw); err != nil {
+				return false, fmt.Errorf("invalid %s: %v", name, err)
+			}
+		}
+	}
+
+	// Флаги командной строки
+	for _, current := range overrides {
+		if err := setFromString(current.setting.value, current.raw); err != nil {
+			return false, fmt.Errorf("invalid --%s: %v", current.setting.path, err)
+		}
+	}
+
+	// Вывод конфигурации не проверяет ее: так можно разобраться, почему сервис не запускается
+	if *printConfig {
+		return true, nil
+	}
+	if err := target.Validate(); err != nil {
+		return false, fmt.Errorf("invalid configuration: %v", err)
+	}
+	return false, nil
+}
+
+// Print выводит конфигурацию в формате YAML, скрывая секреты
+func Print(writer io.Writer, target interface{}) error {
+	node, err := encodeNode(reflect.ValueOf(target), false)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return fmt.Errorf("failed to encode configuration: %v", err)
+	}
+	_, err = writer.Write(buffer.Bytes())
+	return err
+}
+
+// loadFile читает YAML; неизвестные ключи считаются ошибкой, чтобы опечатки не терялись
+func loadFile(path string, required bool, target interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %v", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(target); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse configuration file %s: %v", path, err)
+	}
+	return nil
+}
+
+// collectSettings обходит структуру конфигурации и собирает конечные поля
+func collectSettings(target interface{}) ([]*setting, error) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configuration must be a pointer to struct, got %T", target)
+	}
+
+	var settings []*setting
+	var walk func(value reflect.Value, prefix string) error
+	walk = func(value reflect.Value, prefix string) error {
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				return fmt.Errorf("configuration field %s%s has no yaml tag", prefix, field.Name)
+			}
+
+			path := prefix + name
+			if field.Type.Kind() == reflect.Struct {
+				if err := walk(value.Field(i), path+"."); err != nil {
+					return err
+				}
+				continue
+			}
+			settings = append(settings, &setting{
+				path:   path,
+				value:  value.Field(i),
+				secret: field.Tag.Get("secret") == "true",
+				usage:  field.Tag.Get("usage"),
+			})
+		}
+		return nil
+	}
+
+	if err := walk(value.Elem(), ""); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// setFromString устанавливает значение поля из строки окружения или флага
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	case reflect.Slice:
+		// Списки задаются через запятую
+		items := splitList(raw)
+		slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setFromString(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+	case reflect.Map:
+		// Словари задаются как key=value через запятую
+		if value.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s", value.Type().Key())
+		}
+		items := splitList(raw)
+		mapValue := reflect.MakeMapWithSize(value.Type(), len(items))
+		for _, item := range items {
+			key, itemRaw, found := strings.Cut(item, "=")
+			if !found {
+				return fmt.Errorf("expected key=value, got %q", item)
+			}
+			itemValue := reflect.New(value.Type().Elem()).Elem()
+			if err := setFromString(itemValue, itemRaw); err != nil {
+				return err
+			}
+			mapValue.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), itemValue)
+		}
+		value.Set(mapValue)
+	default:
+		return fmt.Errorf("unsupported configuration type %s", value.Type())
+	}
+	return nil
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// encodeNode строит YAML в порядке полей структуры; длительности выводятся как "5m0s"
+func encodeNode(value reflect.Value, secret bool) (*yaml.Node, error) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if secret {
+		text := ""
+		if !value.IsZero() {
+			text = redacted
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: text}, nil
+	}
+
+	switch {
+	case value.Type() == durationType:
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: time.Duration(value.Int()).String()}, nil
+
+	case value.Kind() == reflect.Struct:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if !field.IsExported() || name == "-" || name == "" {
+				continue
+			}
+			if options == "omitempty" && value.Field(i).IsZero() {
+				continue
+			}
+			child, err := encodeNode(value.Field(i), field.Tag.Get("secret") == "true")
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, child)
+		}
+		return node, nil
+
+	case value.Kind() == reflect.Map:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			child, err := encodeNode(value.MapIndex(key), false)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.String()}, child)
+		}
+		return node, nil
+
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(value.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to encode configuration value: %v", err)
+		}
+		return node, nil
+	}
+}
+
+// envPrefix префикс переменных окружения программы: IRS_MAINSERVICE_
+func envPrefix(program string) string {
+	return "IRS_" + envName("", program) + "_"
+}
+
+func envName(prefix, path string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, path)
+	return prefix + name
+}
@@ -0,0 +1,313 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// testConfig конфигурация с полями всех поддерживаемых видов
+type testConfig struct {
+	Listen   string         `yaml:"listen" usage:"listen address"`
+	Timeout  time.Duration  `yaml:"timeout"`
+	Peers    []string       `yaml:"peers"`
+	Limits   map[string]int `yaml:"limits"`
+	Debug    bool           `yaml:"debug"`
+	Token    string         `yaml:"token" secret:"true"`
+	Postgres Postgres       `yaml:"postgres"`
+
+	validations int
+	invalid     error
+}
+
+func (configuration *testConfig) Validate() error {
+	configuration.validations++
+	return configuration.invalid
+}
+
+// defaultTestConfig значения по умолчанию, как их задает main сервиса
+func defaultTestConfig() *testConfig {
+	return &testConfig{
+		Listen:   ":1000",
+		Timeout:  time.Second,
+		Peers:    []string{"default"},
+		Postgres: Postgres{Host: "localhost", Port: 5432, User: "irs", Database: "irs", SSLMode: "disable"},
+	}
+}
+
+// writeConfigFile сохраняет YAML во временный каталог и возвращает путь
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "configtest.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+// TestLoadPrecedence каждый следующий источник перекрывает предыдущий только
+// в тех настройках, которые он задает
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfigFile(t, `
+listen: ":2000"
+timeout: 2s
+peers: [yaml-a, yaml-b]
+postgres:
+  host: yaml-host
+  port: 6000
+`)
+	t.Setenv("IRS_CONFIGTEST_TIMEOUT", "3s")
+	t.Setenv("IRS_CONFIGTEST_POSTGRES_PORT", "7000")
+	t.Setenv("IRS_CONFIGTEST_DEBUG", "true")
+
+	configuration := defaultTestConfig()
+	printConfig, err := Load("configtest", configuration, []string{"--config", path, "--timeout=4s"})
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if printConfig {
+		t.Error("Load requested --print-config")
+	}
+
+	tests := []struct {
+		setting string
+		got     interface{}
+		want    interface{}
+	}{
+		{setting: "postgres.user (default)", got: configuration.Postgres.User, want: "irs"},
+		{setting: "listen (YAML over default)", got: configuration.Listen, want: ":2000"},
+		{setting: "peers (YAML over default)", got: configuration.Peers, want: []string{"yaml-a", "yaml-b"}},
+		{setting: "postgres.host (YAML over default)", got: configuration.Postgres.Host, want: "yaml-host"},
+		{setting: "postgres.port (env over YAML)", got: configuration.Postgres.Port, want: 7000},
+		{setting: "debug (env over default)", got: configuration.Debug, want: true},
+		{setting: "timeout (flag over env and YAML)", got: configuration.Timeout, want: 4 * time.Second},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("%s = %v, want %v", test.setting, test.got, test.want)
+		}
+	}
+	if configuration.validations != 1 {
+		t.Errorf("Validate called %d times, want once", configuration.validations)
+	}
+}
+
+func TestLoadConfigPathFromEnv(t *testing.T) {
+	t.Setenv("IRS_CONFIGTEST_CONFIG", writeConfigFile(t, `listen: ":2000"`))
+
+	configuration := defaultTestConfig()
+	if _, err := Load("configtest", configuration, nil); err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if configuration.Listen != ":2000" {
+		t.Errorf("listen = %s, want the value from IRS_CONFIGTEST_CONFIG", configuration.Listen)
+	}
+}
+
+func TestLoadParsesValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+		check     func(configuration *testConfig) bool
+	}{
+		{
+			name:      "duration",
+			arguments: []string{"--timeout", "1m30s"},
+			check:     func(configuration *testConfig) bool { return configuration.Timeout == 90*time.Second },
+		},
+		{
+			name:      "list with spaces and empty items",
+			arguments: []string{"--peers", " a, b,,c "},
+			check: func(configuration *testConfig) bool {
+				return reflect.DeepEqual(configuration.Peers, []string{"a", "b", "c"})
+			},
+		},
+		{
+			name:      "map of key=value pairs",
+			arguments: []string{"--limits", "org=10, user=2"},
+			check: func(configuration *testConfig) bool {
+				return reflect.DeepEqual(configuration.Limits, map[string]int{"org": 10, "user": 2})
+			},
+		},
+		{
+			name:      "the last flag wins",
+			arguments: []string{"--listen", ":1", "--listen", ":2"},
+			check:     func(configuration *testConfig) bool { return configuration.Listen == ":2" },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration := defaultTestConfig()
+			if _, err := Load("configtest", configuration, test.arguments); err != nil {
+				t.Fatalf("Load error: %v", err)
+			}
+			if !test.check(configuration) {
+				t.Errorf("unexpected configuration after %v: %+v", test.arguments, configuration)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string // Пусто - файл не передается
+		env       map[string]string
+		arguments []string
+		invalid   error
+		want      string
+	}{
+		{name: "bad duration flag", arguments: []string{"--timeout", "soon"}, want: "timeout"},
+		{name: "bad integer flag", arguments: []string{"--postgres.port", "5432x"}, want: "postgres.port"},
+		{name: "bad map flag", arguments: []string{"--limits", "org"}, want: "limits"},
+		{name: "unknown flag", arguments: []string{"--postgres.hots", "db"}, want: "postgres.hots"},
+		{name: "bad env value", env: map[string]string{"IRS_CONFIGTEST_DEBUG": "maybe"}, want: "IRS_CONFIGTEST_DEBUG"},
+		{name: "unknown YAML key", file: "listen: \":1\"\nlisten_address: \":2\"\n", want: "listen_address"},
+		{name: "explicit config file is missing", arguments: []string{"--config", "/nonexistent/configtest.yaml"}, want: "failed to read configuration file"},
+		{name: "validation fails", invalid: errors.New("listen is empty"), want: "invalid configuration: listen is empty"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			arguments := test.arguments
+			if test.file != "" {
+				arguments = append([]string{"--config", writeConfigFile(t, test.file)}, arguments...)
+			}
+
+			configuration := defaultTestConfig()
+			configuration.invalid = test.invalid
+			_, err := Load("configtest", configuration, arguments)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("Load error = %v, want it to mention %q", err, test.want)
+			}
+		})
+	}
+}
+
+// TestLoadPrintConfigSkipsValidation --print-config выводит и неверную конфигурацию
+func TestLoadPrintConfigSkipsValidation(t *testing.T) {
+	configuration := defaultTestConfig()
+	configuration.invalid = errors.New("listen is empty")
+
+	printConfig, err := Load("configtest", configuration, []string{"--print-config", "--listen", ""})
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if !printConfig {
+		t.Error("Load did not report --print-config")
+	}
+	if configuration.validations != 0 {
+		t.Errorf("Validate called %d times with --print-config", configuration.validations)
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	configuration := defaultTestConfig()
+	configuration.Timeout = 5 * time.Minute
+	configuration.Token = "token-value"
+	configuration.Postgres.Password = "password-value"
+	configuration.Postgres.DSN = "postgres://irs:dsn-password@db:5432/irs"
+
+	var output bytes.Buffer
+	if err := Print(&output, configuration); err != nil {
+		t.Fatalf("Print error: %v", err)
+	}
+	for _, secret := range []string{"token-value", "password-value", "dsn-password"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("Print leaked %q:\n%s", secret, output.String())
+		}
+	}
+
+	var printed struct {
+		Listen   string            `yaml:"listen"`
+		Timeout  string            `yaml:"timeout"`
+		Token    string            `yaml:"token"`
+		Postgres map[string]string `yaml:"postgres"`
+	}
+	if err := yaml.Unmarshal(output.Bytes(), &printed); err != nil {
+		t.Fatalf("Print output is not YAML: %v\n%s", err, output.String())
+	}
+	if printed.Listen != ":1000" || printed.Timeout != "5m0s" {
+		t.Errorf("listen = %q, timeout = %q, want :1000 and 5m0s", printed.Listen, printed.Timeout)
+	}
+	if printed.Token != redacted || printed.Postgres["password"] != redacted || printed.Postgres["dsn"] != redacted {
+		t.Errorf("secrets printed as %q, %q, %q, want %q", printed.Token, printed.Postgres["password"], printed.Postgres["dsn"], redacted)
+	}
+
+	// Пустой секрет выводится пустым: видно, что он не задан
+	configuration.Token = ""
+	output.Reset()
+	if err := Print(&output, configuration); err != nil {
+		t.Fatalf("Print error: %v", err)
+	}
+	if !strings.Contains(output.String(), `token: ""`) {
+		t.Errorf("empty secret is not printed as empty:\n%s", output.String())
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{
+			name: "URL with password",
+			dsn:  "postgres://irs:s3cret@db:5432/irs?sslmode=require",
+			want: "postgres://irs:%2A%2A%2A%2A%2A%2A@db:5432/irs?sslmode=require",
+		},
+		{
+			name: "URL without password",
+			dsn:  "postgres://irs@db:5432/irs",
+			want: "postgres://irs@db:5432/irs",
+		},
+		{
+			name: "keyword form",
+			dsn:  "host=db port=5432 user=irs password=s3cret dbname=irs",
+			want: "host=db port=5432 user=irs password=" + redacted + " dbname=irs",
+		},
+		{
+			name: "quoted password with spaces and quotes",
+			dsn:  `host=db password='s3cret pass \'x\\' dbname=irs`,
+			want: "host=db password=" + redacted + " dbname=irs",
+		},
+		{
+			name: "password built by ConnectionString",
+			dsn:  Postgres{Host: "db", Port: 5432, User: "irs", Password: "s3cret value", Database: "irs", SSLMode: "disable"}.ConnectionString(),
+			want: "host=db port=5432 user=irs dbname=irs sslmode=disable password=" + redacted,
+		},
+		{
+			name: "spaces around the equals sign",
+			dsn:  "user = irs password = s3cret",
+			want: "user=irs password=" + redacted,
+		},
+		{
+			name: "keyword form without password",
+			dsn:  "host=db user=irs dbname=irs",
+			want: "host=db user=irs dbname=irs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			redactedDSN := RedactDSN(test.dsn)
+			if redactedDSN != test.want {
+				t.Errorf("RedactDSN(%q) = %q, want %q", test.dsn, redactedDSN, test.want)
+			}
+			if strings.Contains(redactedDSN, "s3cret") {
+				t.Errorf("RedactDSN leaked the password: %s", redactedDSN)
+			}
+		})
+	}
+}
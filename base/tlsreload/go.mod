@@ -0,0 +1,3 @@
+module industrialregistrysystem/base/tlsreload
+
+go 1.25.3
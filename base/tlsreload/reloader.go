@@ -0,0 +1,140 @@
+// Package tlsreload перечитывает сертификат и ключ сервиса без перезапуска:
+// новые TLS рукопожатия используют новый сертификат, открытые соединения не затрагиваются.
+package tlsreload
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultPollInterval как часто проверять изменение файлов сертификата
+const DefaultPollInterval = 30 * time.Second
+
+// CertificateReloader хранит текущую пару сертификат/ключ и перечитывает ее
+// при изменении файлов или по сигналу SIGHUP
+type CertificateReloader struct {
+	certificatePath string
+	keyPath         string
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	fileStates  [2]fileState // Состояние файлов сертификата и ключа при последней загрузке
+}
+
+// fileState признаки изменения файла
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewCertificateReloader загружает пару сертификат/ключ
+func NewCertificateReloader(certificatePath, keyPath string) (*CertificateReloader, error) {
+	reloader := &CertificateReloader{
+		certificatePath: certificatePath,
+		keyPath:         keyPath,
+	}
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// Reload перечитывает пару сертификат/ключ; при ошибке остается прежняя
+func (reloader *CertificateReloader) Reload() error {
+	states, err := reloader.currentFileStates()
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(reloader.certificatePath, reloader.keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load certificates: %v", err)
+	}
+
+	reloader.mu.Lock()
+	reloader.certificate = &certificate
+	reloader.fileStates = states
+	reloader.mu.Unlock()
+
+	if certificate.Leaf != nil {
+		log.Printf("🔑 Certificate loaded from %s: %s, serial %s, valid until %s",
+			reloader.certificatePath, certificate.Leaf.Subject.CommonName,
+			certificate.Leaf.SerialNumber.Text(16), certificate.Leaf.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Watch перечитывает сертификат при изменении файлов (проверка раз в pollInterval)
+// и по SIGHUP. Работает до завершения процесса.
+func (reloader *CertificateReloader) Watch(pollInterval time.Duration) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hangup:
+			log.Printf("🔄 SIGHUP received, reloading certificate %s", reloader.certificatePath)
+		case <-ticker.C:
+			if !reloader.filesChanged() {
+				continue
+			}
+			log.Printf("🔄 Certificate files changed, reloading %s", reloader.certificatePath)
+		}
+
+		// Файлы могут быть записаны не одновременно: при ошибке повторим на следующей проверке
+		if err := reloader.Reload(); err != nil {
+			log.Printf("❌ Failed to reload certificate, keeping previous one: %v", err)
+		}
+	}
+}
+
+// GetCertificate для tls.Config сервера
+func (reloader *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return reloader.current(), nil
+}
+
+// GetClientCertificate для tls.Config клиента
+func (reloader *CertificateReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return reloader.current(), nil
+}
+
+func (reloader *CertificateReloader) current() *tls.Certificate {
+	reloader.mu.RLock()
+	defer reloader.mu.RUnlock()
+	return reloader.certificate
+}
+
+// filesChanged сообщает, изменились ли файлы с последней успешной загрузки
+func (reloader *CertificateReloader) filesChanged() bool {
+	states, err := reloader.currentFileStates()
+	if err != nil {
+		// Файл временно отсутствует (замена через удаление) - ждем следующей проверки
+		return false
+	}
+
+	reloader.mu.RLock()
+	defer reloader.mu.RUnlock()
+	return states != reloader.fileStates
+}
+
+func (reloader *CertificateReloader) currentFileStates() ([2]fileState, error) {
+	var states [2]fileState
+	for i, path := range []string{reloader.certificatePath, reloader.keyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return states, fmt.Errorf("failed to stat %s: %v", path, err)
+		}
+		states[i] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return states, nil
+}
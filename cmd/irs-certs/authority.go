@@ -0,0 +1,363 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Authority удостоверяющий центр системы: ключ CA и реестр выпущенных сертификатов
+type Authority struct {
+	directory   string // Корневой каталог сертификатов (certs)
+	certificate *x509.Certificate
+	key         crypto.Signer
+	index       *Index
+}
+
+// Index реестр выпущенных сертификатов, из которого строится CRL
+type Index struct {
+	CRLNumber    int64         `json:"crlNumber"`
+	Certificates []IssuedEntry `json:"certificates"`
+}
+
+// IssuedEntry запись о выпущенном сертификате
+type IssuedEntry struct {
+	Serial           string     `json:"serial"`
+	Name             string     `json:"name"`
+	Role             string     `json:"role"`
+	Identity         string     `json:"identity,omitempty"`
+	NotBefore        time.Time  `json:"notBefore"`
+	NotAfter         time.Time  `json:"notAfter"`
+	RevokedAt        *time.Time `json:"revokedAt,omitempty"`
+	RevocationReason int        `json:"revocationReason,omitempty"`
+}
+
+func (authority *Authority) caPath(file string) string {
+	return filepath.Join(authority.directory, "ca", file)
+}
+
+// certificatePaths пути полной цепочки, конечного сертификата и ключа сервиса
+func (authority *Authority) certificatePaths(name string) (fullchainPath, certificatePath, keyPath string) {
+	directory := filepath.Join(authority.directory, name)
+	return filepath.Join(directory, name+"-fullchain.crt"),
+		filepath.Join(directory, name+".crt"),
+		filepath.Join(directory, name+".key")
+}
+
+// InitAuthority создает новый CA. Существующий CA не перезаписывается без force:
+// все выпущенные им сертификаты перестанут проходить проверку.
+func InitAuthority(directory string, validity time.Duration, force bool) (*Authority, error) {
+	authority := &Authority{directory: directory, index: &Index{}}
+	if _, err := os.Stat(authority.caPath("ca.key")); err == nil && !force {
+		return nil, fmt.Errorf("CA already exists in %s (use -force to replace it)", authority.caPath(""))
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %v", err)
+	}
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	subject := subjectDefaults
+	subject.OrganizationalUnit = []string{"CA"}
+	subject.CommonName = "Industrial Registry System CA"
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.Add(validity),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		MaxPathLenZero:        true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %v", err)
+	}
+	authority.certificate, err = x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %v", err)
+	}
+	authority.key = key
+
+	if err := writeKey(authority.caPath("ca.key"), key); err != nil {
+		return nil, err
+	}
+	if err := writePEM(authority.caPath("ca.crt"), 0644, pemBlock("CERTIFICATE", der)); err != nil {
+		return nil, err
+	}
+	if err := authority.saveIndex(); err != nil {
+		return nil, err
+	}
+	return authority, nil
+}
+
+// LoadAuthority загружает существующий CA и реестр
+func LoadAuthority(directory string) (*Authority, error) {
+	authority := &Authority{directory: directory, index: &Index{}}
+
+	certificatePEM, err := os.ReadFile(authority.caPath("ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate (run 'irs-certs init' first): %v", err)
+	}
+	certificateBlock, _ := pem.Decode(certificatePEM)
+	if certificateBlock == nil {
+		return nil, fmt.Errorf("failed to decode CA certificate")
+	}
+	authority.certificate, err = x509.ParseCertificate(certificateBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %v", err)
+	}
+
+	keyPEM, err := os.ReadFile(authority.caPath("ca.key"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA key: %v", err)
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("failed to decode CA key")
+	}
+	authority.key, err = parsePrivateKey(keyBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %v", err)
+	}
+
+	indexData, err := os.ReadFile(authority.caPath("index.json"))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// CA создан прежним скриптом: реестра еще нет
+	case err != nil:
+		return nil, fmt.Errorf("failed to read certificate index: %v", err)
+	default:
+		if err := json.Unmarshal(indexData, authority.index); err != nil {
+			return nil, fmt.Errorf("failed to parse certificate index: %v", err)
+		}
+	}
+
+	return authority, nil
+}
+
+// Issue выпускает сертификат по профилю с новым ключом и записывает файлы сервиса
+func (authority *Authority) Issue(profile Profile, validity time.Duration) (*x509.Certificate, error) {
+	template, err := profile.template()
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber, err = randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template.NotBefore = now.Add(-5 * time.Minute)
+	template.NotAfter = now.Add(validity)
+	// Сертификат не может пережить CA
+	if template.NotAfter.After(authority.certificate.NotAfter) {
+		template.NotAfter = authority.certificate.NotAfter
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key for %s: %v", profile.Name, err)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, authority.certificate, key.Public(), authority.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate for %s: %v", profile.Name, err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate for %s: %v", profile.Name, err)
+	}
+
+	// Ключ пишется первым: сервисы перечитывают пару, когда изменились оба файла
+	fullchainPath, certificatePath, keyPath := authority.certificatePaths(profile.Name)
+	if err := writeKey(keyPath, key); err != nil {
+		return nil, err
+	}
+	if err := writePEM(certificatePath, 0644, pemBlock("CERTIFICATE", der)); err != nil {
+		return nil, err
+	}
+	if err := writePEM(fullchainPath, 0644, pemBlock("CERTIFICATE", der), pemBlock("CERTIFICATE", authority.certificate.Raw)); err != nil {
+		return nil, err
+	}
+
+	authority.index.Certificates = append(authority.index.Certificates, IssuedEntry{
+		Serial:    certificate.SerialNumber.Text(16),
+		Name:      profile.Name,
+		Role:      profile.Role,
+		Identity:  profile.Identity,
+		NotBefore: certificate.NotBefore,
+		NotAfter:  certificate.NotAfter,
+	})
+	if err := authority.saveIndex(); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// Current возвращает последние неотозванные сертификаты по именам
+func (authority *Authority) Current() map[string]*IssuedEntry {
+	current := make(map[string]*IssuedEntry)
+	for i := range authority.index.Certificates {
+		entry := &authority.index.Certificates[i]
+		if entry.RevokedAt != nil {
+			continue
+		}
+		if previous, exists := current[entry.Name]; !exists || entry.NotBefore.After(previous.NotBefore) {
+			current[entry.Name] = entry
+		}
+	}
+	return current
+}
+
+// Revoke отзывает сертификаты по серийному номеру (hex) или по имени сервиса.
+// Возвращает отозванные записи; CRL нужно перевыпустить отдельно.
+func (authority *Authority) Revoke(serialOrName string, reason int) ([]IssuedEntry, error) {
+	var revoked []IssuedEntry
+	now := time.Now().UTC()
+	for i := range authority.index.Certificates {
+		entry := &authority.index.Certificates[i]
+		if entry.RevokedAt != nil || (entry.Serial != serialOrName && entry.Name != serialOrName) {
+			continue
+		}
+		entry.RevokedAt = &now
+		entry.RevocationReason = reason
+		revoked = append(revoked, *entry)
+	}
+
+	if len(revoked) == 0 {
+		return nil, fmt.Errorf("no active certificate with serial or name %q", serialOrName)
+	}
+	return revoked, authority.saveIndex()
+}
+
+// GenerateCRL подписывает CRL со всеми отозванными и еще не истекшими сертификатами
+func (authority *Authority) GenerateCRL(validity time.Duration) (*x509.RevocationList, error) {
+	now := time.Now()
+	authority.index.CRLNumber++
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(authority.index.CRLNumber),
+		ThisUpdate: now,
+		NextUpdate: now.Add(validity),
+	}
+	for _, entry := range authority.index.Certificates {
+		// Истекшие сертификаты отклоняются и без CRL
+		if entry.RevokedAt == nil || entry.NotAfter.Before(now) {
+			continue
+		}
+		serial, ok := new(big.Int).SetString(entry.Serial, 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid serial %q in certificate index", entry.Serial)
+		}
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: *entry.RevokedAt,
+			ReasonCode:     entry.RevocationReason,
+		})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, authority.certificate, authority.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CRL: %v", err)
+	}
+	if err := writePEM(authority.caPath("ca.crl"), 0644, pemBlock("X509 CRL", der)); err != nil {
+		return nil, err
+	}
+	if err := authority.saveIndex(); err != nil {
+		return nil, err
+	}
+	return x509.ParseRevocationList(der)
+}
+
+func (authority *Authority) saveIndex() error {
+	data, err := json.MarshalIndent(authority.index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode certificate index: %v", err)
+	}
+	return writeFile(authority.caPath("index.json"), 0644, append(data, '\n'))
+}
+
+// randomSerial 128-битный случайный серийный номер
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %v", err)
+	}
+	// Нулевой серийный номер недопустим
+	return serial.Add(serial, big.NewInt(1)), nil
+}
+
+// parsePrivateKey разбирает PKCS#8, а также PKCS#1 и SEC 1 ключи CA, созданного openssl
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := parsedKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("key of type %T cannot sign", parsedKey)
+	}
+	return signer, nil
+}
+
+func pemBlock(blockType string, der []byte) *pem.Block {
+	return &pem.Block{Type: blockType, Bytes: der}
+}
+
+func writeKey(path string, key crypto.Signer) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key %s: %v", path, err)
+	}
+	return writePEM(path, 0600, pemBlock("PRIVATE KEY", der))
+}
+
+func writePEM(path string, mode os.FileMode, blocks ...*pem.Block) error {
+	var data []byte
+	for _, block := range blocks {
+		data = append(data, pem.EncodeToMemory(block)...)
+	}
+	return writeFile(path, mode, data)
+}
+
+// writeFile пишет через временный файл, чтобы сервисы не прочитали его наполовину
+func writeFile(path string, mode os.FileMode, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %v", path, err)
+	}
+	temporaryPath := path + ".tmp"
+	if err := os.WriteFile(temporaryPath, data, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(temporaryPath, path); err != nil {
+		os.Remove(temporaryPath)
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return nil
+}
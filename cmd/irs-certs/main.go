@@ -0,0 +1,357 @@
+// Команда irs-certs управляет удостоверяющим центром системы: создает CA, выпускает
+// сертификаты сервисов и воркеров БД, продлевает их и формирует CRL.
+// Заменяет generate_certs.bat и не требует openssl.
+//
+//	irs-certs bootstrap            CA + сертификаты mainservice, database, admin
+//	irs-certs init                 только CA
+//	irs-certs issue <service>      mainservice | database | admin | parser
+//	irs-certs worker <name>        воркер БД с уникальной личностью (URI SAN)
+//	irs-certs renew                перевыпуск сертификатов, срок которых скоро истекает
+//	irs-certs revoke <serial|name> отзыв и обновление CRL
+//	irs-certs crl                  перевыпуск CRL (до его NextUpdate)
+//	irs-certs list                 выпущенные сертификаты
+package main
+
+import (
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultCAValidity          = 10 * 365 * 24 * time.Hour
+	defaultCertificateValidity = 365 * 24 * time.Hour
+	defaultCRLValidity         = 7 * 24 * time.Hour
+	defaultRenewWithin         = 30 * 24 * time.Hour
+)
+
+// revocationReasons коды причин отзыва (RFC 5280)
+var revocationReasons = map[string]int{
+	"unspecified":          0,
+	"keyCompromise":        1,
+	"caCompromise":         2,
+	"affiliationChanged":   3,
+	"superseded":           4,
+	"cessationOfOperation": 5,
+}
+
+func main() {
+	log.SetFlags(0)
+
+	command := "bootstrap"
+	arguments := os.Args[1:]
+	if len(arguments) > 0 && !strings.HasPrefix(arguments[0], "-") {
+		command, arguments = arguments[0], arguments[1:]
+	}
+
+	var err error
+	switch command {
+	case "bootstrap":
+		err = runBootstrap(arguments)
+	case "init":
+		err = runInit(arguments)
+	case "issue":
+		err = runIssue(arguments)
+	case "worker":
+		err = runWorker(arguments)
+	case "renew":
+		err = runRenew(arguments)
+	case "revoke":
+		err = runRevoke(arguments)
+	case "crl":
+		err = runCRL(arguments)
+	case "list":
+		err = runList(arguments)
+	case "help", "-h", "--help":
+		fmt.Fprintln(os.Stderr, "usage: irs-certs [bootstrap|init|issue|worker|renew|revoke|crl|list] [flags] [args]")
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+}
+
+// newFlagSet создает набор флагов команды с общим флагом -dir
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("irs-certs "+name, flag.ExitOnError)
+	directory := flags.String("dir", "certs", "certificates directory")
+	return flags, directory
+}
+
+func runBootstrap(arguments []string) error {
+	flags, directory := newFlagSet("bootstrap")
+	caValidity := flags.Duration("ca-validity", defaultCAValidity, "CA certificate validity")
+	validity := flags.Duration("validity", defaultCertificateValidity, "service certificate validity")
+	force := flags.Bool("force", false, "replace existing CA")
+	flags.Parse(arguments)
+
+	log.Printf("🔐 Generating TLS certificates in %s...", *directory)
+	authority, err := InitAuthority(*directory, *caValidity, *force)
+	if err != nil {
+		return err
+	}
+	printCertificate("CA", authority.certificate)
+
+	for _, service := range bootstrapServices {
+		certificate, err := authority.Issue(serviceProfiles[service], *validity)
+		if err != nil {
+			return err
+		}
+		printCertificate(service, certificate)
+	}
+
+	if _, err := authority.GenerateCRL(defaultCRLValidity); err != nil {
+		return err
+	}
+	log.Printf("✅ Certificates generated successfully!")
+	return nil
+}
+
+func runInit(arguments []string) error {
+	flags, directory := newFlagSet("init")
+	validity := flags.Duration("validity", defaultCAValidity, "CA certificate validity")
+	force := flags.Bool("force", false, "replace existing CA")
+	flags.Parse(arguments)
+
+	authority, err := InitAuthority(*directory, *validity, *force)
+	if err != nil {
+		return err
+	}
+	if _, err := authority.GenerateCRL(defaultCRLValidity); err != nil {
+		return err
+	}
+	printCertificate("CA", authority.certificate)
+	return nil
+}
+
+func runIssue(arguments []string) error {
+	flags, directory := newFlagSet("issue")
+	validity := flags.Duration("validity", defaultCertificateValidity, "certificate validity")
+	flags.Parse(arguments)
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: irs-certs issue [flags] <mainservice|database|admin|parser>...")
+	}
+
+	authority, err := LoadAuthority(*directory)
+	if err != nil {
+		return err
+	}
+	for _, service := range flags.Args() {
+		profile, exists := serviceProfiles[service]
+		if !exists {
+			return fmt.Errorf("unknown service %q", service)
+		}
+		certificate, err := authority.Issue(profile, *validity)
+		if err != nil {
+			return err
+		}
+		printCertificate(service, certificate)
+	}
+	return nil
+}
+
+func runWorker(arguments []string) error {
+	flags, directory := newFlagSet("worker")
+	validity := flags.Duration("validity", defaultCertificateValidity, "certificate validity")
+	flags.Parse(arguments)
+
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: irs-certs worker [flags] <name>...")
+	}
+
+	authority, err := LoadAuthority(*directory)
+	if err != nil {
+		return err
+	}
+	for _, workerName := range flags.Args() {
+		profile, err := workerProfile(workerName)
+		if err != nil {
+			return err
+		}
+		if _, exists := authority.Current()[profile.Name]; exists {
+			return fmt.Errorf("worker %s already has an active certificate (use 'renew' or 'revoke')", workerName)
+		}
+		certificate, err := authority.Issue(profile, *validity)
+		if err != nil {
+			return err
+		}
+		printCertificate(profile.Name, certificate)
+	}
+	return nil
+}
+
+func runRenew(arguments []string) error {
+	flags, directory := newFlagSet("renew")
+	within := flags.Duration("within", defaultRenewWithin, "renew certificates expiring within this period")
+	validity := flags.Duration("validity", defaultCertificateValidity, "new certificate validity")
+	revokeOld := flags.Bool("revoke-old", false, "revoke renewed certificates as superseded")
+	flags.Parse(arguments)
+
+	authority, err := LoadAuthority(*directory)
+	if err != nil {
+		return err
+	}
+
+	// Без аргументов продлеваются все сертификаты, иначе только перечисленные (независимо от срока)
+	requested := make(map[string]bool)
+	for _, name := range flags.Args() {
+		requested[name] = true
+	}
+
+	deadline := time.Now().Add(*within)
+	renewed := 0
+	for name, entry := range authority.Current() {
+		if len(requested) > 0 && !requested[name] {
+			continue
+		}
+		if len(requested) == 0 && entry.NotAfter.After(deadline) {
+			continue
+		}
+
+		oldSerial := entry.Serial
+		profile, err := profileByName(name)
+		if err != nil {
+			return err
+		}
+		certificate, err := authority.Issue(profile, *validity)
+		if err != nil {
+			return err
+		}
+		log.Printf("🔄 Renewed %s (old serial %s)", name, oldSerial)
+		printCertificate(name, certificate)
+		renewed++
+
+		if *revokeOld {
+			if _, err := authority.Revoke(oldSerial, revocationReasons["superseded"]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if renewed == 0 {
+		log.Printf("✅ No certificates expire before %s", deadline.Format(time.RFC3339))
+		return nil
+	}
+	if *revokeOld {
+		if _, err := authority.GenerateCRL(defaultCRLValidity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runRevoke(arguments []string) error {
+	flags, directory := newFlagSet("revoke")
+	reasonName := flags.String("reason", "unspecified", "revocation reason (keyCompromise, superseded, cessationOfOperation, ...)")
+	crlValidity := flags.Duration("crl-validity", defaultCRLValidity, "CRL validity")
+	flags.Parse(arguments)
+
+	reason, known := revocationReasons[*reasonName]
+	if !known {
+		return fmt.Errorf("unknown revocation reason %q", *reasonName)
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: irs-certs revoke [flags] <serial|name>...")
+	}
+
+	authority, err := LoadAuthority(*directory)
+	if err != nil {
+		return err
+	}
+	for _, serialOrName := range flags.Args() {
+		revoked, err := authority.Revoke(serialOrName, reason)
+		if err != nil {
+			return err
+		}
+		for _, entry := range revoked {
+			log.Printf("🚫 Revoked %s (serial %s, reason %s)", entry.Name, entry.Serial, *reasonName)
+		}
+	}
+
+	crl, err := authority.GenerateCRL(*crlValidity)
+	if err != nil {
+		return err
+	}
+	log.Printf("📜 CRL #%s updated: %d revoked, next update %s",
+		crl.Number, len(crl.RevokedCertificateEntries), crl.NextUpdate.Format(time.RFC3339))
+	return nil
+}
+
+func runCRL(arguments []string) error {
+	flags, directory := newFlagSet("crl")
+	validity := flags.Duration("validity", defaultCRLValidity, "CRL validity")
+	flags.Parse(arguments)
+
+	authority, err := LoadAuthority(*directory)
+	if err != nil {
+		return err
+	}
+	crl, err := authority.GenerateCRL(*validity)
+	if err != nil {
+		return err
+	}
+	log.Printf("📜 CRL #%s written to %s: %d revoked, next update %s",
+		crl.Number, authority.caPath("ca.crl"), len(crl.RevokedCertificateEntries), crl.NextUpdate.Format(time.RFC3339))
+	return nil
+}
+
+func runList(arguments []string) error {
+	flags, directory := newFlagSet("list")
+	flags.Parse(arguments)
+
+	authority, err := LoadAuthority(*directory)
+	if err != nil {
+		return err
+	}
+
+	entries := append([]IssuedEntry(nil), authority.index.Certificates...)
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].NotBefore.Before(entries[j].NotBefore)
+	})
+
+	now := time.Now()
+	for _, entry := range entries {
+		state := "active"
+		switch {
+		case entry.RevokedAt != nil:
+			state = "revoked " + entry.RevokedAt.Format(time.RFC3339)
+		case entry.NotAfter.Before(now):
+			state = "expired"
+		}
+		fmt.Printf("%-24s %-12s %-34s until %s  %s\n",
+			entry.Name, entry.Role, entry.Serial, entry.NotAfter.Format("2006-01-02"), state)
+	}
+	return nil
+}
+
+// printCertificate выводит основные поля сертификата, как делал generate_certs.bat
+func printCertificate(name string, certificate *x509.Certificate) {
+	log.Printf("📝 %s: %s", name, certificate.Subject.String())
+	log.Printf("   Serial: %s, valid until %s", certificate.SerialNumber.Text(16), certificate.NotAfter.Format(time.RFC3339))
+
+	var alternativeNames []string
+	for _, dnsName := range certificate.DNSNames {
+		alternativeNames = append(alternativeNames, "DNS:"+dnsName)
+	}
+	for _, ip := range certificate.IPAddresses {
+		alternativeNames = append(alternativeNames, "IP:"+ip.String())
+	}
+	for _, uri := range certificate.URIs {
+		alternativeNames = append(alternativeNames, "URI:"+uri.String())
+	}
+	if len(alternativeNames) > 0 {
+		log.Printf("   Subject Alternative Name: %s", strings.Join(alternativeNames, ", "))
+	}
+}
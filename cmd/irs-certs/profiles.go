@@ -0,0 +1,136 @@
+package main
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	// organization организация во всех сертификатах системы: mainservice проверяет ее у клиентов
+	organization = "IndustrialRegistrySystem"
+
+	// workerIdentityPrefix префикс URI SAN воркеров БД: по нему mainservice различает экземпляры
+	workerIdentityPrefix = "spiffe://industrialregistrysystem/database/"
+)
+
+// subjectDefaults общие поля Subject, как в прежнем generate_certs.bat
+var subjectDefaults = pkix.Name{
+	Country:      []string{"RU"},
+	Province:     []string{"Moscow"},
+	Locality:     []string{"Moscow"},
+	Organization: []string{organization},
+}
+
+// Profile описывает сертификат одного сервиса
+type Profile struct {
+	Name               string   // Имя сертификата: каталог и имена файлов в certs/
+	Role               string   // Роль сервиса (mainservice, database, admin, parser)
+	OrganizationalUnit string   // OU, по которому mainservice определяет роль клиента
+	CommonName         string   // CN
+	DNSNames           []string // DNS SAN
+	IPAddresses        []string // IP SAN
+	Identity           string   // URI SAN с уникальной личностью (для воркеров БД)
+}
+
+// serviceProfiles стандартные сертификаты сервисов
+var serviceProfiles = map[string]Profile{
+	"mainservice": {
+		Name:               "mainservice",
+		Role:               "mainservice",
+		OrganizationalUnit: "MainService",
+		CommonName:         "mainservice",
+		DNSNames:           []string{"mainservice", "localhost"},
+		IPAddresses:        []string{"127.0.0.1"},
+	},
+	"database": {
+		Name:               "database",
+		Role:               "database",
+		OrganizationalUnit: "Database",
+		CommonName:         "database",
+		// validateDatabaseCertificate требует DNS "database" или "database.industrialregistrysystem"
+		DNSNames:    []string{"database", "database.industrialregistrysystem", "localhost"},
+		IPAddresses: []string{"127.0.0.1"},
+	},
+	"admin": {
+		Name:               "admin",
+		Role:               "admin",
+		OrganizationalUnit: "Admin",
+		CommonName:         "admin",
+		DNSNames:           []string{"admin", "localhost"},
+		IPAddresses:        []string{"127.0.0.1"},
+	},
+	"parser": {
+		Name:               "parser",
+		Role:               "parser",
+		OrganizationalUnit: "Parser",
+		CommonName:         "parser",
+		DNSNames:           []string{"parser", "localhost"},
+		IPAddresses:        []string{"127.0.0.1"},
+	},
+}
+
+// bootstrapServices сертификаты, которые выпускаются при первоначальной настройке
+var bootstrapServices = []string{"mainservice", "database", "admin"}
+
+var workerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// workerProfile строит профиль дополнительного воркера БД с уникальной личностью
+func workerProfile(workerName string) (Profile, error) {
+	if !workerNamePattern.MatchString(workerName) {
+		return Profile{}, fmt.Errorf("invalid worker name %q: use lowercase letters, digits and '-'", workerName)
+	}
+
+	profile := serviceProfiles["database"]
+	profile.Name = "database-" + workerName
+	profile.CommonName = "database-" + workerName
+	profile.Identity = workerIdentityPrefix + workerName
+	return profile, nil
+}
+
+// profileByName восстанавливает профиль по имени сертификата из реестра
+func profileByName(name string) (Profile, error) {
+	if profile, exists := serviceProfiles[name]; exists {
+		return profile, nil
+	}
+	if workerName, isWorker := strings.CutPrefix(name, "database-"); isWorker {
+		return workerProfile(workerName)
+	}
+	return Profile{}, fmt.Errorf("unknown certificate %q", name)
+}
+
+// template заполняет шаблон конечного сертификата
+func (profile Profile) template() (*x509.Certificate, error) {
+	subject := subjectDefaults
+	subject.OrganizationalUnit = []string{profile.OrganizationalUnit}
+	subject.CommonName = profile.CommonName
+
+	template := &x509.Certificate{
+		Subject:     subject,
+		DNSNames:    profile.DNSNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	for _, address := range profile.IPAddresses {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q in profile %s", address, profile.Name)
+		}
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+
+	if profile.Identity != "" {
+		identity, err := url.Parse(profile.Identity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid identity %q: %v", profile.Identity, err)
+		}
+		template.URIs = []*url.URL{identity}
+	}
+
+	return template, nil
+}
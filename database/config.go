@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"industrialregistrysystem/base/config"
+)
+
+// Config настройки воркера БД: config/database.yaml, переменные IRS_DATABASE_* и флаги.
+// Пароль PostgreSQL задается в файле или через IRS_DATABASE_POSTGRES_PASSWORD.
+type Config struct {
+	// MainServiceAddress адрес mainservice, к которому подключается воркер
+	MainServiceAddress string `yaml:"mainservice_address" usage:"mainservice gRPC address"`
+
+	// InstanceID ID экземпляра; по умолчанию генерируется из хоста, PID и случайного суффикса
+	InstanceID string `yaml:"instance_id" usage:"worker instance ID (generated when empty)"`
+
+	// ReconnectDelay пауза перед повторным подключением к mainservice
+	ReconnectDelay time.Duration `yaml:"reconnect_delay" usage:"delay before reconnecting to mainservice"`
+
+	// CommandTimeout время на выполнение одной команды
+	CommandTimeout time.Duration `yaml:"command_timeout" usage:"timeout of a single command"`
+
+	// ShutdownTimeout сколько при остановке ждать выполняемые команды перед закрытием потока
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" usage:"how long to wait for in-flight commands on shutdown"`
+
+	// Schema схема PostgreSQL, таблицы которой доступны универсальным CRUD командам
+	Schema string `yaml:"schema" usage:"PostgreSQL schema exposed to generic CRUD commands"`
+
+	TLS      config.TLSFiles `yaml:"tls"`
+	Postgres config.Postgres `yaml:"postgres"`
+	Pool     PoolConfig      `yaml:"pool"`
+}
+
+// PoolConfig ограничения пула соединений с PostgreSQL
+type PoolConfig struct {
+	MaxOpenConns    int           `yaml:"max_open_conns" usage:"maximum open PostgreSQL connections"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" usage:"maximum idle PostgreSQL connections"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" usage:"maximum PostgreSQL connection lifetime"`
+}
+
+// defaultConfig значения по умолчанию, совпадающие с прежними константами (кроме пароля)
+func defaultConfig() *Config {
+	files := config.ServiceTLSFiles("database")
+	files.ServerName = "mainservice"
+
+	return &Config{
+		MainServiceAddress: "localhost:5051",
+		ReconnectDelay:     5 * time.Second,
+		CommandTimeout:     30 * time.Second,
+		ShutdownTimeout:    30 * time.Second,
+		Schema:             "public",
+		TLS:                files,
+		Postgres: config.Postgres{
+			Host:     "192.168.1.137",
+			Port:     5433,
+			User:     "myuser",
+			Database: "mydatabase",
+			SSLMode:  "disable",
+		},
+		Pool: PoolConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+	}
+}
+
+// Validate проверяет настройки при запуске
+func (configuration *Config) Validate() error {
+	if err := config.CheckAddress("mainservice_address", configuration.MainServiceAddress); err != nil {
+		return err
+	}
+	if err := config.CheckPositive("reconnect_delay", configuration.ReconnectDelay); err != nil {
+		return err
+	}
+	if err := config.CheckPositive("command_timeout", configuration.CommandTimeout); err != nil {
+		return err
+	}
+	if err := config.CheckPositive("shutdown_timeout", configuration.ShutdownTimeout); err != nil {
+		return err
+	}
+	if configuration.Schema == "" {
+		return fmt.Errorf("schema: must be set")
+	}
+	if err := configuration.TLS.Validate(); err != nil {
+		return err
+	}
+	if configuration.TLS.ServerName == "" {
+		return fmt.Errorf("tls.server_name: must be set")
+	}
+	if err := configuration.Postgres.Validate(); err != nil {
+		return err
+	}
+
+	pool := configuration.Pool
+	if pool.MaxOpenConns <= 0 {
+		return fmt.Errorf("pool.max_open_conns: must be positive, got %d", pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns < 0 || pool.MaxIdleConns > pool.MaxOpenConns {
+		return fmt.Errorf("pool.max_idle_conns: must be between 0 and max_open_conns (%d), got %d",
+			pool.MaxOpenConns, pool.MaxIdleConns)
+	}
+	return config.CheckPositive("pool.conn_max_lifetime", pool.ConnMaxLifetime)
+}
@@ -5,62 +5,75 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"industrialregistrysystem/base/api"
+	"industrialregistrysystem/base/config"
 )
 
 type DataService struct {
-	db *sql.DB
+	db             *sql.DB
+	schema         *SchemaRegistry // Таблицы и колонки, доступные универсальным CRUD командам
+	commandTimeout time.Duration   // Время на выполнение одной команды от mainservice
+	exports        *exportRegistry // Выполняемые выгрузки, ожидающие разрешений mainservice
 }
 
-func NewDataService() *DataService {
-	db, err := sql.Open("postgres", "host=192.168.1.137 user=myuser password=mypassword dbname=mydatabase sslmode=disable port=5433")
+func NewDataService(configuration *Config) *DataService {
+	connectionString := configuration.Postgres.ConnectionString()
+	log.Printf("🐘 Connecting to PostgreSQL: %s", config.RedactDSN(connectionString))
+
+	db, err := sql.Open("postgres", connectionString)
 	if err != nil {
 		log.Fatal(err)
 	}
 	
 	// Настройка пула соединений
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(configuration.Pool.MaxOpenConns)
+	db.SetMaxIdleConns(configuration.Pool.MaxIdleConns)
+	db.SetConnMaxLifetime(configuration.Pool.ConnMaxLifetime)
 	
 	// Проверяем подключение
 	if err := db.Ping(); err != nil {
 		log.Fatal("Failed to ping database:", err)
 	}
 	
-	return &DataService{db: db}
+	// Загружаем схему: без нее универсальные команды не пропустят ни одной таблицы
+	schema := NewSchemaRegistry(db, configuration.Schema)
+	if err := schema.Refresh(context.Background()); err != nil {
+		log.Fatal("Failed to load database schema:", err)
+	}
+	
+	return &DataService{db: db, schema: schema, commandTimeout: configuration.CommandTimeout, exports: newExportRegistry()}
+}
+
+// Close закрывает пул соединений с PostgreSQL
+func (dataService *DataService) Close() {
+	if err := dataService.db.Close(); err != nil {
+		log.Printf("Failed to close database pool: %v", err)
+	}
 }
 
 // Create - универсальное создание записи
 func (dataService *DataService) Create(ctx context.Context, createRequest *api.CreateRequest) (*api.EntityResponse, error) {
 	// Определяем таблицу и поля для вставки
 	tableName := createRequest.TableName
-	entity := createRequest.Entity
+	table, err := dataService.schema.Table(tableName)
+	if err != nil {
+		return nil, err
+	}
 	
 	// Формируем SQL запрос динамически
-	columns := ""
-	placeholders := ""
-	values := []interface{}{}
-	parameterIndex := 1
-	
-	for fieldName, fieldValue := range entity.Fields {
-		if columns != "" {
-			columns += ", "
-			placeholders += ", "
-		}
-		columns += fieldName
-		placeholders += "$" + fmt.Sprintf("%d", parameterIndex)
-		values = append(values, fieldValue)
-		parameterIndex++
+	query, values, err := buildInsertQuery(table, createRequest.Entity)
+	if err != nil {
+		return nil, err
 	}
 	
-	query := "INSERT INTO " + tableName + " (" + columns + ") VALUES (" + placeholders + ") RETURNING id"
-	
 	var id int32
-	err := dataService.db.QueryRowContext(ctx, query, values...).Scan(&id)
+	err = dataService.db.QueryRowContext(ctx, query, values...).Scan(&id)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +87,12 @@ func (dataService *DataService) Create(ctx context.Context, createRequest *api.C
 
 // Get - универсальное получение записи по ID
 func (dataService *DataService) Get(ctx context.Context, getRequest *api.GetRequest) (*api.EntityResponse, error) {
-	query := "SELECT * FROM " + getRequest.TableName + " WHERE id = $1 AND destroyed = false"
+	table, err := dataService.schema.Table(getRequest.TableName)
+	if err != nil {
+		return nil, err
+	}
+	
+	query := "SELECT * FROM " + table.QuotedName() + " WHERE id = $1 AND destroyed = false"
 	
 	rows, err := dataService.db.QueryContext(ctx, query, getRequest.Id)
 	if err != nil {
@@ -82,56 +100,21 @@ func (dataService *DataService) Get(ctx context.Context, getRequest *api.GetRequ
 	}
 	defer rows.Close()
 	
-	columns, err := rows.Columns()
+	columnTypes, err := rows.ColumnTypes()
 	if err != nil {
 		return nil, err
 	}
 	
-	// Создаем срезы для значений
-	values := make([]interface{}, len(columns))
-	valuePointers := make([]interface{}, len(columns))
-	for i := range values {
-		valuePointers[i] = &values[i]
-	}
-	
 	if !rows.Next() {
 		return nil, sql.ErrNoRows
 	}
 	
-	err = rows.Scan(valuePointers...)
+	// Преобразуем результат в Entity по типам колонок
+	entity, err := scanEntity(rows, columnTypes)
 	if err != nil {
 		return nil, err
 	}
 	
-	// Преобразуем результат в Entity с правильным преобразованием типов
-	entity := &api.Entity{
-		Fields: make(map[string]string),
-	}
-	
-	for i, columnName := range columns {
-		if values[i] != nil {
-			// Правильное преобразование типов
-			switch v := values[i].(type) {
-			case []byte:
-				entity.Fields[columnName] = string(v)
-			case string:
-				entity.Fields[columnName] = v
-			case int64:
-				entity.Fields[columnName] = fmt.Sprintf("%d", v)
-			case float64:
-				entity.Fields[columnName] = fmt.Sprintf("%f", v)
-			case bool:
-				entity.Fields[columnName] = fmt.Sprintf("%t", v)
-			case time.Time:
-				entity.Fields[columnName] = v.Format(time.RFC3339)
-			default:
-				entity.Fields[columnName] = fmt.Sprintf("%v", v)
-			}
-		} else {
-			entity.Fields[columnName] = ""
-		}
-	}
-	
 	return &api.EntityResponse{
 		TableName: getRequest.TableName,
 		Entity:    entity,
@@ -141,28 +124,18 @@ func (dataService *DataService) Get(ctx context.Context, getRequest *api.GetRequ
 // Update - универсальное обновление записи
 func (dataService *DataService) Update(ctx context.Context, updateRequest *api.UpdateRequest) (*api.EntityResponse, error) {
 	tableName := updateRequest.TableName
-	entity := updateRequest.Entity
-	
-	// Формируем SET часть запроса
-	setClause := ""
-	values := []interface{}{}
-	parameterIndex := 1
-	
-	for fieldName, fieldValue := range entity.Fields {
-		if setClause != "" {
-			setClause += ", "
-		}
-		setClause += fieldName + " = $" + fmt.Sprintf("%d", parameterIndex)
-		values = append(values, fieldValue)
-		parameterIndex++
+	table, err := dataService.schema.Table(tableName)
+	if err != nil {
+		return nil, err
 	}
 	
-	// Добавляем ID в конец
-	values = append(values, updateRequest.Id)
-	
-	query := "UPDATE " + tableName + " SET " + setClause + ", updated_at = NOW() WHERE id = $" + fmt.Sprintf("%d", parameterIndex) + " AND destroyed = false"
+	// Формируем SET часть запроса, ID добавляется последним параметром
+	query, values, err := buildUpdateQuery(table, updateRequest.Entity, updateRequest.Id)
+	if err != nil {
+		return nil, err
+	}
 	
-	_, err := dataService.db.ExecContext(ctx, query, values...)
+	_, err = dataService.db.ExecContext(ctx, query, values...)
 	if err != nil {
 		return nil, err
 	}
@@ -177,12 +150,16 @@ func (dataService *DataService) Update(ctx context.Context, updateRequest *api.U
 func (dataService *DataService) Delete(ctx context.Context, deleteRequest *api.DeleteRequest) (*api.DeleteResponse, error) {
 	var query string
 	var result sql.Result
-	var err error
+	
+	table, err := dataService.schema.Table(deleteRequest.TableName)
+	if err != nil {
+		return nil, err
+	}
 	
 	if deleteRequest.SoftDelete {
-		query = "UPDATE " + deleteRequest.TableName + " SET destroyed = true, updated_at = NOW() WHERE id = $1"
+		query = "UPDATE " + table.QuotedName() + " SET destroyed = true, updated_at = NOW() WHERE id = $1"
 	} else {
-		query = "DELETE FROM " + deleteRequest.TableName + " WHERE id = $1"
+		query = "DELETE FROM " + table.QuotedName() + " WHERE id = $1"
 	}
 	
 	result, err = dataService.db.ExecContext(ctx, query, deleteRequest.Id)
@@ -198,48 +175,105 @@ func (dataService *DataService) Delete(ctx context.Context, deleteRequest *api.D
 	}, nil
 }
 
-// List - универсальное получение списка записей
-func (dataService *DataService) List(ctx context.Context, listRequest *api.ListRequest) (*api.ListResponse, error) {
-	// Базовый запрос
-	query := "SELECT * FROM " + listRequest.TableName + " WHERE destroyed = false"
-	countQuery := "SELECT COUNT(*) FROM " + listRequest.TableName + " WHERE destroyed = false"
-	
+// buildListCondition возвращает условие WHERE для List и Export: неудаленные строки,
+// фильтры по равенству и дерево условий. Параметры нумеруются с $1.
+func buildListCondition(table *TableSchema, filters map[string]string, filter *api.Filter) (string, []interface{}, error) {
+	condition := "destroyed = false"
 	values := []interface{}{}
-	paramIndex := 1
 	
 	// Добавляем фильтры
-	if len(listRequest.Filters) > 0 {
-		query += " AND ("
-		countQuery += " AND ("
-		
-		first := true
-		for fieldName, fieldValue := range listRequest.Filters {
-			if !first {
-				query += " AND "
-				countQuery += " AND "
+	if len(filters) > 0 {
+		parts := make([]string, 0, len(filters))
+		for fieldName, fieldValue := range filters {
+			columnName, err := table.QuoteColumn(fieldName)
+			if err != nil {
+				return "", nil, err
 			}
-			query += fieldName + " = $" + fmt.Sprintf("%d", paramIndex)
-			countQuery += fieldName + " = $" + fmt.Sprintf("%d", paramIndex)
 			values = append(values, fieldValue)
-			paramIndex++
-			first = false
+			parts = append(parts, columnName+" = $"+fmt.Sprintf("%d", len(values)))
 		}
-		
-		query += ")"
-		countQuery += ")"
+		condition += " AND (" + strings.Join(parts, " AND ") + ")"
+	}
+	
+	// Добавляем дерево условий
+	if filter != nil {
+		filterCondition, filterValues, err := buildFilter(table, filter, len(values)+1)
+		if err != nil {
+			return "", nil, err
+		}
+		condition += " AND (" + filterCondition + ")"
+		values = append(values, filterValues...)
+	}
+	
+	return condition, values, nil
+}
+
+// List - универсальное получение списка записей
+func (dataService *DataService) List(ctx context.Context, listRequest *api.ListRequest) (*api.ListResponse, error) {
+	table, err := dataService.schema.Table(listRequest.TableName)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Отбор строк: фильтры по равенству и дерево условий
+	condition, values, err := buildListCondition(table, listRequest.Filters, listRequest.Filter)
+	if err != nil {
+		return nil, err
 	}
+	query := "SELECT * FROM " + table.QuotedName() + " WHERE " + condition
+	countQuery := "SELECT COUNT(*) FROM " + table.QuotedName() + " WHERE " + condition
+	paramIndex := len(values) + 1
 	
-	// Добавляем сортировку
-	if listRequest.OrderBy != "" {
-		query += " ORDER BY " + listRequest.OrderBy
-		if listRequest.OrderDesc {
-			query += " DESC"
+	// Условия выше отбирают строки для подсчета; продолжение страницы в подсчет не входит
+	countValues := values
+	
+	// Сортировка по колонке дополняется id: порядок строк однозначен и для токена страницы
+	page := keyset{descending: listRequest.OrderDesc}
+	if listRequest.OrderBy != "" && listRequest.OrderBy != "id" {
+		page.keyExpression, err = table.QuoteColumn(listRequest.OrderBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	// Токен выдается только для того же запроса: без полей пагинации
+	fingerprint, err := queryFingerprint(&api.ListRequest{
+		TableName: listRequest.TableName,
+		OrderBy:   listRequest.OrderBy,
+		OrderDesc: listRequest.OrderDesc,
+		Filters:   listRequest.Filters,
+		Filter:    listRequest.Filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	// Продолжаем после последней строки предыдущей страницы
+	if listRequest.PageToken != "" {
+		token, err := decodePageToken(listRequest.PageToken, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		condition, keyValues, err := page.after(token, paramIndex)
+		if err != nil {
+			return nil, err
 		}
+		query += " AND " + condition
+		values = append(values, keyValues...)
+		paramIndex += len(keyValues)
 	}
 	
-	// Добавляем пагинацию
-	query += " LIMIT $" + fmt.Sprintf("%d", paramIndex) + " OFFSET $" + fmt.Sprintf("%d", paramIndex+1)
-	values = append(values, listRequest.PageSize, (listRequest.Page-1)*listRequest.PageSize)
+	query += " ORDER BY " + page.orderBy()
+	
+	// Добавляем пагинацию: лишняя строка показывает, есть ли следующая страница.
+	// OFFSET остается для клиентов, листающих по номеру страницы без токена.
+	pageSize := normalizePageSize(listRequest.PageSize)
+	query += " LIMIT $" + fmt.Sprintf("%d", paramIndex)
+	values = append(values, pageSize+1)
+	if listRequest.PageToken == "" && listRequest.Page > 1 {
+		query += " OFFSET $" + fmt.Sprintf("%d", paramIndex+1)
+		values = append(values, (listRequest.Page-1)*pageSize)
+	}
 	
 	// Выполняем запрос на получение данных
 	rows, err := dataService.db.QueryContext(ctx, query, values...)
@@ -248,72 +282,54 @@ func (dataService *DataService) List(ctx context.Context, listRequest *api.ListR
 	}
 	defer rows.Close()
 	
-	columns, err := rows.Columns()
+	columnTypes, err := rows.ColumnTypes()
 	if err != nil {
 		return nil, err
 	}
 	
 	var entities []*api.Entity
 	for rows.Next() {
-		rowValues := make([]interface{}, len(columns))
-		rowValuePointers := make([]interface{}, len(columns))
-		for i := range rowValues {
-			rowValuePointers[i] = &rowValues[i]
-		}
-		
-		err = rows.Scan(rowValuePointers...)
+		entity, err := scanEntity(rows, columnTypes)
 		if err != nil {
 			return nil, err
 		}
-		
-		entity := &api.Entity{
-			Fields: make(map[string]string),
+		entities = append(entities, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	
+	// Токен следующей страницы строится по последней строке текущей
+	var nextPageToken string
+	if len(entities) > int(pageSize) {
+		entities = entities[:pageSize]
+		lastEntity := entities[len(entities)-1]
+		var key *api.Value
+		if page.keyExpression != "" {
+			key = lastEntity.Values[listRequest.OrderBy]
 		}
-		
-		for i, columnName := range columns {
-			if rowValues[i] != nil {
-				switch v := rowValues[i].(type) {
-				case []byte:
-					entity.Fields[columnName] = string(v)
-				case string:
-					entity.Fields[columnName] = v
-				case int64:
-					entity.Fields[columnName] = fmt.Sprintf("%d", v)
-				case float64:
-					entity.Fields[columnName] = fmt.Sprintf("%f", v)
-				case bool:
-					entity.Fields[columnName] = fmt.Sprintf("%t", v)
-				case time.Time:
-					entity.Fields[columnName] = v.Format(time.RFC3339)
-				default:
-					entity.Fields[columnName] = fmt.Sprintf("%v", v)
-				}
-			} else {
-				entity.Fields[columnName] = ""
-			}
+		nextPageToken, err = encodePageToken(fingerprint, key, lastEntity.Values["id"].GetInt64Value())
+		if err != nil {
+			return nil, err
 		}
-		
-		entities = append(entities, entity)
 	}
 	
 	// Получаем общее количество
-	var totalCount int32
-	countValues := values[:len(values)-2] // Убираем LIMIT и OFFSET
-	if len(countValues) > 0 {
+	totalCount := int32(-1)
+	if !listRequest.SkipTotalCount {
 		err = dataService.db.QueryRowContext(ctx, countQuery, countValues...).Scan(&totalCount)
-	} else {
-		err = dataService.db.QueryRowContext(ctx, countQuery).Scan(&totalCount)
-	}
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
 	}
 	
 	return &api.ListResponse{
-		TableName:  listRequest.TableName,
-		Entities:   entities,
-		TotalCount: totalCount,
-		Page:       listRequest.Page,
-		PageSize:   listRequest.PageSize,
+		TableName:     listRequest.TableName,
+		Entities:      entities,
+		TotalCount:    totalCount,
+		Page:          listRequest.Page,
+		PageSize:      pageSize,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -323,25 +339,48 @@ func (dataService *DataService) Search(ctx context.Context, searchRequest *api.S
 		return nil, fmt.Errorf("search fields are required")
 	}
 	
-	query := "SELECT * FROM " + searchRequest.TableName + " WHERE destroyed = false AND ("
-	countQuery := "SELECT COUNT(*) FROM " + searchRequest.TableName + " WHERE destroyed = false AND ("
+	table, err := dataService.schema.Table(searchRequest.TableName)
+	if err != nil {
+		return nil, err
+	}
+	
+	query := "SELECT * FROM " + table.QuotedName() + " WHERE destroyed = false AND ("
+	countQuery := "SELECT COUNT(*) FROM " + table.QuotedName() + " WHERE destroyed = false AND ("
 	
 	searchPattern := "%" + searchRequest.Query + "%"
 	values := []interface{}{}
 	
 	for fieldIndex, fieldName := range searchRequest.Fields {
+		columnName, err := table.QuoteColumn(fieldName)
+		if err != nil {
+			return nil, err
+		}
 		if fieldIndex > 0 {
 			query += " OR "
 			countQuery += " OR "
 		}
-		query += fieldName + " ILIKE $" + fmt.Sprintf("%d", fieldIndex+1)
-		countQuery += fieldName + " ILIKE $" + fmt.Sprintf("%d", fieldIndex+1)
+		query += columnName + " ILIKE $" + fmt.Sprintf("%d", fieldIndex+1)
+		countQuery += columnName + " ILIKE $" + fmt.Sprintf("%d", fieldIndex+1)
 		values = append(values, searchPattern)
 	}
 	
 	query += ")"
 	countQuery += ")"
 	
+	// Дерево условий сужает результат поиска
+	if searchRequest.Filter != nil {
+		condition, filterValues, err := buildFilter(table, searchRequest.Filter, len(values)+1)
+		if err != nil {
+			return nil, err
+		}
+		query += " AND (" + condition + ")"
+		countQuery += " AND (" + condition + ")"
+		values = append(values, filterValues...)
+	}
+	
+	// Подсчет использует условия без лимита и оффсета
+	countValues := values
+	
 	// Добавляем лимит и оффсет
 	if searchRequest.Limit > 0 {
 		query += " LIMIT $" + fmt.Sprintf("%d", len(values)+1)
@@ -360,57 +399,23 @@ func (dataService *DataService) Search(ctx context.Context, searchRequest *api.S
 	}
 	defer rows.Close()
 	
-	columns, err := rows.Columns()
+	columnTypes, err := rows.ColumnTypes()
 	if err != nil {
 		return nil, err
 	}
 	
 	var entities []*api.Entity
 	for rows.Next() {
-		rowValues := make([]interface{}, len(columns))
-		rowValuePointers := make([]interface{}, len(columns))
-		for i := range rowValues {
-			rowValuePointers[i] = &rowValues[i]
-		}
-		
-		err = rows.Scan(rowValuePointers...)
+		entity, err := scanEntity(rows, columnTypes)
 		if err != nil {
 			return nil, err
 		}
-		
-		entity := &api.Entity{
-			Fields: make(map[string]string),
-		}
-		
-		for i, columnName := range columns {
-			if rowValues[i] != nil {
-				switch v := rowValues[i].(type) {
-				case []byte:
-					entity.Fields[columnName] = string(v)
-				case string:
-					entity.Fields[columnName] = v
-				case int64:
-					entity.Fields[columnName] = fmt.Sprintf("%d", v)
-				case float64:
-					entity.Fields[columnName] = fmt.Sprintf("%f", v)
-				case bool:
-					entity.Fields[columnName] = fmt.Sprintf("%t", v)
-				case time.Time:
-					entity.Fields[columnName] = v.Format(time.RFC3339)
-				default:
-					entity.Fields[columnName] = fmt.Sprintf("%v", v)
-				}
-			} else {
-				entity.Fields[columnName] = ""
-			}
-		}
-		
 		entities = append(entities, entity)
 	}
 	
 	// Получаем общее количество
 	var totalCount int32
-	err = dataService.db.QueryRowContext(ctx, countQuery, values[:len(searchRequest.Fields)]...).Scan(&totalCount)
+	err = dataService.db.QueryRowContext(ctx, countQuery, countValues...).Scan(&totalCount)
 	if err != nil {
 		return nil, err
 	}
@@ -426,6 +431,21 @@ func (dataService *DataService) Search(ctx context.Context, searchRequest *api.S
 
 // BatchCreate - пакетное создание записей
 func (dataService *DataService) BatchCreate(ctx context.Context, batchCreateRequest *api.BatchCreateRequest) (*api.BatchResponse, error) {
+	table, err := dataService.schema.Table(batchCreateRequest.TableName)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Проверяем колонки всех записей до начала транзакции
+	queries := make([]string, len(batchCreateRequest.Entities))
+	queryValues := make([][]interface{}, len(batchCreateRequest.Entities))
+	for entityIndex, entity := range batchCreateRequest.Entities {
+		queries[entityIndex], queryValues[entityIndex], err = buildInsertQuery(table, entity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	
 	transaction, err := dataService.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -435,27 +455,9 @@ func (dataService *DataService) BatchCreate(ctx context.Context, batchCreateRequ
 	var ids []int32
 	var errors []string
 	
-	for _, entity := range batchCreateRequest.Entities {
-		columns := ""
-		placeholders := ""
-		values := []interface{}{}
-		parameterIndex := 1
-		
-		for fieldName, fieldValue := range entity.Fields {
-			if columns != "" {
-				columns += ", "
-				placeholders += ", "
-			}
-			columns += fieldName
-			placeholders += "$" + fmt.Sprintf("%d", parameterIndex)
-			values = append(values, fieldValue)
-			parameterIndex++
-		}
-		
-		query := "INSERT INTO " + batchCreateRequest.TableName + " (" + columns + ") VALUES (" + placeholders + ") RETURNING id"
-		
+	for entityIndex := range batchCreateRequest.Entities {
 		var id int32
-		err := transaction.QueryRowContext(ctx, query, values...).Scan(&id)
+		err := transaction.QueryRowContext(ctx, queries[entityIndex], queryValues[entityIndex]...).Scan(&id)
 		if err != nil {
 			errors = append(errors, err.Error())
 		} else {
@@ -477,6 +479,18 @@ func (dataService *DataService) BatchCreate(ctx context.Context, batchCreateRequ
 
 // BatchUpdate - пакетное обновление записей
 func (dataService *DataService) BatchUpdate(ctx context.Context, batchUpdateRequest *api.BatchUpdateRequest) (*api.BatchResponse, error) {
+	table, err := dataService.schema.Table(batchUpdateRequest.TableName)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Проверяем колонки и значения всех записей до начала транзакции
+	for _, entity := range batchUpdateRequest.Entities {
+		if _, _, err := entityArguments(table, entity); err != nil {
+			return nil, err
+		}
+	}
+	
 	transaction, err := dataService.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -489,7 +503,7 @@ func (dataService *DataService) BatchUpdate(ctx context.Context, batchUpdateRequ
 	
 	for _, entity := range batchUpdateRequest.Entities {
 		// Предполагаем, что ID есть в fields
-		idString, exists := entity.Fields["id"]
+		idString, exists := entity.TextFields()["id"]
 		if !exists {
 			errors = append(errors, "ID field is required for batch update")
 			continue
@@ -498,25 +512,12 @@ func (dataService *DataService) BatchUpdate(ctx context.Context, batchUpdateRequ
 		var id int32
 		fmt.Sscanf(idString, "%d", &id)
 		
-		setClause := ""
-		values := []interface{}{}
-		parameterIndex := 1
-		
-		for fieldName, fieldValue := range entity.Fields {
-			if fieldName == "id" {
-				continue
-			}
-			if setClause != "" {
-				setClause += ", "
-			}
-			setClause += fieldName + " = $" + fmt.Sprintf("%d", parameterIndex)
-			values = append(values, fieldValue)
-			parameterIndex++
+		query, values, err := buildUpdateQuery(table, entity, id)
+		if err != nil {
+			errors = append(errors, err.Error())
+			continue
 		}
 		
-		values = append(values, id)
-		query := "UPDATE " + batchUpdateRequest.TableName + " SET " + setClause + ", updated_at = NOW() WHERE id = $" + fmt.Sprintf("%d", parameterIndex) + " AND destroyed = false"
-		
 		result, err := transaction.ExecContext(ctx, query, values...)
 		if err != nil {
 			errors = append(errors, err.Error())
@@ -539,25 +540,106 @@ func (dataService *DataService) BatchUpdate(ctx context.Context, batchUpdateRequ
 	}, nil
 }
 
+// buildInsertQuery формирует INSERT по полям и типизированным значениям записи;
+// колонки проверяются по схеме таблицы
+func buildInsertQuery(table *TableSchema, entity *api.Entity) (string, []interface{}, error) {
+	columns := ""
+	placeholders := ""
+	values := []interface{}{}
+	parameterIndex := 1
+	
+	columnNames, arguments, err := entityArguments(table, entity)
+	if err != nil {
+		return "", nil, err
+	}
+	
+	for _, columnName := range columnNames {
+		if columns != "" {
+			columns += ", "
+			placeholders += ", "
+		}
+		columns += pq.QuoteIdentifier(columnName)
+		placeholders += "$" + fmt.Sprintf("%d", parameterIndex)
+		values = append(values, arguments[columnName])
+		parameterIndex++
+	}
+	
+	query := "INSERT INTO " + table.QuotedName() + " (" + columns + ") VALUES (" + placeholders + ") RETURNING id"
+	return query, values, nil
+}
+
+// buildUpdateQuery формирует UPDATE записи с указанным ID; поле id в SET не попадает
+func buildUpdateQuery(table *TableSchema, entity *api.Entity, id int32) (string, []interface{}, error) {
+	setClause := ""
+	values := []interface{}{}
+	parameterIndex := 1
+	
+	columnNames, arguments, err := entityArguments(table, entity)
+	if err != nil {
+		return "", nil, err
+	}
+	
+	for _, columnName := range columnNames {
+		if columnName == "id" {
+			continue
+		}
+		setClause += pq.QuoteIdentifier(columnName) + " = $" + fmt.Sprintf("%d", parameterIndex) + ", "
+		values = append(values, arguments[columnName])
+		parameterIndex++
+	}
+	
+	values = append(values, id)
+	query := "UPDATE " + table.QuotedName() + " SET " + setClause + "updated_at = NOW() WHERE id = $" + fmt.Sprintf("%d", parameterIndex) + " AND destroyed = false"
+	return query, values, nil
+}
+
 // ListOrganizations - получение списка организаций
 func (dataService *DataService) ListOrganizations(ctx context.Context, req *api.ListOrganizationsRequest) (*api.ListOrganizationsResponse, error) {
 	query := `SELECT id, inn, name, full_name, spark_status, internal_status, final_status, 
 					 registration_date, added_to_registry_date, has_special_status, 
 					 is_systemically_important, msp_status, created_at, updated_at 
-			  FROM active_organizations`
+			  FROM active_organizations WHERE true`
 	
-	countQuery := "SELECT COUNT(*) FROM active_organizations"
+	countQuery := "SELECT COUNT(*) FROM active_organizations WHERE true"
 	
 	args := []interface{}{}
 	
 	if req.Filter != "" {
-		query += " WHERE name ILIKE $1 OR inn ILIKE $1"
-		countQuery += " WHERE name ILIKE $1 OR inn ILIKE $1"
+		query += " AND (name ILIKE $1 OR inn ILIKE $1)"
+		countQuery += " AND (name ILIKE $1 OR inn ILIKE $1)"
 		args = append(args, "%"+req.Filter+"%")
 	}
+	countArgs := args
+	
+	// Пустое название сортируется вместе с NULL: в Organization они неотличимы,
+	// а ключ токена должен однозначно задавать позицию строки
+	page := keyset{keyExpression: "NULLIF(name, '')"}
+	fingerprint, err := queryFingerprint(&api.ListOrganizationsRequest{Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+	
+	if req.PageToken != "" {
+		token, err := decodePageToken(req.PageToken, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		condition, keyArgs, err := page.after(token, len(args)+1)
+		if err != nil {
+			return nil, err
+		}
+		query += " AND " + condition
+		args = append(args, keyArgs...)
+	}
 	
-	query += " ORDER BY name LIMIT $2 OFFSET $3"
-	args = append(args, req.PageSize, (req.Page-1)*req.PageSize)
+	// Лишняя строка показывает, есть ли следующая страница
+	pageSize := normalizePageSize(req.PageSize)
+	query += " ORDER BY " + page.orderBy() + fmt.Sprintf(" LIMIT $%d", len(args)+1)
+	args = append(args, pageSize+1)
+	if req.PageToken == "" && req.Page > 1 {
+		query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+		args = append(args, (req.Page-1)*pageSize)
+	}
 	
 	rows, err := dataService.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -567,29 +649,99 @@ func (dataService *DataService) ListOrganizations(ctx context.Context, req *api.
 	
 	var organizations []*api.Organization
 	for rows.Next() {
-		var org api.Organization
-		err := rows.Scan(
-			&org.Id, &org.Inn, &org.Name, &org.FullName, &org.SparkStatus, &org.InternalStatus,
-			&org.FinalStatus, &org.RegistrationDate, &org.AddedToRegistryDate, &org.HasSpecialStatus,
-			&org.IsSystemicallyImportant, &org.MspStatus, &org.CreatedAt, &org.UpdatedAt,
-		)
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	
+	var nextPageToken string
+	if len(organizations) > int(pageSize) {
+		organizations = organizations[:pageSize]
+		lastOrganization := organizations[len(organizations)-1]
+		key := api.NullValue()
+		if lastOrganization.Name != "" {
+			key = &api.Value{Kind: &api.Value_StringValue{StringValue: lastOrganization.Name}}
+		}
+		nextPageToken, err = encodePageToken(fingerprint, key, int64(lastOrganization.Id))
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	totalCount := int32(-1)
+	if !req.SkipTotalCount {
+		err = dataService.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
 		if err != nil {
 			return nil, err
 		}
-		organizations = append(organizations, &org)
 	}
 	
+	return &api.ListOrganizationsResponse{
+		Organizations: organizations,
+		TotalCount:    totalCount,
+		Page:          req.Page,
+		PageSize:      pageSize,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// SearchOrganizations - поиск организаций по названию, полному названию, ИНН и ОГРН
+func (dataService *DataService) SearchOrganizations(ctx context.Context, req *api.SearchOrganizationsRequest) (*api.ListOrganizationsResponse, error) {
+	if req.Query == "" {
+		return nil, &InvalidArgumentError{Reason: "search query is required"}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	// % и _ в запросе ищутся буквально, а не как шаблон ILIKE
+	searchCondition := " WHERE name ILIKE $1 OR full_name ILIKE $1 OR inn ILIKE $1 OR ogrn ILIKE $1"
+	searchPattern := "%" + escapeLikePattern(req.Query) + "%"
+
+	query := `SELECT id, inn, name, full_name, spark_status, internal_status, final_status, 
+					 registration_date, added_to_registry_date, has_special_status, 
+					 is_systemically_important, msp_status, created_at, updated_at 
+			  FROM active_organizations` + searchCondition + " ORDER BY name LIMIT $2"
+
+	rows, err := dataService.db.QueryContext(ctx, query, searchPattern, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var organizations []*api.Organization
+	for rows.Next() {
+		organization, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	var totalCount int32
-	err = dataService.db.QueryRowContext(ctx, countQuery, args[:len(args)-2]...).Scan(&totalCount)
+	err = dataService.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM active_organizations"+searchCondition, searchPattern).Scan(&totalCount)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return &api.ListOrganizationsResponse{
 		Organizations: organizations,
 		TotalCount:    totalCount,
-		Page:          req.Page,
-		PageSize:      req.PageSize,
+		Page:          1,
+		PageSize:      limit,
 	}, nil
 }
 
@@ -701,7 +853,6 @@ func (dataService *DataService) GetStaffData(ctx context.Context, req *api.GetSt
 
 // Специализированные методы остаются без изменений
 func (dataService *DataService) GetOrganization(ctx context.Context, getOrganizationRequest *api.GetOrganizationRequest) (*api.OrganizationResponse, error) {
-	var organization api.Organization
 	var query string
 	var arguments []interface{}
 
@@ -720,16 +871,12 @@ func (dataService *DataService) GetOrganization(ctx context.Context, getOrganiza
 		arguments = []interface{}{identifier.Inn}
 	}
 
-	err := dataService.db.QueryRowContext(ctx, query, arguments...).Scan(
-		&organization.Id, &organization.Inn, &organization.Name, &organization.FullName, &organization.SparkStatus, &organization.InternalStatus,
-		&organization.FinalStatus, &organization.RegistrationDate, &organization.AddedToRegistryDate, &organization.HasSpecialStatus,
-		&organization.IsSystemicallyImportant, &organization.MspStatus, &organization.CreatedAt, &organization.UpdatedAt,
-	)
+	organization, err := scanOrganization(dataService.db.QueryRowContext(ctx, query, arguments...))
 	if err != nil {
 		return nil, err
 	}
 
-	return &api.OrganizationResponse{Organization: &organization}, nil
+	return &api.OrganizationResponse{Organization: organization}, nil
 }
 
 func (dataService *DataService) ValidateInvite(ctx context.Context, validateInviteRequest *api.ValidateInviteRequest) (*api.InviteResponse, error) {
@@ -748,48 +895,59 @@ func (dataService *DataService) ValidateInvite(ctx context.Context, validateInvi
 	return &api.InviteResponse{Invite: &invite}, nil
 }
 
-func (dataService *DataService) UseInvite(ctx context.Context, useInviteRequest *api.UseInviteRequest) (*api.UserResponse, error) {
+func (dataService *DataService) UseInvite(ctx context.Context, useInviteRequest *api.UseInviteRequest) (*api.InviteResponse, error) {
 	transaction, err := dataService.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer transaction.Rollback()
 
-	var invite api.Invite
+	// Блокируем приглашение до конца транзакции: один код нельзя использовать дважды
+	var inviteId, organizationId, roleId int32
 	err = transaction.QueryRowContext(ctx,
-		`UPDATE invite_codes SET is_used = true, used_at = NOW(), used_by = (SELECT id FROM users WHERE email = $1)
-		 WHERE code = $2 AND is_used = false AND expires_at > NOW()
-		 RETURNING id, code, email, organization_id, role_id, is_used, expires_at, created_at`,
-		useInviteRequest.Email, useInviteRequest.Code,
-	).Scan(&invite.Id, &invite.Code, &invite.Email, &invite.OrganizationId, 
-		&invite.RoleId, &invite.IsUsed, &invite.ExpiresAt, &invite.CreatedAt)
+		`SELECT id, organization_id, role_id FROM invite_codes
+		 WHERE code = $1 AND is_used = false AND expires_at > NOW()
+		 FOR UPDATE`,
+		useInviteRequest.Code,
+	).Scan(&inviteId, &organizationId, &roleId)
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Создаем пользователя
-	_, err = dataService.CreateUser(ctx, &api.CreateUserRequest{
+	// Создаем пользователя в той же транзакции: при ошибке приглашение остается неиспользованным
+	userId, err := insertUser(ctx, transaction, &api.CreateUserRequest{
 		Email:          useInviteRequest.Email,
 		Password:       useInviteRequest.Password,
 		FirstName:      useInviteRequest.FirstName,
 		LastName:       useInviteRequest.LastName,
 		Phone:          useInviteRequest.Phone,
-		OrganizationId: invite.OrganizationId,
-		RoleId:         invite.RoleId,
+		OrganizationId: organizationId,
+		RoleId:         roleId,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	var invite api.Invite
+	err = transaction.QueryRowContext(ctx,
+		`UPDATE invite_codes SET is_used = true, used_at = NOW(), used_by = $1
+		 WHERE id = $2
+		 RETURNING id, code, email, organization_id, role_id, is_used, expires_at, created_at`,
+		userId, inviteId,
+	).Scan(&invite.Id, &invite.Code, &invite.Email, &invite.OrganizationId, 
+		&invite.RoleId, &invite.IsUsed, &invite.ExpiresAt, &invite.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
 	if err := transaction.Commit(); err != nil {
 		return nil, err
 	}
 
-	// Возвращаем созданного пользователя
-	return dataService.GetUser(ctx, &api.GetUserRequest{
-		Identifier: &api.GetUserRequest_Email{Email: useInviteRequest.Email},
-	})
+	// Возвращаем погашенное приглашение
+	return &api.InviteResponse{Invite: &invite}, nil
 }
 
 func (dataService *DataService) SubmitForm(ctx context.Context, submitFormRequest *api.SubmitFormRequest) (*api.FormResponse, error) {
@@ -816,7 +974,6 @@ func (dataService *DataService) SubmitForm(ctx context.Context, submitFormReques
 
 // Вспомогательные методы (без изменений)
 func (dataService *DataService) GetUser(ctx context.Context, getUserRequest *api.GetUserRequest) (*api.UserResponse, error) {
-	var user api.User
 	var query string
 	var arguments []interface{}
 
@@ -833,19 +990,29 @@ func (dataService *DataService) GetUser(ctx context.Context, getUserRequest *api
 		arguments = []interface{}{identifier.Email}
 	}
 
-	err := dataService.db.QueryRowContext(ctx, query, arguments...).Scan(
-		&user.Id, &user.Email, &user.FirstName, &user.LastName, &user.Phone,
-		&user.OrganizationId, &user.RoleId, &user.IsActive, &user.IsVerified,
-		&user.CreatedAt, &user.LastLogin, &user.UpdatedAt,
-	)
+	user, err := scanUser(dataService.db.QueryRowContext(ctx, query, arguments...))
 	if err != nil {
 		return nil, err
 	}
 
-	return &api.UserResponse{User: &user}, nil
+	return &api.UserResponse{User: user}, nil
 }
 
 func (dataService *DataService) CreateUser(ctx context.Context, createUserRequest *api.CreateUserRequest) (*api.UserResponse, error) {
+	userId, err := insertUser(ctx, dataService.db, createUserRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Возвращаем созданного пользователя
+	return dataService.GetUser(ctx, &api.GetUserRequest{
+		Identifier: &api.GetUserRequest_Id{Id: userId},
+	})
+}
+
+// insertUser добавляет пользователя и возвращает его ID; querier - пул соединений
+// или транзакция, в которой пользователь создается вместе с другими изменениями
+func insertUser(ctx context.Context, querier rowQuerier, createUserRequest *api.CreateUserRequest) (int32, error) {
 	// Генерация солей и хешей пароля (упрощенная версия)
 	saltEmail := generateSalt()
 	saltPhone := generateSalt()
@@ -853,7 +1020,7 @@ func (dataService *DataService) CreateUser(ctx context.Context, createUserReques
 	passwordHashPhone := hashPassword(createUserRequest.Password + saltPhone)
 
 	var userId int32
-	err := dataService.db.QueryRowContext(ctx,
+	err := querier.QueryRowContext(ctx,
 		`INSERT INTO users (email, password_hash_email_sha256, password_hash_email_sha512256, 
 		 password_hash_phone_sha256, password_hash_phone_sha512256, salt_email, salt_phone,
 		 first_name, last_name, phone, organization_id, role_id, is_active, is_verified)
@@ -866,16 +1033,144 @@ func (dataService *DataService) CreateUser(ctx context.Context, createUserReques
 		createUserRequest.FirstName, createUserRequest.LastName, createUserRequest.Phone, createUserRequest.OrganizationId, createUserRequest.RoleId,
 		true, false,
 	).Scan(&userId)
-	if err != nil {
-		return nil, err
+	return userId, err
+}
+
+// UpdateUser обновляет только переданные поля пользователя
+func (dataService *DataService) UpdateUser(ctx context.Context, updateUserRequest *api.UpdateUserRequest) (*api.UserResponse, error) {
+	setClause := ""
+	values := []interface{}{}
+
+	addField := func(fieldName string, fieldValue interface{}) {
+		if setClause != "" {
+			setClause += ", "
+		}
+		values = append(values, fieldValue)
+		setClause += fieldName + " = $" + fmt.Sprintf("%d", len(values))
+	}
+
+	if updateUserRequest.FirstName != "" {
+		addField("first_name", updateUserRequest.FirstName)
+	}
+	if updateUserRequest.LastName != "" {
+		addField("last_name", updateUserRequest.LastName)
+	}
+	if updateUserRequest.Phone != "" {
+		addField("phone", updateUserRequest.Phone)
+	}
+	if updateUserRequest.IsActive != nil {
+		addField("is_active", *updateUserRequest.IsActive)
+	}
+
+	// Если менять нечего, просто возвращаем текущее состояние пользователя
+	if setClause != "" {
+		values = append(values, updateUserRequest.Id)
+		query := "UPDATE users SET " + setClause + ", updated_at = NOW() WHERE id = $" + fmt.Sprintf("%d", len(values)) + " AND destroyed = false"
+
+		result, err := dataService.db.ExecContext(ctx, query, values...)
+		if err != nil {
+			return nil, err
+		}
+
+		affectedRows, _ := result.RowsAffected()
+		if affectedRows == 0 {
+			return nil, sql.ErrNoRows
+		}
 	}
 
-	// Возвращаем созданного пользователя
 	return dataService.GetUser(ctx, &api.GetUserRequest{
-		Identifier: &api.GetUserRequest_Id{Id: userId},
+		Identifier: &api.GetUserRequest_Id{Id: updateUserRequest.Id},
 	})
 }
 
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// rowQuerier общий интерфейс для *sql.DB и *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// scanOrganization читает организацию с учетом NULL значений в необязательных колонках
+func scanOrganization(row rowScanner) (*api.Organization, error) {
+	var organization api.Organization
+	var inn, name, fullName, sparkStatus, internalStatus, finalStatus, mspStatus sql.NullString
+	var registrationDate, addedToRegistryDate, createdAt, updatedAt sql.NullTime
+	var hasSpecialStatus, isSystemicallyImportant sql.NullBool
+
+	err := row.Scan(
+		&organization.Id, &inn, &name, &fullName, &sparkStatus, &internalStatus,
+		&finalStatus, &registrationDate, &addedToRegistryDate, &hasSpecialStatus,
+		&isSystemicallyImportant, &mspStatus, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	organization.Inn = inn.String
+	organization.Name = name.String
+	organization.FullName = fullName.String
+	organization.SparkStatus = sparkStatus.String
+	organization.InternalStatus = internalStatus.String
+	organization.FinalStatus = finalStatus.String
+	organization.RegistrationDate = formatNullDate(registrationDate)
+	organization.AddedToRegistryDate = formatNullDate(addedToRegistryDate)
+	organization.HasSpecialStatus = hasSpecialStatus.Bool
+	organization.IsSystemicallyImportant = isSystemicallyImportant.Bool
+	organization.MspStatus = mspStatus.String
+	organization.CreatedAt = nullTimeToTimestamp(createdAt)
+	organization.UpdatedAt = nullTimeToTimestamp(updatedAt)
+
+	return &organization, nil
+}
+
+// scanUser читает пользователя с учетом NULL значений в необязательных колонках
+func scanUser(row rowScanner) (*api.User, error) {
+	var user api.User
+	var firstName, lastName, phone sql.NullString
+	var organizationId, roleId sql.NullInt32
+	var isActive, isVerified sql.NullBool
+	var createdAt, lastLogin, updatedAt sql.NullTime
+
+	err := row.Scan(
+		&user.Id, &user.Email, &firstName, &lastName, &phone,
+		&organizationId, &roleId, &isActive, &isVerified,
+		&createdAt, &lastLogin, &updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.FirstName = firstName.String
+	user.LastName = lastName.String
+	user.Phone = phone.String
+	user.OrganizationId = organizationId.Int32
+	user.RoleId = roleId.Int32
+	user.IsActive = isActive.Bool
+	user.IsVerified = isVerified.Bool
+	user.CreatedAt = nullTimeToTimestamp(createdAt)
+	user.LastLogin = nullTimeToTimestamp(lastLogin)
+	user.UpdatedAt = nullTimeToTimestamp(updatedAt)
+
+	return &user, nil
+}
+
+func formatNullDate(value sql.NullTime) string {
+	if !value.Valid {
+		return ""
+	}
+	return value.Time.Format("2006-01-02")
+}
+
+func nullTimeToTimestamp(value sql.NullTime) *timestamppb.Timestamp {
+	if !value.Valid {
+		return nil
+	}
+	return timestamppb.New(value.Time)
+}
+
 // Вспомогательные функции (без изменений)
 func generateSalt() string {
 	return "salt_" + fmt.Sprintf("%d", time.Now().UnixNano())
@@ -887,4 +1182,42 @@ func hashPassword(password string) string {
 
 func generateInviteCode() string {
 	return fmt.Sprintf("INV%d", time.Now().UnixNano())
-}
\ No newline at end of file
+}
+
+// HealthCheck проверяет доступность PostgreSQL и возвращает состояние пула соединений
+func (dataService *DataService) HealthCheck(ctx context.Context) *api.SystemResponse {
+	pingContext, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	startedAt := time.Now()
+	pingError := dataService.db.PingContext(pingContext)
+	pingLatency := time.Since(startedAt)
+
+	stats := dataService.db.Stats()
+	data := map[string]string{
+		"ping_latency_ms":      strconv.FormatFloat(float64(pingLatency.Microseconds())/1000, 'f', 3, 64),
+		"max_open_connections": strconv.Itoa(stats.MaxOpenConnections),
+		"open_connections":     strconv.Itoa(stats.OpenConnections),
+		"in_use":               strconv.Itoa(stats.InUse),
+		"idle":                 strconv.Itoa(stats.Idle),
+		"wait_count":           strconv.FormatInt(stats.WaitCount, 10),
+		"wait_duration_ms":     strconv.FormatInt(stats.WaitDuration.Milliseconds(), 10),
+		"max_idle_closed":      strconv.FormatInt(stats.MaxIdleClosed, 10),
+		"max_lifetime_closed":  strconv.FormatInt(stats.MaxLifetimeClosed, 10),
+	}
+
+	if pingError != nil {
+		data["error"] = pingError.Error()
+		return &api.SystemResponse{
+			Success: false,
+			Message: "Database ping failed",
+			Data:    data,
+		}
+	}
+
+	return &api.SystemResponse{
+		Success: true,
+		Message: "Service is healthy",
+		Data:    data,
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"industrialregistrysystem/base/api"
+)
+
+// Размер фрагмента выгрузки
+const (
+	defaultExportChunkSize = 500
+	maxExportChunkSize     = 5000
+)
+
+// exportCursorName имя курсора выгрузки; курсоры видны только в своей транзакции
+const exportCursorName = "export_cursor"
+
+// errExportStalled mainservice не разрешил отправить следующий фрагмент за отведенное время
+var errExportStalled = errors.New("export stalled: no credit from mainservice")
+
+// exportFlow разрешения mainservice на отправку фрагментов одной выгрузки
+type exportFlow struct {
+	mu      sync.Mutex
+	credits int32
+	granted chan struct{}   // Сигнал о новых разрешениях
+	ctx     context.Context // Отменяется командой cancel и закрытием потока команд
+	cancel  context.CancelFunc
+}
+
+// acquire ждет разрешения на отправку одного фрагмента не дольше timeout
+func (flow *exportFlow) acquire(ctx context.Context, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		// Отмененная выгрузка не отправляет даже разрешенные фрагменты
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		flow.mu.Lock()
+		if flow.credits > 0 {
+			flow.credits--
+			flow.mu.Unlock()
+			return nil
+		}
+		flow.mu.Unlock()
+
+		select {
+		case <-flow.granted:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return errExportStalled
+		}
+	}
+}
+
+// grant добавляет разрешения и будит ожидающую выгрузку
+func (flow *exportFlow) grant(chunks int32) {
+	flow.mu.Lock()
+	flow.credits += chunks
+	flow.mu.Unlock()
+
+	select {
+	case flow.granted <- struct{}{}:
+	default:
+	}
+}
+
+// exportRegistry выполняемые выгрузки по request_id: к ним направляются команды ExportCredit
+type exportRegistry struct {
+	mu      sync.Mutex
+	exports map[string]*exportFlow
+}
+
+func newExportRegistry() *exportRegistry {
+	return &exportRegistry{exports: make(map[string]*exportFlow)}
+}
+
+// route применяет команды управления выгрузками в цикле приема, до запуска горутины
+// обработчика. Возвращает true, если команда обработана целиком.
+func (registry *exportRegistry) route(command *api.CommandRequest) bool {
+	if credit := command.GetExportCredit(); credit != nil {
+		registry.handleCredit(command.RequestId, credit)
+		return true
+	}
+	// Выгрузка регистрируется до запуска горутины: разрешения и отмена, отправленные
+	// следом за командой, застают ее в реестре
+	if export := command.GetExport(); export != nil {
+		registry.register(command.RequestId, export.Window)
+	}
+	return false
+}
+
+// register добавляет выгрузку с window разрешениями из ExportRequest
+func (registry *exportRegistry) register(requestID string, window int32) *exportFlow {
+	// mainservice всегда задает окно; без него выгрузка ждала бы разрешения, которое не придет
+	if window < 1 {
+		window = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	flow := &exportFlow{credits: window, granted: make(chan struct{}, 1), ctx: ctx, cancel: cancel}
+
+	registry.mu.Lock()
+	registry.exports[requestID] = flow
+	registry.mu.Unlock()
+	return flow
+}
+
+// lookup возвращает зарегистрированную выгрузку
+func (registry *exportRegistry) lookup(requestID string) (*exportFlow, bool) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	flow, found := registry.exports[requestID]
+	return flow, found
+}
+
+// unregister удаляет завершенную выгрузку и освобождает ее контекст
+func (registry *exportRegistry) unregister(requestID string) {
+	registry.mu.Lock()
+	flow, found := registry.exports[requestID]
+	delete(registry.exports, requestID)
+	registry.mu.Unlock()
+
+	if found {
+		flow.cancel()
+	}
+}
+
+// handleCredit применяет команду ExportCredit. Разрешения для уже завершенной
+// выгрузки приходят штатно и игнорируются.
+func (registry *exportRegistry) handleCredit(requestID string, credit *api.ExportCredit) {
+	flow, found := registry.lookup(requestID)
+	if !found {
+		return
+	}
+
+	if credit.Cancel {
+		log.Printf("🚫 Export %s cancelled by mainservice", requestID)
+		flow.cancel()
+		return
+	}
+	flow.grant(credit.Chunks)
+}
+
+// cancelAll прерывает все выгрузки: поток команд закрыт, и фрагменты некуда отправлять
+func (registry *exportRegistry) cancelAll() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for requestID, flow := range registry.exports {
+		log.Printf("🚫 Export %s cancelled: command stream closed", requestID)
+		flow.cancel()
+	}
+}
+
+// Export читает таблицу курсором PostgreSQL и отправляет строки фрагментами с тем же
+// request_id. Каждый фрагмент отправляется только после разрешения mainservice, поэтому
+// в памяти воркера одновременно находится не больше одного фрагмента. Последний
+// фрагмент помечен last. Выгрузка должна быть зарегистрирована через route.
+func (dataService *DataService) Export(requestID string, exportRequest *api.ExportRequest, stream api.DatabaseService_CommandStreamClient) error {
+	flow, found := dataService.exports.lookup(requestID)
+	if !found {
+		return fmt.Errorf("export %s is not registered", requestID)
+	}
+	defer dataService.exports.unregister(requestID)
+	ctx := flow.ctx
+
+	table, err := dataService.schema.Table(exportRequest.TableName)
+	if err != nil {
+		return err
+	}
+
+	condition, values, err := buildListCondition(table, exportRequest.Filters, exportRequest.Filter)
+	if err != nil {
+		return err
+	}
+
+	// Порядок, как в List: колонка сортировки, затем id
+	order := keyset{descending: exportRequest.OrderDesc}
+	if exportRequest.OrderBy != "" && exportRequest.OrderBy != "id" {
+		order.keyExpression, err = table.QuoteColumn(exportRequest.OrderBy)
+		if err != nil {
+			return err
+		}
+	}
+	query := "SELECT * FROM " + table.QuotedName() + " WHERE " + condition + " ORDER BY " + order.orderBy()
+	chunkSize := normalizeExportChunkSize(exportRequest.ChunkSize)
+
+	// Все фрагменты читаются из одного снимка: изменения во время выгрузки
+	// не дают повторов и пропусков строк
+	tx, err := dataService.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DECLARE "+exportCursorName+" NO SCROLL CURSOR FOR "+query, values...); err != nil {
+		return err
+	}
+
+	log.Printf("📤 Export %s of table %s started, chunk size %d", requestID, table.Name, chunkSize)
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM %s", chunkSize, exportCursorName)
+	var totalRows int64
+	for sequence := int64(0); ; sequence++ {
+		if err := flow.acquire(ctx, dataService.commandTimeout); err != nil {
+			return err
+		}
+
+		chunk, err := dataService.fetchExportChunk(ctx, tx, fetchQuery)
+		if err != nil {
+			return err
+		}
+		if sequence > 0 {
+			chunk.Columns = nil
+		}
+		chunk.TableName = table.Name
+		chunk.Sequence = sequence
+		totalRows += int64(len(chunk.Entities))
+
+		// Неполный фрагмент означает, что курсор исчерпан
+		chunk.Last = len(chunk.Entities) < int(chunkSize)
+		if chunk.Last {
+			chunk.TotalRows = totalRows
+		}
+
+		err = stream.Send(&api.CommandResponse{
+			RequestId: requestID,
+			Response: &api.CommandResponse_ExportChunk{
+				ExportChunk: chunk,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if chunk.Last {
+			log.Printf("📤 Export %s finished: %d rows in %d chunks", requestID, totalRows, sequence+1)
+			return tx.Commit()
+		}
+	}
+}
+
+// fetchExportChunk читает следующий фрагмент из курсора выгрузки
+func (dataService *DataService) fetchExportChunk(ctx context.Context, tx *sql.Tx, fetchQuery string) (*api.ExportChunk, error) {
+	fetchContext, cancel := context.WithTimeout(ctx, dataService.commandTimeout)
+	defer cancel()
+
+	rows, err := tx.QueryContext(fetchContext, fetchQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := &api.ExportChunk{Columns: make([]string, 0, len(columnTypes))}
+	for _, columnType := range columnTypes {
+		chunk.Columns = append(chunk.Columns, columnType.Name())
+	}
+	for rows.Next() {
+		entity, err := scanEntityValues(rows, columnTypes)
+		if err != nil {
+			return nil, err
+		}
+		chunk.Entities = append(chunk.Entities, entity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+// normalizeExportChunkSize подставляет размер фрагмента по умолчанию и ограничивает сверху
+func normalizeExportChunkSize(chunkSize int32) int32 {
+	switch {
+	case chunkSize <= 0:
+		return defaultExportChunkSize
+	case chunkSize > maxExportChunkSize:
+		return maxExportChunkSize
+	default:
+		return chunkSize
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"industrialregistrysystem/base/api"
+)
+
+func exportCommand(requestID string, window int32) *api.CommandRequest {
+	return &api.CommandRequest{
+		RequestId: requestID,
+		Command:   &api.CommandRequest_Export{Export: &api.ExportRequest{TableName: "organizations", Window: window}},
+	}
+}
+
+func exportCredit(requestID string, credit *api.ExportCredit) *api.CommandRequest {
+	return &api.CommandRequest{
+		RequestId: requestID,
+		Command:   &api.CommandRequest_ExportCredit{ExportCredit: credit},
+	}
+}
+
+// acquireAll забирает разрешения, пока они есть, и возвращает их число и ошибку, на которой остановился
+func acquireAll(flow *exportFlow) (int, error) {
+	for acquired := 0; ; acquired++ {
+		if err := flow.acquire(flow.ctx, 20*time.Millisecond); err != nil {
+			return acquired, err
+		}
+	}
+}
+
+// TestExportRouteCreditsBeforeStart команды приходят подряд, раньше, чем горутина
+// выгрузки успевает начать работу: ни окно, ни разрешения, ни отмена не теряются
+func TestExportRouteCreditsBeforeStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []*api.CommandRequest
+		acquired int
+		err      error
+	}{
+		{
+			name:     "window from the command",
+			commands: []*api.CommandRequest{exportCommand("export-1", 4)},
+			acquired: 4,
+			err:      errExportStalled,
+		},
+		{
+			name: "credits sent right after the command",
+			commands: []*api.CommandRequest{
+				exportCommand("export-1", 2),
+				exportCredit("export-1", &api.ExportCredit{Chunks: 1}),
+				exportCredit("export-1", &api.ExportCredit{Chunks: 3}),
+			},
+			acquired: 6,
+			err:      errExportStalled,
+		},
+		{
+			name:     "missing window still allows the first chunk",
+			commands: []*api.CommandRequest{exportCommand("export-1", 0)},
+			acquired: 1,
+			err:      errExportStalled,
+		},
+		{
+			name: "cancel sent right after the command",
+			commands: []*api.CommandRequest{
+				exportCommand("export-1", 4),
+				exportCredit("export-1", &api.ExportCredit{Cancel: true}),
+			},
+			acquired: 0,
+			err:      context.Canceled,
+		},
+		{
+			name: "credits for another export are ignored",
+			commands: []*api.CommandRequest{
+				exportCredit("export-0", &api.ExportCredit{Chunks: 5}),
+				exportCommand("export-1", 1),
+				exportCredit("export-2", &api.ExportCredit{Chunks: 5}),
+			},
+			acquired: 1,
+			err:      errExportStalled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := newExportRegistry()
+			for _, command := range test.commands {
+				handled := registry.route(command)
+				if wantHandled := command.GetExportCredit() != nil; handled != wantHandled {
+					t.Fatalf("route(%s) = %t, want %t", command.RequestId, handled, wantHandled)
+				}
+			}
+
+			flow, found := registry.lookup("export-1")
+			if !found {
+				t.Fatal("export-1 is not registered after its command was routed")
+			}
+			acquired, err := acquireAll(flow)
+			if acquired != test.acquired {
+				t.Errorf("acquired %d chunks, want %d", acquired, test.acquired)
+			}
+			if !errors.Is(err, test.err) {
+				t.Errorf("acquire error = %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+// TestExportCreditWakesWaitingExport разрешение, пришедшее во время ожидания, будит выгрузку
+func TestExportCreditWakesWaitingExport(t *testing.T) {
+	registry := newExportRegistry()
+	registry.route(exportCommand("export-1", 1))
+	flow, _ := registry.lookup("export-1")
+	if err := flow.acquire(flow.ctx, time.Second); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- flow.acquire(flow.ctx, 5*time.Second)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	registry.route(exportCredit("export-1", &api.ExportCredit{Chunks: 1}))
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatalf("acquire after credit: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("acquire did not wake up after credit")
+	}
+}
+
+// TestExportUnregister завершенная выгрузка освобождает контекст, а опоздавшие
+// разрешения и cancelAll ее уже не затрагивают
+func TestExportUnregister(t *testing.T) {
+	registry := newExportRegistry()
+	registry.route(exportCommand("export-1", 1))
+	registry.route(exportCommand("export-2", 1))
+	flow, _ := registry.lookup("export-1")
+
+	registry.unregister("export-1")
+	if flow.ctx.Err() == nil {
+		t.Error("context of an unregistered export is not cancelled")
+	}
+	if _, found := registry.lookup("export-1"); found {
+		t.Error("export-1 is still registered")
+	}
+	registry.route(exportCredit("export-1", &api.ExportCredit{Chunks: 1}))
+
+	other, _ := registry.lookup("export-2")
+	registry.cancelAll()
+	if !errors.Is(other.ctx.Err(), context.Canceled) {
+		t.Errorf("cancelAll left export-2 running: %v", other.ctx.Err())
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"industrialregistrysystem/base/api"
+)
+
+// Ограничения дерева условий: защищают PostgreSQL от чрезмерно сложных запросов
+const (
+	maxFilterDepth      = 16
+	maxFilterConditions = 100
+	maxFilterValues     = 1000 // Значений в одном IN
+	// Параметров во всем дереве. PostgreSQL принимает не больше 65535 параметров
+	// в запросе; остаток оставлен фильтрам по равенству и пагинации.
+	maxFilterArguments = 10000
+)
+
+// FilterError ошибка в структуре дерева условий. Передается как ErrorResponse
+// с кодом INVALID_ARGUMENT.
+type FilterError struct {
+	Reason string
+}
+
+func (filterError *FilterError) Error() string {
+	return "invalid filter: " + filterError.Reason
+}
+
+// filterBuilder переводит дерево api.Filter в условие WHERE с параметрами $N.
+// Колонки проверяются по схеме таблицы, значения передаются только параметрами.
+type filterBuilder struct {
+	table      *TableSchema
+	arguments  []interface{}
+	firstIndex int // Номер первого параметра условия в общем запросе
+	conditions int
+}
+
+// buildFilter возвращает SQL условие и его параметры, нумерация которых начинается с firstIndex
+func buildFilter(table *TableSchema, filter *api.Filter, firstIndex int) (string, []interface{}, error) {
+	builder := &filterBuilder{table: table, firstIndex: firstIndex}
+	condition, err := builder.build(filter, 1)
+	if err != nil {
+		return "", nil, err
+	}
+	return condition, builder.arguments, nil
+}
+
+func (builder *filterBuilder) build(filter *api.Filter, depth int) (string, error) {
+	if depth > maxFilterDepth {
+		return "", &FilterError{Reason: fmt.Sprintf("nesting is deeper than %d levels", maxFilterDepth)}
+	}
+
+	switch node := filter.GetNode().(type) {
+	case *api.Filter_Condition:
+		builder.conditions++
+		if builder.conditions > maxFilterConditions {
+			return "", &FilterError{Reason: fmt.Sprintf("more than %d conditions", maxFilterConditions)}
+		}
+		return builder.condition(node.Condition)
+	case *api.Filter_And:
+		return builder.group(node.And, " AND ", depth)
+	case *api.Filter_Or:
+		return builder.group(node.Or, " OR ", depth)
+	case *api.Filter_Not:
+		inner, err := builder.build(node.Not, depth+1)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + inner + ")", nil
+	default:
+		return "", &FilterError{Reason: "empty filter node"}
+	}
+}
+
+func (builder *filterBuilder) group(group *api.FilterGroup, separator string, depth int) (string, error) {
+	if len(group.GetFilters()) == 0 {
+		return "", &FilterError{Reason: "empty AND/OR group"}
+	}
+
+	parts := make([]string, 0, len(group.Filters))
+	for _, filter := range group.Filters {
+		part, err := builder.build(filter, depth+1)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, "("+part+")")
+	}
+	return strings.Join(parts, separator), nil
+}
+
+func (builder *filterBuilder) condition(condition *api.Condition) (string, error) {
+	columnName, err := builder.table.QuoteColumn(condition.Column)
+	if err != nil {
+		return "", err
+	}
+
+	// valueCount проверяет число значений условия
+	valueCount := func(minimum, maximum int) error {
+		count := len(condition.Values)
+		if count < minimum || count > maximum {
+			return &SchemaError{Table: builder.table.Name, Column: condition.Column,
+				Reason: fmt.Sprintf("%s expects %s, got %d", condition.Operator, valueCountText(minimum, maximum), count)}
+		}
+		return nil
+	}
+
+	switch condition.Operator {
+	case api.FilterOperator_EQ, api.FilterOperator_NE, api.FilterOperator_LT,
+		api.FilterOperator_LE, api.FilterOperator_GT, api.FilterOperator_GE:
+		if err := valueCount(1, 1); err != nil {
+			return "", err
+		}
+		parameter, err := builder.parameter(condition, condition.Values[0])
+		if err != nil {
+			return "", err
+		}
+		return columnName + " " + comparisonOperators[condition.Operator] + " " + parameter, nil
+
+	case api.FilterOperator_IN, api.FilterOperator_NOT_IN:
+		if err := valueCount(1, maxFilterValues); err != nil {
+			return "", err
+		}
+		parameters := make([]string, 0, len(condition.Values))
+		for _, value := range condition.Values {
+			parameter, err := builder.parameter(condition, value)
+			if err != nil {
+				return "", err
+			}
+			parameters = append(parameters, parameter)
+		}
+		operator := " IN ("
+		if condition.Operator == api.FilterOperator_NOT_IN {
+			operator = " NOT IN ("
+		}
+		return columnName + operator + strings.Join(parameters, ", ") + ")", nil
+
+	case api.FilterOperator_BETWEEN:
+		if err := valueCount(2, 2); err != nil {
+			return "", err
+		}
+		lower, err := builder.parameter(condition, condition.Values[0])
+		if err != nil {
+			return "", err
+		}
+		upper, err := builder.parameter(condition, condition.Values[1])
+		if err != nil {
+			return "", err
+		}
+		return columnName + " BETWEEN " + lower + " AND " + upper, nil
+
+	case api.FilterOperator_IS_NULL:
+		if err := valueCount(0, 0); err != nil {
+			return "", err
+		}
+		return columnName + " IS NULL", nil
+
+	case api.FilterOperator_IS_NOT_NULL:
+		if err := valueCount(0, 0); err != nil {
+			return "", err
+		}
+		return columnName + " IS NOT NULL", nil
+
+	case api.FilterOperator_PREFIX, api.FilterOperator_ILIKE:
+		if err := valueCount(1, 1); err != nil {
+			return "", err
+		}
+		pattern, isString := condition.Values[0].GetKind().(*api.Value_StringValue)
+		if !isString {
+			return "", &SchemaError{Table: builder.table.Name, Column: condition.Column,
+				Reason: fmt.Sprintf("%s expects a string value", condition.Operator)}
+		}
+		if condition.Operator == api.FilterOperator_PREFIX {
+			parameter, err := builder.addArgument(escapeLikePattern(pattern.StringValue) + "%")
+			if err != nil {
+				return "", err
+			}
+			return columnName + " LIKE " + parameter, nil
+		}
+		parameter, err := builder.addArgument(pattern.StringValue)
+		if err != nil {
+			return "", err
+		}
+		return columnName + " ILIKE " + parameter, nil
+
+	default:
+		return "", &SchemaError{Table: builder.table.Name, Column: condition.Column,
+			Reason: fmt.Sprintf("unsupported operator %s", condition.Operator)}
+	}
+}
+
+// comparisonOperators SQL операторы сравнения
+var comparisonOperators = map[api.FilterOperator]string{
+	api.FilterOperator_EQ: "=",
+	api.FilterOperator_NE: "<>",
+	api.FilterOperator_LT: "<",
+	api.FilterOperator_LE: "<=",
+	api.FilterOperator_GT: ">",
+	api.FilterOperator_GE: ">=",
+}
+
+// parameter добавляет значение условия параметром запроса. NULL в сравнениях
+// не допускается: для него есть IS_NULL.
+func (builder *filterBuilder) parameter(condition *api.Condition, value *api.Value) (string, error) {
+	if _, isNull := value.GetKind().(*api.Value_NullValue); isNull {
+		return "", &SchemaError{Table: builder.table.Name, Column: condition.Column,
+			Reason: fmt.Sprintf("%s does not accept NULL, use IS_NULL", condition.Operator)}
+	}
+
+	argument, err := valueArgument(value)
+	if err != nil {
+		return "", &SchemaError{Table: builder.table.Name, Column: condition.Column, Reason: "invalid value (" + err.Error() + ")"}
+	}
+	return builder.addArgument(argument)
+}
+
+// addArgument добавляет параметр запроса и возвращает его номер $N
+func (builder *filterBuilder) addArgument(argument interface{}) (string, error) {
+	if len(builder.arguments) >= maxFilterArguments {
+		return "", &FilterError{Reason: fmt.Sprintf("more than %d values in total", maxFilterArguments)}
+	}
+	builder.arguments = append(builder.arguments, argument)
+	return fmt.Sprintf("$%d", builder.firstIndex+len(builder.arguments)-1), nil
+}
+
+// escapeLikePattern экранирует спецсимволы LIKE, чтобы значение сравнивалось буквально
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func valueCountText(minimum, maximum int) string {
+	switch {
+	case minimum == maximum && minimum == 0:
+		return "no values"
+	case minimum == maximum && minimum == 1:
+		return "1 value"
+	case minimum == maximum:
+		return fmt.Sprintf("%d values", minimum)
+	default:
+		return fmt.Sprintf("%d to %d values", minimum, maximum)
+	}
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"industrialregistrysystem/base/api"
+)
+
+// newTestTable схема таблицы public.<name> с заданными колонками без обращения к БД
+func newTestTable(name string, columnNames ...string) *TableSchema {
+	table := &TableSchema{
+		Name:       name,
+		quotedName: pq.QuoteIdentifier("public") + "." + pq.QuoteIdentifier(name),
+		columns:    make(map[string]ColumnSchema, len(columnNames)),
+	}
+	for _, columnName := range columnNames {
+		column := ColumnSchema{Name: columnName, DataType: "text", Nullable: true}
+		table.Columns = append(table.Columns, column)
+		table.columns[columnName] = column
+	}
+	return table
+}
+
+func conditionFilter(column string, operator api.FilterOperator, values ...*api.Value) *api.Filter {
+	return &api.Filter{Node: &api.Filter_Condition{Condition: &api.Condition{Column: column, Operator: operator, Values: values}}}
+}
+
+func and(filters ...*api.Filter) *api.Filter {
+	return &api.Filter{Node: &api.Filter_And{And: &api.FilterGroup{Filters: filters}}}
+}
+
+func or(filters ...*api.Filter) *api.Filter {
+	return &api.Filter{Node: &api.Filter_Or{Or: &api.FilterGroup{Filters: filters}}}
+}
+
+func not(filter *api.Filter) *api.Filter {
+	return &api.Filter{Node: &api.Filter_Not{Not: filter}}
+}
+
+func intValue(value int64) *api.Value {
+	return &api.Value{Kind: &api.Value_Int64Value{Int64Value: value}}
+}
+
+func stringValue(value string) *api.Value {
+	return &api.Value{Kind: &api.Value_StringValue{StringValue: value}}
+}
+
+// intValues count значений int64 подряд, начиная с 1
+func intValues(count int) []*api.Value {
+	values := make([]*api.Value, count)
+	for i := range values {
+		values[i] = intValue(int64(i + 1))
+	}
+	return values
+}
+
+// nestedNot дерево из depth узлов: depth-1 отрицаний вокруг одного условия
+func nestedNot(depth int) *api.Filter {
+	filter := conditionFilter("a", api.FilterOperator_IS_NULL)
+	for i := 1; i < depth; i++ {
+		filter = not(filter)
+	}
+	return filter
+}
+
+// conditions count условий, объединенных через AND
+func conditions(count int) *api.Filter {
+	filters := make([]*api.Filter, count)
+	for i := range filters {
+		filters[i] = conditionFilter("a", api.FilterOperator_IS_NOT_NULL)
+	}
+	return and(filters...)
+}
+
+// inGroups count условий IN по valuesEach значений, объединенных через OR
+func inGroups(count int, valuesEach int) *api.Filter {
+	filters := make([]*api.Filter, count)
+	for i := range filters {
+		filters[i] = conditionFilter("a", api.FilterOperator_IN, intValues(valuesEach)...)
+	}
+	return or(filters...)
+}
+
+func TestBuildFilter(t *testing.T) {
+	table := newTestTable("organizations", "a", "b", "name")
+
+	tests := []struct {
+		name       string
+		filter     *api.Filter
+		firstIndex int
+		condition  string
+		arguments  []interface{}
+	}{
+		{
+			name:       "comparison",
+			filter:     conditionFilter("a", api.FilterOperator_GE, intValue(10)),
+			firstIndex: 1,
+			condition:  `"a" >= $1`,
+			arguments:  []interface{}{int64(10)},
+		},
+		{
+			name: "NOT, AND and OR keep the tree shape",
+			filter: or(
+				and(not(conditionFilter("a", api.FilterOperator_EQ, intValue(1))), conditionFilter("b", api.FilterOperator_IS_NULL)),
+				conditionFilter("name", api.FilterOperator_NE, stringValue("x")),
+			),
+			firstIndex: 1,
+			condition:  `((NOT ("a" = $1)) AND ("b" IS NULL)) OR ("name" <> $2)`,
+			arguments:  []interface{}{int64(1), "x"},
+		},
+		{
+			name:       "BETWEEN inside AND numbers both bounds",
+			filter:     and(conditionFilter("a", api.FilterOperator_BETWEEN, intValue(1), intValue(5)), conditionFilter("b", api.FilterOperator_EQ, intValue(7))),
+			firstIndex: 1,
+			condition:  `("a" BETWEEN $1 AND $2) AND ("b" = $3)`,
+			arguments:  []interface{}{int64(1), int64(5), int64(7)},
+		},
+		{
+			name:       "numbering starts at firstIndex",
+			filter:     conditionFilter("a", api.FilterOperator_NOT_IN, intValue(1), intValue(2), intValue(3)),
+			firstIndex: 4,
+			condition:  `"a" NOT IN ($4, $5, $6)`,
+			arguments:  []interface{}{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:       "PREFIX escapes LIKE wildcards",
+			filter:     conditionFilter("name", api.FilterOperator_PREFIX, stringValue(`50%_off\`)),
+			firstIndex: 1,
+			condition:  `"name" LIKE $1`,
+			arguments:  []interface{}{`50\%\_off\\%`},
+		},
+		{
+			name:       "ILIKE passes the pattern as is",
+			filter:     conditionFilter("name", api.FilterOperator_ILIKE, stringValue("%завод%")),
+			firstIndex: 1,
+			condition:  `"name" ILIKE $1`,
+			arguments:  []interface{}{"%завод%"},
+		},
+		{
+			name:       "quotes in values stay in parameters",
+			filter:     conditionFilter("name", api.FilterOperator_EQ, stringValue("O'Brien")),
+			firstIndex: 1,
+			condition:  `"name" = $1`,
+			arguments:  []interface{}{"O'Brien"},
+		},
+		{
+			name:       "decimal beyond int64 is passed as text",
+			filter:     conditionFilter("a", api.FilterOperator_GT, &api.Value{Kind: &api.Value_DecimalValue{DecimalValue: "9223372036854775808"}}),
+			firstIndex: 1,
+			condition:  `"a" > $1`,
+			arguments:  []interface{}{"9223372036854775808"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			condition, arguments, err := buildFilter(table, test.filter, test.firstIndex)
+			if err != nil {
+				t.Fatalf("buildFilter error: %v", err)
+			}
+			if condition != test.condition {
+				t.Errorf("condition = %s, want %s", condition, test.condition)
+			}
+			if !reflect.DeepEqual(arguments, test.arguments) {
+				t.Errorf("arguments = %#v, want %#v", arguments, test.arguments)
+			}
+		})
+	}
+}
+
+func TestBuildFilterLimits(t *testing.T) {
+	table := newTestTable("organizations", "a")
+
+	tests := []struct {
+		name   string
+		filter *api.Filter
+		reason string // Пусто, если дерево допустимо
+	}{
+		{name: "depth at the limit", filter: nestedNot(maxFilterDepth)},
+		{name: "depth over the limit", filter: nestedNot(maxFilterDepth + 1), reason: "nesting is deeper than 16 levels"},
+		{name: "conditions at the limit", filter: conditions(maxFilterConditions)},
+		{name: "conditions over the limit", filter: conditions(maxFilterConditions + 1), reason: "more than 100 conditions"},
+		{name: "arguments at the limit", filter: inGroups(maxFilterArguments/maxFilterValues, maxFilterValues)},
+		{
+			name:   "arguments over the limit",
+			filter: or(inGroups(maxFilterArguments/maxFilterValues, maxFilterValues), conditionFilter("a", api.FilterOperator_EQ, intValue(1))),
+			reason: "more than 10000 values in total",
+		},
+		{
+			name:   "conditions and IN values at their limits exceed the parameter cap",
+			filter: inGroups(maxFilterConditions, maxFilterValues),
+			reason: "more than 10000 values in total",
+		},
+		{name: "empty group", filter: and(), reason: "empty AND/OR group"},
+		{name: "empty node", filter: &api.Filter{}, reason: "empty filter node"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, arguments, err := buildFilter(table, test.filter, 1)
+			if test.reason == "" {
+				if err != nil {
+					t.Fatalf("buildFilter error: %v", err)
+				}
+				if len(arguments) > maxFilterArguments {
+					t.Errorf("%d arguments over the limit %d", len(arguments), maxFilterArguments)
+				}
+				return
+			}
+
+			var filterError *FilterError
+			if !errors.As(err, &filterError) {
+				t.Fatalf("buildFilter error = %v, want FilterError", err)
+			}
+			if filterError.Reason != test.reason {
+				t.Errorf("reason = %q, want %q", filterError.Reason, test.reason)
+			}
+			if code := errorCode(err); code != "INVALID_ARGUMENT" {
+				t.Errorf("errorCode = %s, want INVALID_ARGUMENT", code)
+			}
+		})
+	}
+}
+
+func TestBuildFilterSchemaErrors(t *testing.T) {
+	table := newTestTable("organizations", "a")
+
+	tests := []struct {
+		name   string
+		filter *api.Filter
+		reason string
+	}{
+		{name: "unknown column", filter: conditionFilter("missing", api.FilterOperator_IS_NULL), reason: "unknown column"},
+		{name: "NULL in comparison", filter: conditionFilter("a", api.FilterOperator_EQ, &api.Value{Kind: &api.Value_NullValue{}}), reason: "EQ does not accept NULL, use IS_NULL"},
+		{name: "BETWEEN with one bound", filter: conditionFilter("a", api.FilterOperator_BETWEEN, intValue(1)), reason: "BETWEEN expects 2 values, got 1"},
+		{name: "IN over the value limit", filter: conditionFilter("a", api.FilterOperator_IN, intValues(maxFilterValues+1)...), reason: "IN expects 1 to 1000 values, got 1001"},
+		{name: "PREFIX with a number", filter: conditionFilter("a", api.FilterOperator_PREFIX, intValue(1)), reason: "PREFIX expects a string value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := buildFilter(table, test.filter, 1)
+			var schemaError *SchemaError
+			if !errors.As(err, &schemaError) {
+				t.Fatalf("buildFilter error = %v, want SchemaError", err)
+			}
+			if schemaError.Reason != test.reason {
+				t.Errorf("reason = %q, want %q", schemaError.Reason, test.reason)
+			}
+		})
+	}
+}
+
+func TestBuildListCondition(t *testing.T) {
+	table := newTestTable("organizations", "a", "b", "district", "status")
+
+	tests := []struct {
+		name      string
+		filters   map[string]string
+		filter    *api.Filter
+		condition string
+		arguments []interface{}
+	}{
+		{
+			name:      "no filters",
+			condition: "destroyed = false",
+			arguments: []interface{}{},
+		},
+		{
+			name:      "equality filter only",
+			filters:   map[string]string{"district": "Северный"},
+			condition: `destroyed = false AND ("district" = $1)`,
+			arguments: []interface{}{"Северный"},
+		},
+		{
+			name:      "filter tree only starts at $1",
+			filter:    conditionFilter("a", api.FilterOperator_BETWEEN, intValue(1), intValue(2)),
+			condition: `destroyed = false AND ("a" BETWEEN $1 AND $2)`,
+			arguments: []interface{}{int64(1), int64(2)},
+		},
+		{
+			name:      "filter tree continues numbering after equality filters",
+			filters:   map[string]string{"district": "Северный"},
+			filter:    or(conditionFilter("a", api.FilterOperator_LT, intValue(5)), conditionFilter("b", api.FilterOperator_IN, intValue(1), intValue(2))),
+			condition: `destroyed = false AND ("district" = $1) AND (("a" < $2) OR ("b" IN ($3, $4)))`,
+			arguments: []interface{}{"Северный", int64(5), int64(1), int64(2)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			condition, arguments, err := buildListCondition(table, test.filters, test.filter)
+			if err != nil {
+				t.Fatalf("buildListCondition error: %v", err)
+			}
+			if condition != test.condition {
+				t.Errorf("condition = %s, want %s", condition, test.condition)
+			}
+			if !reflect.DeepEqual(arguments, test.arguments) {
+				t.Errorf("arguments = %#v, want %#v", arguments, test.arguments)
+			}
+		})
+	}
+
+	// Порядок фильтров по равенству не определен, но дерево условий всегда нумеруется после них
+	t.Run("several equality filters", func(t *testing.T) {
+		filters := map[string]string{"district": "Северный", "status": "active"}
+		condition, arguments, err := buildListCondition(table, filters, conditionFilter("a", api.FilterOperator_EQ, intValue(1)))
+		if err != nil {
+			t.Fatalf("buildListCondition error: %v", err)
+		}
+		if !strings.HasSuffix(condition, ` AND ("a" = $3)`) {
+			t.Errorf("condition = %s, want the filter tree to use $3", condition)
+		}
+		if len(arguments) != 3 || arguments[2] != int64(1) {
+			t.Errorf("arguments = %#v, want the filter tree value last", arguments)
+		}
+	})
+}
@@ -4,25 +4,39 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"sync"
 	"time"
 
+	"github.com/lib/pq"
 	"google.golang.org/grpc/credentials"
 	"industrialregistrysystem/base/api"
+	"industrialregistrysystem/base/config"
+	"industrialregistrysystem/base/tlsreload"
 )
 
+// certificateReloader создается один раз: credentials пересоздаются при каждом переподключении,
+// а наблюдение за файлами сертификата должно быть единственным
+var certificateReloader *tlsreload.CertificateReloader
+
 // loadTLSCredentialsClient загружает TLS сертификаты для клиента
-func loadTLSCredentialsClient() (credentials.TransportCredentials, error) {
-	// Загружаем клиентский сертификат
-	clientCertificate, err := tls.LoadX509KeyPair("certs/database/database-fullchain.crt", "certs/database/database.key")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load client certificates: %v", err)
+func loadTLSCredentialsClient(files config.TLSFiles) (credentials.TransportCredentials, error) {
+	// Загружаем клиентский сертификат; при обновлении файлов он перечитывается без перезапуска
+	if certificateReloader == nil {
+		reloader, err := tlsreload.NewCertificateReloader(files.Certificate, files.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificates: %v", err)
+		}
+		certificateReloader = reloader
+		go certificateReloader.Watch(files.ReloadInterval)
 	}
 
 	// Загружаем CA сертификат
-	caCertificate, err := os.ReadFile("certs/ca/ca.crt")
+	caCertificate, err := os.ReadFile(files.CA)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read CA certificate: %v", err)
 	}
@@ -34,18 +48,86 @@ func loadTLSCredentialsClient() (credentials.TransportCredentials, error) {
 
 	// Настраиваем TLS конфигурацию
 	configuration := &tls.Config{
-		Certificates: []tls.Certificate{clientCertificate},
-		RootCAs:      certificatePool,
-		ServerName:   "mainservice", // Должен совпадать с CN сертификата сервера
-		MinVersion:   tls.VersionTLS12,
+		// Сертификат запрашивается при каждом рукопожатии: переподключения используют актуальный
+		GetClientCertificate: certificateReloader.GetClientCertificate,
+		RootCAs:              certificatePool,
+		ServerName:           files.ServerName, // Должен совпадать с DNS Name сертификата сервера
+		MinVersion:           tls.VersionTLS12,
 	}
 
 	return credentials.NewTLS(configuration), nil
 }
 
+// lockedCommandStream сериализует отправку ответов: gRPC поток не допускает параллельных Send
+type lockedCommandStream struct {
+	api.DatabaseService_CommandStreamClient
+	sendMutex sync.Mutex
+}
+
+func (stream *lockedCommandStream) Send(response *api.CommandResponse) error {
+	stream.sendMutex.Lock()
+	defer stream.sendMutex.Unlock()
+	return stream.DatabaseService_CommandStreamClient.Send(response)
+}
+
+// CloseSend нельзя вызывать параллельно с Send
+func (stream *lockedCommandStream) CloseSend() error {
+	stream.sendMutex.Lock()
+	defer stream.sendMutex.Unlock()
+	return stream.DatabaseService_CommandStreamClient.CloseSend()
+}
+
+// InvalidArgumentError ошибка в параметрах команды, не связанная со схемой и фильтрами.
+// Передается как ErrorResponse с кодом INVALID_ARGUMENT.
+type InvalidArgumentError struct {
+	Reason string
+}
+
+func (argumentError *InvalidArgumentError) Error() string {
+	return argumentError.Reason
+}
+
+// errorCode сопоставляет ошибку выполнения команды с кодом ErrorResponse
+func errorCode(err error) string {
+	var postgresError *pq.Error
+	var schemaError *SchemaError
+	var filterError *FilterError
+	var argumentError *InvalidArgumentError
+	switch {
+	case errors.As(err, &schemaError), errors.As(err, &filterError), errors.As(err, &argumentError),
+		errors.Is(err, errInvalidPageToken):
+		return "INVALID_ARGUMENT"
+	case errors.Is(err, sql.ErrNoRows):
+		return "NOT_FOUND"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "DEADLINE_EXCEEDED"
+	case errors.As(err, &postgresError):
+		switch postgresError.Code.Name() {
+		case "unique_violation":
+			return "ALREADY_EXISTS"
+		case "foreign_key_violation", "not_null_violation", "check_violation":
+			return "FAILED_PRECONDITION"
+		case "undefined_table", "undefined_column", "invalid_text_representation":
+			return "INVALID_ARGUMENT"
+		}
+		return "INTERNAL"
+	default:
+		return "INTERNAL"
+	}
+}
+
+// errorDetails дополняет ErrorResponse: для ошибок схемы указывает таблицу и колонку
+func errorDetails(err error) string {
+	var schemaError *SchemaError
+	if errors.As(err, &schemaError) {
+		return schemaError.Details()
+	}
+	return ""
+}
+
 // handleServerCommand обрабатывает команды от сервера
 func handleServerCommand(dataService *DataService, stream api.DatabaseService_CommandStreamClient, command *api.CommandRequest) {
-	contextWithTimeout, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	contextWithTimeout, cancel := context.WithTimeout(context.Background(), dataService.commandTimeout)
 	defer cancel()
 	
 	var response *api.CommandResponse
@@ -61,6 +143,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -81,6 +165,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -101,6 +187,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -121,6 +209,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -141,6 +231,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -161,6 +253,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -181,6 +275,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -201,6 +297,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -222,6 +320,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -242,6 +342,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -262,14 +364,16 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
 		} else {
 			response = &api.CommandResponse{
 				RequestId: command.RequestId,
-				Response: &api.CommandResponse_User{
-					User: result,
+				Response: &api.CommandResponse_Invite{
+					Invite: result,
 				},
 			}
 		}
@@ -282,6 +386,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -302,6 +408,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -314,6 +422,94 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 			}
 		}
 
+	case *api.CommandRequest_SearchOrganizations:
+		result, err := dataService.SearchOrganizations(contextWithTimeout, cmd.SearchOrganizations)
+		if err != nil {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Error{
+					Error: &api.ErrorResponse{
+						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
+					},
+				},
+			}
+		} else {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Organizations{
+					Organizations: result,
+				},
+			}
+		}
+
+	case *api.CommandRequest_GetUser:
+		result, err := dataService.GetUser(contextWithTimeout, cmd.GetUser)
+		if err != nil {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Error{
+					Error: &api.ErrorResponse{
+						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
+					},
+				},
+			}
+		} else {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_User{
+					User: result,
+				},
+			}
+		}
+
+	case *api.CommandRequest_CreateUser:
+		result, err := dataService.CreateUser(contextWithTimeout, cmd.CreateUser)
+		if err != nil {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Error{
+					Error: &api.ErrorResponse{
+						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
+					},
+				},
+			}
+		} else {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_User{
+					User: result,
+				},
+			}
+		}
+
+	case *api.CommandRequest_UpdateUser:
+		result, err := dataService.UpdateUser(contextWithTimeout, cmd.UpdateUser)
+		if err != nil {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Error{
+					Error: &api.ErrorResponse{
+						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
+					},
+				},
+			}
+		} else {
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_User{
+					User: result,
+				},
+			}
+		}
+
 	case *api.CommandRequest_CreateInvite:
 		result, err := dataService.CreateInvite(contextWithTimeout, cmd.CreateInvite)
 		if err != nil {
@@ -322,6 +518,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -342,6 +540,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -362,6 +562,8 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: err.Error(),
+						Code:    errorCode(err),
+						Details: errorDetails(err),
 					},
 				},
 			}
@@ -374,6 +576,29 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 			}
 		}
 		
+	// Потоковые команды
+	case *api.CommandRequest_Export:
+		// Фрагменты выгрузки отправляются по мере разрешений mainservice; отдельный ответ нужен только при ошибке
+		err := dataService.Export(command.RequestId, cmd.Export, stream)
+		if err == nil {
+			return
+		}
+		if errors.Is(err, context.Canceled) {
+			// mainservice отменил выгрузку или поток закрыт: ответ уже никто не ждет
+			log.Printf("🚫 Export %s stopped: %v", command.RequestId, err)
+			return
+		}
+		response = &api.CommandResponse{
+			RequestId: command.RequestId,
+			Response: &api.CommandResponse_Error{
+				Error: &api.ErrorResponse{
+					Message: err.Error(),
+					Code:    errorCode(err),
+					Details: errorDetails(err),
+				},
+			},
+		}
+		
 	// Системные команды
 	case *api.CommandRequest_SystemCommand:
 		systemCommand := cmd.SystemCommand
@@ -391,23 +616,48 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 				},
 			}
 		case "health_check":
-			// Проверка здоровья (заглушка)
+			// Проверка здоровья: пинг PostgreSQL и состояние пула соединений
 			response = &api.CommandResponse{
 				RequestId: command.RequestId,
 				Response: &api.CommandResponse_System{
-					System: &api.SystemResponse{
-						Success: true,
-						Message: "Service is healthy",
-						Data:    make(map[string]string),
-					},
+					System: dataService.HealthCheck(contextWithTimeout),
 				},
 			}
+		case schemaCommand:
+			// Текущая схема: таблицы и колонки, доступные универсальным командам
+			response = &api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Schema{
+					Schema: dataService.schema.Snapshot(),
+				},
+			}
+		case schemaRefreshCommand:
+			// Перечитываем information_schema после миграций
+			if err := dataService.schema.Refresh(contextWithTimeout); err != nil {
+				response = &api.CommandResponse{
+					RequestId: command.RequestId,
+					Response: &api.CommandResponse_Error{
+						Error: &api.ErrorResponse{
+							Message: err.Error(),
+							Code:    errorCode(err),
+						},
+					},
+				}
+			} else {
+				response = &api.CommandResponse{
+					RequestId: command.RequestId,
+					Response: &api.CommandResponse_Schema{
+						Schema: dataService.schema.Snapshot(),
+					},
+				}
+			}
 		default:
 			response = &api.CommandResponse{
 				RequestId: command.RequestId,
 				Response: &api.CommandResponse_Error{
 					Error: &api.ErrorResponse{
 						Message: "Unsupported system command: " + systemCommand,
+						Code:    "UNIMPLEMENTED",
 					},
 				},
 			}
@@ -419,6 +669,7 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 			Response: &api.CommandResponse_Error{
 				Error: &api.ErrorResponse{
 					Message: "Unsupported command type",
+					Code:    "UNIMPLEMENTED",
 				},
 			},
 		}
@@ -430,4 +681,69 @@ func handleServerCommand(dataService *DataService, stream api.DatabaseService_Co
 	} else {
 		log.Printf("✅ Successfully processed request %s", command.RequestId)
 	}
-}
\ No newline at end of file
+}
+
+// shutdownCommand системная команда mainservice: завершить выполняемые команды и закрыть поток
+const shutdownCommand = "shutdown"
+
+// acknowledgeShutdown подтверждает mainservice получение команды shutdown
+func acknowledgeShutdown(stream *lockedCommandStream, command *api.CommandRequest) {
+	err := stream.Send(&api.CommandResponse{
+		RequestId: command.RequestId,
+		Response: &api.CommandResponse_System{
+			System: &api.SystemResponse{
+				Success: true,
+				Message: "Finishing in-flight commands before closing the stream",
+				Data:    make(map[string]string),
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to acknowledge shutdown: %v", err)
+	}
+}
+
+// drainCommandStream дожидается выполняемых команд не дольше timeout, отклоняя новые
+// с кодом UNAVAILABLE (mainservice повторит их на другой БД), и закрывает поток.
+// Разрешения для начатых выгрузок по-прежнему принимаются.
+func drainCommandStream(stream *lockedCommandStream, commands <-chan *api.CommandRequest,
+	inFlight *sync.WaitGroup, exports *exportRegistry, timeout time.Duration) {
+	finished := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(finished)
+	}()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-finished:
+			log.Println("✅ All in-flight commands finished, closing command stream")
+			stream.CloseSend()
+			return
+		case <-deadline.C:
+			log.Printf("⚠️ In-flight commands did not finish within %s, closing command stream", timeout)
+			stream.CloseSend()
+			return
+		case command := <-commands:
+			if credit := command.GetExportCredit(); credit != nil {
+				exports.handleCredit(command.RequestId, credit)
+				continue
+			}
+			err := stream.Send(&api.CommandResponse{
+				RequestId: command.RequestId,
+				Response: &api.CommandResponse_Error{
+					Error: &api.ErrorResponse{
+						Message: "Database worker is shutting down",
+						Code:    "UNAVAILABLE",
+					},
+				},
+			})
+			if err != nil {
+				log.Printf("Failed to reject request %s: %v", command.RequestId, err)
+			}
+		}
+	}
+}
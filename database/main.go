@@ -2,69 +2,121 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"industrialregistrysystem/base/api"
+	"industrialregistrysystem/base/config"
 )
 
+// keepaliveParameters HTTP/2 пинги обнаруживают оборванное соединение с mainservice,
+// даже когда по потоку команд ничего не передается
+var keepaliveParameters = keepalive.ClientParameters{
+	Time:                30 * time.Second,
+	Timeout:             10 * time.Second,
+	PermitWithoutStream: true,
+}
+
 func main() {
+	// Загружаем настройки: файл, переменные окружения, флаги
+	configuration := defaultConfig()
+	printConfig, err := config.Load("database", configuration, os.Args[1:])
+	if err != nil {
+		log.Fatalf("❌ Failed to load configuration: %v", err)
+	}
+	if printConfig {
+		if err := config.Print(os.Stdout, configuration); err != nil {
+			log.Fatalf("❌ Failed to print configuration: %v", err)
+		}
+		return
+	}
+
 	// Data Service - активный клиент, готовый обрабатывать запросы
-	dataService := NewDataService()
+	dataService := NewDataService(configuration)
+
+	// ID экземпляра отличает этот воркер от других, использующих тот же сертификат
+	instanceID := configuration.InstanceID
+	if instanceID == "" {
+		instanceID = newInstanceID()
+	}
+	log.Printf("🆔 Database worker instance ID: %s", instanceID)
 	
-	// Бесконечный цикл для переподключения
-	for {
-		err := connectAndServe(dataService)
-		if err != nil {
-			log.Printf("Connection failed: %v. Reconnecting in 5 seconds...", err)
-			time.Sleep(5 * time.Second)
+	// По SIGINT/SIGTERM воркер дожидается выполняемых команд и закрывает поток
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Цикл переподключения до остановки
+	for ctx.Err() == nil {
+		err := connectAndServe(ctx, configuration, dataService, instanceID)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Connection failed: %v. Reconnecting in %s...", err, configuration.ReconnectDelay)
+			select {
+			case <-time.After(configuration.ReconnectDelay):
+			case <-ctx.Done():
+			}
 		}
 	}
+
+	dataService.Close()
+	log.Println("👋 Database worker stopped")
 }
 
-func connectAndServe(dataService *DataService) error {
-	// Подключаемся к gRPC серверу на localhost:5051 с TLS
-	tlsCredentials, err := loadTLSCredentialsClient()
+func connectAndServe(ctx context.Context, configuration *Config, dataService *DataService, instanceID string) error {
+	// Подключаемся к gRPC серверу mainservice с TLS
+	tlsCredentials, err := loadTLSCredentialsClient(configuration.TLS)
 	if err != nil {
 		log.Printf("❌ Failed to load TLS credentials, using insecure: %v", err)
 		// Fallback to insecure connection
-		connection, err := grpc.Dial("localhost:5051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+		connection, err := grpc.Dial(configuration.MainServiceAddress, grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithKeepaliveParams(keepaliveParameters))
 		if err != nil {
 			return err
 		}
 		defer connection.Close()
 		
-		return serveWithConnection(dataService, connection)
+		return serveWithConnection(ctx, configuration, dataService, connection, instanceID)
 	}
 
 	// Используем TLS соединение
-	connection, err := grpc.Dial("localhost:5051", grpc.WithTransportCredentials(tlsCredentials))
+	connection, err := grpc.Dial(configuration.MainServiceAddress, grpc.WithTransportCredentials(tlsCredentials),
+		grpc.WithKeepaliveParams(keepaliveParameters))
 	if err != nil {
 		return err
 	}
 	defer connection.Close()
 	
-	return serveWithConnection(dataService, connection)
+	return serveWithConnection(ctx, configuration, dataService, connection, instanceID)
 }
 
-func serveWithConnection(dataService *DataService, connection *grpc.ClientConn) error {
+// serveWithConnection обрабатывает команды mainservice, пока поток открыт. Когда ctx отменен или
+// mainservice прислал shutdown, новые команды отклоняются, а выполняемые завершаются до закрытия потока.
+func serveWithConnection(ctx context.Context, configuration *Config, dataService *DataService, connection *grpc.ClientConn, instanceID string) error {
 	// Создаем gRPC клиент
 	client := api.NewDatabaseServiceClient(connection)
 	
 	log.Println("📊 Data Service (Active gRPC Client) started - ready to handle DB requests")
 	log.Println("   Connected to PostgreSQL database")
-	log.Println("   Connected to gRPC server on localhost:5051")
+	log.Printf("   Connected to gRPC server on %s", configuration.MainServiceAddress)
 	log.Println("   Establishing command channel...")
 	
-	// Устанавливаем streaming соединение
-	ctx := context.Background()
-	stream, err := client.CommandStream(ctx)
+	// Устанавливаем streaming соединение. Контекст потока не связан с ctx:
+	// при остановке выполняемые команды должны успеть отправить ответы
+	commandStream, err := client.CommandStream(context.Background())
 	if err != nil {
 		return err
 	}
+	stream := &lockedCommandStream{DatabaseService_CommandStreamClient: commandStream}
 	
 	// Отправляем initial ready message
 	err = stream.Send(&api.CommandResponse{
@@ -72,6 +124,7 @@ func serveWithConnection(dataService *DataService, connection *grpc.ClientConn)
 		Response: &api.CommandResponse_Ready{
 			Ready: &api.ReadyMessage{
 				ServiceName: "database-service",
+				InstanceId:  instanceID,
 			},
 		},
 	})
@@ -81,18 +134,79 @@ func serveWithConnection(dataService *DataService, connection *grpc.ClientConn)
 	
 	log.Println("✅ Command channel established - waiting for server commands...")
 	
+	// Команды принимаются в отдельной горутине, чтобы остановка не ждала следующей команды
+	commands := make(chan *api.CommandRequest)
+	receiveErrors := make(chan error, 1)
+	stopReceiving := make(chan struct{})
+	defer close(stopReceiving)
+	go func() {
+		for {
+			command, err := stream.Recv()
+			if err != nil {
+				receiveErrors <- err
+				return
+			}
+			select {
+			case commands <- command:
+			case <-stopReceiving:
+				return
+			}
+		}
+	}()
+
+	// Выгрузки не переживают поток команд: их фрагменты некуда отправлять
+	defer dataService.exports.cancelAll()
+
 	// Обрабатываем входящие команды от сервера
+	var inFlight sync.WaitGroup
 	for {
-		command, err := stream.Recv()
-		if err == io.EOF {
-			log.Println("Server closed connection")
-			return nil
-		}
-		if err != nil {
+		select {
+		case command := <-commands:
+			// Выгрузки регистрируются и получают разрешения сразу, не дожидаясь отдельной горутины
+			if dataService.exports.route(command) {
+				continue
+			}
+
+			if command.GetSystemCommand() == shutdownCommand {
+				log.Println("🛑 mainservice is shutting down, finishing in-flight commands")
+				acknowledgeShutdown(stream, command)
+				drainCommandStream(stream, commands, &inFlight, dataService.exports, configuration.ShutdownTimeout)
+				return nil
+			}
+
+			// Обрабатываем команду в горутине чтобы не блокировать получение новых команд
+			inFlight.Add(1)
+			go func() {
+				defer inFlight.Done()
+				handleServerCommand(dataService, stream, command)
+			}()
+
+		case err := <-receiveErrors:
+			if err == io.EOF {
+				log.Println("Server closed connection")
+				return nil
+			}
 			return err
+
+		case <-ctx.Done():
+			log.Println("🛑 Shutting down, finishing in-flight commands")
+			drainCommandStream(stream, commands, &inFlight, dataService.exports, configuration.ShutdownTimeout)
+			return nil
 		}
-		
-		// Обрабатываем команду в горутине чтобы не блокировать получение новых команд
-		go handleServerCommand(dataService, stream, command)
 	}
-}
\ No newline at end of file
+}
+
+// newInstanceID генерирует уникальный ID экземпляра воркера: хост, PID и случайный суффикс
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano())
+	}
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
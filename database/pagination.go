@@ -0,0 +1,148 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"industrialregistrysystem/base/api"
+)
+
+// Размер страницы List и ListOrganizations
+const (
+	defaultPageSize = 20
+	maxPageSize     = 1000
+)
+
+// errInvalidPageToken токен страницы поврежден или выдан для другого запроса
+var errInvalidPageToken = errors.New("invalid page token")
+
+// pageToken содержимое непрозрачного токена страницы: ключ сортировки и id
+// последней строки предыдущей страницы
+type pageToken struct {
+	Query string `json:"q"`           // Отпечаток запроса: таблица, сортировка и фильтры
+	Key   []byte `json:"k,omitempty"` // api.Value ключа сортировки; пусто при сортировке только по id
+	ID    int64  `json:"i"`
+}
+
+// keyset сортировка страницы по ключу и id. id делает порядок строк однозначным,
+// поэтому вставки между запросами страниц не дают повторов и пропусков.
+type keyset struct {
+	keyExpression string // SQL выражение ключа сортировки; пусто - только по id
+	descending    bool
+}
+
+// orderBy возвращает выражение ORDER BY
+func (page keyset) orderBy() string {
+	direction := ""
+	if page.descending {
+		direction = " DESC"
+	}
+	if page.keyExpression == "" {
+		return "id" + direction
+	}
+	return page.keyExpression + direction + ", id" + direction
+}
+
+// after возвращает условие на строки после строки из токена. NULL при сортировке
+// по возрастанию идут последними, по убыванию - первыми, как в PostgreSQL по умолчанию.
+func (page keyset) after(token *pageToken, firstIndex int) (string, []interface{}, error) {
+	idOperator := ">"
+	if page.descending {
+		idOperator = "<"
+	}
+	idParameter := fmt.Sprintf("$%d", firstIndex)
+
+	if page.keyExpression == "" {
+		return "id " + idOperator + " " + idParameter, []interface{}{token.ID}, nil
+	}
+
+	var key api.Value
+	if err := proto.Unmarshal(token.Key, &key); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", errInvalidPageToken, err)
+	}
+	keyExpression := page.keyExpression
+
+	// Пустой ключ в токене - тоже NULL: так его кодирует ListOrganizations для пустого названия
+	if _, isNull := key.GetKind().(*api.Value_NullValue); isNull || key.GetKind() == nil {
+		condition := "(" + keyExpression + " IS NULL AND id " + idOperator + " " + idParameter + ")"
+		if page.descending {
+			condition = "(" + condition + " OR " + keyExpression + " IS NOT NULL)"
+		}
+		return condition, []interface{}{token.ID}, nil
+	}
+
+	keyArgument, err := valueArgument(&key)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", errInvalidPageToken, err)
+	}
+	keyParameter := fmt.Sprintf("$%d", firstIndex+1)
+	condition := "(" + keyExpression + " " + idOperator + " " + keyParameter +
+		" OR (" + keyExpression + " = " + keyParameter + " AND id " + idOperator + " " + idParameter + ")"
+	if !page.descending {
+		condition += " OR " + keyExpression + " IS NULL"
+	}
+	return condition + ")", []interface{}{token.ID, keyArgument}, nil
+}
+
+// encodePageToken формирует токен следующей страницы по последней строке текущей
+func encodePageToken(fingerprint string, key *api.Value, id int64) (string, error) {
+	token := pageToken{Query: fingerprint, ID: id}
+	if key != nil {
+		encodedKey, err := proto.Marshal(key)
+		if err != nil {
+			return "", err
+		}
+		token.Key = encodedKey
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken разбирает токен и проверяет, что он выдан для того же запроса
+func decodePageToken(text string, fingerprint string) (*pageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidPageToken, err)
+	}
+
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidPageToken, err)
+	}
+	if token.Query != fingerprint {
+		return nil, fmt.Errorf("%w: issued for a different table, ordering or filter", errInvalidPageToken)
+	}
+	return &token, nil
+}
+
+// queryFingerprint отпечаток запроса без полей пагинации: токен нельзя применить к
+// запросу с другой сортировкой или фильтрами
+func queryFingerprint(request proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:12]), nil
+}
+
+// normalizePageSize подставляет размер страницы по умолчанию и ограничивает сверху
+func normalizePageSize(pageSize int32) int32 {
+	switch {
+	case pageSize <= 0:
+		return defaultPageSize
+	case pageSize > maxPageSize:
+		return maxPageSize
+	default:
+		return pageSize
+	}
+}
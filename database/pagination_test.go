@@ -0,0 +1,252 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"industrialregistrysystem/base/api"
+)
+
+// tokenFor токен страницы для проверки after: без кодирования в base64
+func tokenFor(t *testing.T, key *api.Value, id int64) *pageToken {
+	t.Helper()
+	token := &pageToken{ID: id}
+	if key != nil {
+		encodedKey, err := proto.Marshal(key)
+		if err != nil {
+			t.Fatalf("marshal key: %v", err)
+		}
+		token.Key = encodedKey
+	}
+	return token
+}
+
+func TestKeysetAfter(t *testing.T) {
+	nullKey := &api.Value{Kind: &api.Value_NullValue{}}
+
+	tests := []struct {
+		name      string
+		page      keyset
+		key       *api.Value
+		condition string
+		arguments []interface{}
+		orderBy   string
+	}{
+		{
+			name:      "id only ascending",
+			page:      keyset{},
+			condition: "id > $3",
+			arguments: []interface{}{int64(42)},
+			orderBy:   "id",
+		},
+		{
+			name:      "id only descending",
+			page:      keyset{descending: true},
+			condition: "id < $3",
+			arguments: []interface{}{int64(42)},
+			orderBy:   "id DESC",
+		},
+		{
+			name:      "ascending after a value: greater keys, then NULLs at the end",
+			page:      keyset{keyExpression: `"revenue"`},
+			key:       intValue(1000),
+			condition: `("revenue" > $4 OR ("revenue" = $4 AND id > $3) OR "revenue" IS NULL)`,
+			arguments: []interface{}{int64(42), int64(1000)},
+			orderBy:   `"revenue", id`,
+		},
+		{
+			name:      "descending after a value: NULLs are already passed",
+			page:      keyset{keyExpression: `"revenue"`, descending: true},
+			key:       intValue(1000),
+			condition: `("revenue" < $4 OR ("revenue" = $4 AND id < $3))`,
+			arguments: []interface{}{int64(42), int64(1000)},
+			orderBy:   `"revenue" DESC, id DESC`,
+		},
+		{
+			name:      "ascending after NULL: only the remaining NULLs",
+			page:      keyset{keyExpression: `"revenue"`},
+			key:       nullKey,
+			condition: `("revenue" IS NULL AND id > $3)`,
+			arguments: []interface{}{int64(42)},
+			orderBy:   `"revenue", id`,
+		},
+		{
+			name:      "descending after NULL: remaining NULLs, then every value",
+			page:      keyset{keyExpression: `"revenue"`, descending: true},
+			key:       nullKey,
+			condition: `(("revenue" IS NULL AND id < $3) OR "revenue" IS NOT NULL)`,
+			arguments: []interface{}{int64(42)},
+			orderBy:   `"revenue" DESC, id DESC`,
+		},
+		{
+			name:      "missing key is treated as NULL",
+			page:      keyset{keyExpression: "NULLIF(name, '')"},
+			condition: `(NULLIF(name, '') IS NULL AND id > $3)`,
+			arguments: []interface{}{int64(42)},
+			orderBy:   "NULLIF(name, ''), id",
+		},
+		{
+			name:      "string key",
+			page:      keyset{keyExpression: "NULLIF(name, '')"},
+			key:       stringValue("Завод"),
+			condition: `(NULLIF(name, '') > $4 OR (NULLIF(name, '') = $4 AND id > $3) OR NULLIF(name, '') IS NULL)`,
+			arguments: []interface{}{int64(42), "Завод"},
+			orderBy:   "NULLIF(name, ''), id",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			condition, arguments, err := test.page.after(tokenFor(t, test.key, 42), 3)
+			if err != nil {
+				t.Fatalf("after error: %v", err)
+			}
+			if condition != test.condition {
+				t.Errorf("condition = %s, want %s", condition, test.condition)
+			}
+			if !reflect.DeepEqual(arguments, test.arguments) {
+				t.Errorf("arguments = %#v, want %#v", arguments, test.arguments)
+			}
+			if orderBy := test.page.orderBy(); orderBy != test.orderBy {
+				t.Errorf("orderBy = %s, want %s", orderBy, test.orderBy)
+			}
+		})
+	}
+}
+
+func TestKeysetAfterInvalidKey(t *testing.T) {
+	page := keyset{keyExpression: `"revenue"`}
+	tests := []struct {
+		name  string
+		token *pageToken
+	}{
+		{name: "key is not a protobuf message", token: &pageToken{Key: []byte{0xff, 0xff}, ID: 1}},
+		{name: "key is not a valid value", token: tokenFor(t, &api.Value{Kind: &api.Value_JsonValue{JsonValue: "{"}}, 1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := page.after(test.token, 1); !errors.Is(err, errInvalidPageToken) {
+				t.Errorf("after error = %v, want errInvalidPageToken", err)
+			}
+		})
+	}
+}
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		key  *api.Value
+		id   int64
+	}{
+		{name: "id only", id: 7},
+		{name: "integer key", key: intValue(-15), id: 8},
+		{name: "string key", key: stringValue("ООО «Северный завод»"), id: 9},
+		{name: "NULL key", key: &api.Value{Kind: &api.Value_NullValue{}}, id: 10},
+		{name: "decimal key", key: &api.Value{Kind: &api.Value_DecimalValue{DecimalValue: "123456789012345678901.5"}}, id: 1 << 40},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := encodePageToken("fingerprint", test.key, test.id)
+			if err != nil {
+				t.Fatalf("encodePageToken error: %v", err)
+			}
+			token, err := decodePageToken(text, "fingerprint")
+			if err != nil {
+				t.Fatalf("decodePageToken error: %v", err)
+			}
+			if token.ID != test.id {
+				t.Errorf("ID = %d, want %d", token.ID, test.id)
+			}
+
+			if test.key == nil {
+				if len(token.Key) != 0 {
+					t.Errorf("Key = %x, want empty", token.Key)
+				}
+				return
+			}
+			var key api.Value
+			if err := proto.Unmarshal(token.Key, &key); err != nil {
+				t.Fatalf("unmarshal key: %v", err)
+			}
+			if !proto.Equal(&key, test.key) {
+				t.Errorf("key = %v, want %v", &key, test.key)
+			}
+		})
+	}
+}
+
+func TestDecodePageTokenRejected(t *testing.T) {
+	fingerprint := func(request *api.ListRequest) string {
+		text, err := queryFingerprint(request)
+		if err != nil {
+			t.Fatalf("queryFingerprint error: %v", err)
+		}
+		return text
+	}
+	issuedFor := &api.ListRequest{TableName: "organizations", OrderBy: "revenue"}
+	token, err := encodePageToken(fingerprint(issuedFor), intValue(1000), 42)
+	if err != nil {
+		t.Fatalf("encodePageToken error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		request *api.ListRequest
+	}{
+		{name: "other table", token: token, request: &api.ListRequest{TableName: "users", OrderBy: "revenue"}},
+		{name: "other column", token: token, request: &api.ListRequest{TableName: "organizations", OrderBy: "name"}},
+		{name: "other direction", token: token, request: &api.ListRequest{TableName: "organizations", OrderBy: "revenue", OrderDesc: true}},
+		{
+			name:    "other filter",
+			token:   token,
+			request: &api.ListRequest{TableName: "organizations", OrderBy: "revenue", Filters: map[string]string{"district": "Северный"}},
+		},
+		{
+			name:    "other filter tree",
+			token:   token,
+			request: &api.ListRequest{TableName: "organizations", OrderBy: "revenue", Filter: conditionFilter("a", api.FilterOperator_IS_NULL)},
+		},
+		{name: "not base64", token: "not a token!", request: issuedFor},
+		{name: "not JSON", token: "bm90IGpzb24", request: issuedFor},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodePageToken(test.token, fingerprint(test.request))
+			if !errors.Is(err, errInvalidPageToken) {
+				t.Fatalf("decodePageToken error = %v, want errInvalidPageToken", err)
+			}
+			if code := errorCode(err); code != "INVALID_ARGUMENT" {
+				t.Errorf("errorCode = %s, want INVALID_ARGUMENT", code)
+			}
+		})
+	}
+
+	if _, err := decodePageToken(token, fingerprint(issuedFor)); err != nil {
+		t.Errorf("token rejected for the request it was issued for: %v", err)
+	}
+}
+
+func TestNormalizePageSize(t *testing.T) {
+	tests := []struct {
+		pageSize int32
+		want     int32
+	}{
+		{pageSize: -1, want: defaultPageSize},
+		{pageSize: 0, want: defaultPageSize},
+		{pageSize: 1, want: 1},
+		{pageSize: maxPageSize, want: maxPageSize},
+		{pageSize: maxPageSize + 1, want: maxPageSize},
+	}
+
+	for _, test := range tests {
+		if got := normalizePageSize(test.pageSize); got != test.want {
+			t.Errorf("normalizePageSize(%d) = %d, want %d", test.pageSize, got, test.want)
+		}
+	}
+}
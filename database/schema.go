@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"industrialregistrysystem/base/api"
+)
+
+// Системные команды mainservice для работы со схемой
+const (
+	schemaCommand        = "schema"
+	schemaRefreshCommand = "schema_refresh"
+)
+
+// SchemaError ошибка проверки идентификатора по схеме: неизвестная таблица или колонка.
+// Передается в mainservice как ErrorResponse с кодом INVALID_ARGUMENT.
+type SchemaError struct {
+	Table  string
+	Column string
+	Reason string
+}
+
+func (schemaError *SchemaError) Error() string {
+	if schemaError.Column != "" {
+		return fmt.Sprintf("%s: %s.%s", schemaError.Reason, schemaError.Table, schemaError.Column)
+	}
+	return fmt.Sprintf("%s: %s", schemaError.Reason, schemaError.Table)
+}
+
+// Details описание ошибки для поля ErrorResponse.Details
+func (schemaError *SchemaError) Details() string {
+	if schemaError.Column != "" {
+		return fmt.Sprintf("table=%s column=%s", schemaError.Table, schemaError.Column)
+	}
+	return fmt.Sprintf("table=%s", schemaError.Table)
+}
+
+// ColumnSchema колонка таблицы из information_schema.columns
+type ColumnSchema struct {
+	Name     string
+	DataType string
+	Nullable bool
+}
+
+// TableSchema таблица, доступная универсальным CRUD командам
+type TableSchema struct {
+	Name       string
+	Columns    []ColumnSchema // В порядке ordinal_position
+	quotedName string
+	columns    map[string]ColumnSchema
+}
+
+// QuotedName имя таблицы вместе со схемой, экранированное для SQL
+func (table *TableSchema) QuotedName() string {
+	return table.quotedName
+}
+
+// Column возвращает колонку таблицы или SchemaError, если ее нет
+func (table *TableSchema) Column(name string) (ColumnSchema, error) {
+	column, exists := table.columns[name]
+	if !exists {
+		return ColumnSchema{}, &SchemaError{Table: table.Name, Column: name, Reason: "unknown column"}
+	}
+	return column, nil
+}
+
+// QuoteColumn проверяет колонку и возвращает ее экранированное имя
+func (table *TableSchema) QuoteColumn(name string) (string, error) {
+	if _, err := table.Column(name); err != nil {
+		return "", err
+	}
+	return pq.QuoteIdentifier(name), nil
+}
+
+// SchemaRegistry список таблиц и колонок схемы PostgreSQL. Все идентификаторы из запросов
+// проверяются по нему, прежде чем попасть в SQL.
+type SchemaRegistry struct {
+	db         *sql.DB
+	schemaName string
+
+	mu       sync.RWMutex
+	tables   map[string]*TableSchema
+	loadedAt time.Time
+}
+
+func NewSchemaRegistry(db *sql.DB, schemaName string) *SchemaRegistry {
+	return &SchemaRegistry{
+		db:         db,
+		schemaName: schemaName,
+		tables:     make(map[string]*TableSchema),
+	}
+}
+
+// Refresh перечитывает таблицы и колонки из information_schema. При ошибке
+// остается прежняя схема.
+func (registry *SchemaRegistry) Refresh(ctx context.Context) error {
+	rows, err := registry.db.QueryContext(ctx, `
+		SELECT c.table_name, c.column_name, c.data_type, c.is_nullable = 'YES'
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+		  ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = $1 AND t.table_type = 'BASE TABLE'
+		ORDER BY c.table_name, c.ordinal_position`, registry.schemaName)
+	if err != nil {
+		return fmt.Errorf("failed to read information_schema: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]*TableSchema)
+	for rows.Next() {
+		var tableName string
+		var column ColumnSchema
+		if err := rows.Scan(&tableName, &column.Name, &column.DataType, &column.Nullable); err != nil {
+			return fmt.Errorf("failed to read information_schema: %w", err)
+		}
+
+		table, exists := tables[tableName]
+		if !exists {
+			table = &TableSchema{
+				Name:       tableName,
+				quotedName: pq.QuoteIdentifier(registry.schemaName) + "." + pq.QuoteIdentifier(tableName),
+				columns:    make(map[string]ColumnSchema),
+			}
+			tables[tableName] = table
+		}
+		table.Columns = append(table.Columns, column)
+		table.columns[column.Name] = column
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read information_schema: %w", err)
+	}
+
+	if len(tables) == 0 {
+		log.Printf("⚠️ Schema %q has no tables: all generic CRUD commands will be rejected", registry.schemaName)
+	}
+
+	registry.mu.Lock()
+	registry.tables = tables
+	registry.loadedAt = time.Now()
+	registry.mu.Unlock()
+
+	log.Printf("🗂️ Loaded schema %q: %d tables", registry.schemaName, len(tables))
+	return nil
+}
+
+// Table возвращает таблицу или SchemaError, если ее нет в схеме
+func (registry *SchemaRegistry) Table(name string) (*TableSchema, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	table, exists := registry.tables[name]
+	if !exists {
+		return nil, &SchemaError{Table: name, Reason: "unknown table"}
+	}
+	return table, nil
+}
+
+// Snapshot возвращает текущую схему для ответа mainservice
+func (registry *SchemaRegistry) Snapshot() *api.SchemaResponse {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	tableNames := make([]string, 0, len(registry.tables))
+	for tableName := range registry.tables {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+
+	response := &api.SchemaResponse{
+		SchemaName: registry.schemaName,
+		Tables:     make([]*api.TableSchema, 0, len(tableNames)),
+		LoadedAt:   timestamppb.New(registry.loadedAt),
+	}
+	for _, tableName := range tableNames {
+		table := registry.tables[tableName]
+		tableSchema := &api.TableSchema{Name: table.Name}
+		for _, column := range table.Columns {
+			tableSchema.Columns = append(tableSchema.Columns, &api.ColumnSchema{
+				Name:     column.Name,
+				DataType: column.DataType,
+				Nullable: column.Nullable,
+			})
+		}
+		response.Tables = append(response.Tables, tableSchema)
+	}
+	return response
+}
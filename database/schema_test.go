@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"industrialregistrysystem/base/api"
+)
+
+// newTestRegistry реестр схемы из готовых таблиц без обращения к БД
+func newTestRegistry(tables ...*TableSchema) *SchemaRegistry {
+	registry := NewSchemaRegistry(nil, "public")
+	for _, table := range tables {
+		registry.tables[table.Name] = table
+	}
+	return registry
+}
+
+// newTestDataService DataService без пула соединений: запросы, не прошедшие проверку
+// схемы, до БД не доходят
+func newTestDataService() *DataService {
+	registry := newTestRegistry(
+		newTestTable("organizations", "id", "name", "district"),
+		newTestTable("Reports", "id", "Title", `odd"name`),
+	)
+	return &DataService{schema: registry, exports: newExportRegistry()}
+}
+
+// assertSchemaError ошибка - SchemaError для указанной таблицы и колонки с кодом INVALID_ARGUMENT
+func assertSchemaError(t *testing.T, err error, table string, column string) {
+	t.Helper()
+	var schemaError *SchemaError
+	if !errors.As(err, &schemaError) {
+		t.Fatalf("error = %v, want SchemaError", err)
+	}
+	if schemaError.Table != table || schemaError.Column != column {
+		t.Errorf("SchemaError for %s.%s, want %s.%s", schemaError.Table, schemaError.Column, table, column)
+	}
+	if code := errorCode(err); code != "INVALID_ARGUMENT" {
+		t.Errorf("errorCode = %s, want INVALID_ARGUMENT", code)
+	}
+}
+
+func TestSchemaRegistryTable(t *testing.T) {
+	registry := newTestDataService().schema
+
+	tests := []struct {
+		name      string
+		tableName string
+		known     bool
+		quoted    string
+	}{
+		{name: "known table", tableName: "organizations", known: true, quoted: `"public"."organizations"`},
+		{name: "mixed case is kept", tableName: "Reports", known: true, quoted: `"public"."Reports"`},
+		{name: "table names are case sensitive", tableName: "reports"},
+		{name: "unknown table", tableName: "users"},
+		{name: "schema-qualified name", tableName: "public.organizations"},
+		{name: "quote in the name", tableName: `organizations"`},
+		{name: "statement separator", tableName: "organizations; DROP TABLE users"},
+		{name: "empty name", tableName: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table, err := registry.Table(test.tableName)
+			if !test.known {
+				if table != nil {
+					t.Errorf("Table(%q) returned a table", test.tableName)
+				}
+				assertSchemaError(t, err, test.tableName, "")
+				return
+			}
+			if err != nil {
+				t.Fatalf("Table(%q) error: %v", test.tableName, err)
+			}
+			if quoted := table.QuotedName(); quoted != test.quoted {
+				t.Errorf("QuotedName = %s, want %s", quoted, test.quoted)
+			}
+		})
+	}
+}
+
+func TestTableSchemaQuoteColumn(t *testing.T) {
+	table := newTestTable("Reports", "id", "Title", `odd"name`)
+
+	tests := []struct {
+		name   string
+		column string
+		known  bool
+	}{
+		{name: "known column", column: "id", known: true},
+		{name: "mixed case", column: "Title", known: true},
+		{name: "embedded quote", column: `odd"name`, known: true},
+		{name: "column names are case sensitive", column: "title"},
+		{name: "unknown column", column: "missing"},
+		{name: "quote breaking out of the identifier", column: `Title" = 1 OR "1`},
+		{name: "statement separator", column: "Title; DROP TABLE users"},
+		{name: "expression", column: "lower(Title)"},
+		{name: "empty name", column: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			quoted, err := table.QuoteColumn(test.column)
+			if !test.known {
+				if quoted != "" {
+					t.Errorf("QuoteColumn(%q) = %s, want nothing", test.column, quoted)
+				}
+				assertSchemaError(t, err, "Reports", test.column)
+				return
+			}
+			if err != nil {
+				t.Fatalf("QuoteColumn(%q) error: %v", test.column, err)
+			}
+			if want := pq.QuoteIdentifier(test.column); quoted != want {
+				t.Errorf("QuoteColumn(%q) = %s, want %s", test.column, quoted, want)
+			}
+		})
+	}
+}
+
+// TestDataServiceRejectsUnknownIdentifiers идентификаторы из запросов проверяются по схеме
+// до построения SQL: ошибка возвращается раньше, чем запрос дошел бы до БД
+func TestDataServiceRejectsUnknownIdentifiers(t *testing.T) {
+	dataService := newTestDataService()
+	ctx := context.Background()
+	injected := `name" = '' OR 1=1; --`
+	entity := func(fieldName string) *api.Entity {
+		return &api.Entity{Fields: map[string]string{"name": "Завод", fieldName: "value"}}
+	}
+
+	tests := []struct {
+		name   string
+		call   func() error
+		table  string
+		column string
+	}{
+		{
+			name: "get from an unknown table",
+			call: func() error {
+				_, err := dataService.Get(ctx, &api.GetRequest{TableName: "users", Id: 1})
+				return err
+			},
+			table: "users",
+		},
+		{
+			name: "delete from an injected table name",
+			call: func() error {
+				_, err := dataService.Delete(ctx, &api.DeleteRequest{TableName: "organizations; DROP TABLE users", Id: 1})
+				return err
+			},
+			table: "organizations; DROP TABLE users",
+		},
+		{
+			name: "create with an unknown column",
+			call: func() error {
+				_, err := dataService.Create(ctx, &api.CreateRequest{TableName: "organizations", Entity: entity("missing")})
+				return err
+			},
+			table:  "organizations",
+			column: "missing",
+		},
+		{
+			name: "update with a quote in a column name",
+			call: func() error {
+				_, err := dataService.Update(ctx, &api.UpdateRequest{TableName: "organizations", Id: 1, Entity: entity(injected)})
+				return err
+			},
+			table:  "organizations",
+			column: injected,
+		},
+		{
+			name: "list from an unknown table",
+			call: func() error {
+				_, err := dataService.List(ctx, &api.ListRequest{TableName: "users"})
+				return err
+			},
+			table: "users",
+		},
+		{
+			name: "list ordered by an unknown column",
+			call: func() error {
+				_, err := dataService.List(ctx, &api.ListRequest{TableName: "organizations", OrderBy: "name; DROP TABLE users"})
+				return err
+			},
+			table:  "organizations",
+			column: "name; DROP TABLE users",
+		},
+		{
+			name: "list filtered by a quote in the key",
+			call: func() error {
+				_, err := dataService.List(ctx, &api.ListRequest{TableName: "organizations", Filters: map[string]string{injected: "1"}})
+				return err
+			},
+			table:  "organizations",
+			column: injected,
+		},
+		{
+			name: "list filter tree with an unknown column",
+			call: func() error {
+				_, err := dataService.List(ctx, &api.ListRequest{TableName: "organizations", Filter: conditionFilter("missing", api.FilterOperator_IS_NULL)})
+				return err
+			},
+			table:  "organizations",
+			column: "missing",
+		},
+		{
+			name: "search in an unknown field",
+			call: func() error {
+				_, err := dataService.Search(ctx, &api.SearchRequest{TableName: "organizations", Query: "завод", Fields: []string{"name", "inn;"}})
+				return err
+			},
+			table:  "organizations",
+			column: "inn;",
+		},
+		{
+			name: "search field with a different case",
+			call: func() error {
+				_, err := dataService.Search(ctx, &api.SearchRequest{TableName: "Reports", Query: "отчет", Fields: []string{"title"}})
+				return err
+			},
+			table:  "Reports",
+			column: "title",
+		},
+		{
+			name: "export ordered by an unknown column",
+			call: func() error {
+				dataService.exports.register("export-order", 1)
+				return dataService.Export("export-order", &api.ExportRequest{TableName: "organizations", OrderBy: `name"`}, nil)
+			},
+			table:  "organizations",
+			column: `name"`,
+		},
+		{
+			name: "export filtered by a statement separator",
+			call: func() error {
+				dataService.exports.register("export-filter", 1)
+				return dataService.Export("export-filter", &api.ExportRequest{TableName: "organizations", Filters: map[string]string{"district;": "Северный"}}, nil)
+			},
+			table:  "organizations",
+			column: "district;",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertSchemaError(t, test.call(), test.table, test.column)
+		})
+	}
+}
+
+// TestQueriesQuoteKnownIdentifiers известные идентификаторы попадают в SQL только
+// через pq.QuoteIdentifier: регистр сохраняется, кавычки удваиваются
+func TestQueriesQuoteKnownIdentifiers(t *testing.T) {
+	table := newTestTable("Reports", "id", "Title", `odd"name`)
+	entity := &api.Entity{Fields: map[string]string{"Title": "Отчет", `odd"name`: "x"}}
+
+	insertQuery, _, err := buildInsertQuery(table, entity)
+	if err != nil {
+		t.Fatalf("buildInsertQuery error: %v", err)
+	}
+	if want := `INSERT INTO "public"."Reports" ("Title", "odd""name") VALUES ($1, $2) RETURNING id`; insertQuery != want {
+		t.Errorf("insert query = %s, want %s", insertQuery, want)
+	}
+
+	updateQuery, _, err := buildUpdateQuery(table, entity, 7)
+	if err != nil {
+		t.Fatalf("buildUpdateQuery error: %v", err)
+	}
+	if !strings.HasPrefix(updateQuery, `UPDATE "public"."Reports" SET "Title" = $1, "odd""name" = $2, `) {
+		t.Errorf("update query = %s, want quoted mixed-case and quoted columns", updateQuery)
+	}
+
+	condition, _, err := buildListCondition(table, map[string]string{`odd"name`: "x"}, conditionFilter("Title", api.FilterOperator_IS_NULL))
+	if err != nil {
+		t.Fatalf("buildListCondition error: %v", err)
+	}
+	if want := `destroyed = false AND ("odd""name" = $1) AND ("Title" IS NULL)`; condition != want {
+		t.Errorf("condition = %s, want %s", condition, want)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"industrialregistrysystem/base/api"
+)
+
+// scanEntity читает текущую строку результата в Entity: устаревшие текстовые fields
+// и типизированные values по типам колонок PostgreSQL
+func scanEntity(rows *sql.Rows, columnTypes []*sql.ColumnType) (*api.Entity, error) {
+	rowValues, err := scanRow(rows, len(columnTypes))
+	if err != nil {
+		return nil, err
+	}
+
+	entity := &api.Entity{
+		Fields: make(map[string]string, len(columnTypes)),
+		Values: make(map[string]*api.Value, len(columnTypes)),
+	}
+	for i, columnType := range columnTypes {
+		columnName := columnType.Name()
+		entity.Fields[columnName] = legacyFieldText(rowValues[i])
+		entity.Values[columnName] = columnValue(columnType.DatabaseTypeName(), rowValues[i])
+	}
+	return entity, nil
+}
+
+// scanEntityValues читает текущую строку в Entity только с типизированными values.
+// Для выгрузки: текстовая копия в fields удвоила бы размер фрагментов.
+func scanEntityValues(rows *sql.Rows, columnTypes []*sql.ColumnType) (*api.Entity, error) {
+	rowValues, err := scanRow(rows, len(columnTypes))
+	if err != nil {
+		return nil, err
+	}
+
+	entity := &api.Entity{Values: make(map[string]*api.Value, len(columnTypes))}
+	for i, columnType := range columnTypes {
+		entity.Values[columnType.Name()] = columnValue(columnType.DatabaseTypeName(), rowValues[i])
+	}
+	return entity, nil
+}
+
+// scanRow читает значения текущей строки в том виде, в каком их отдает lib/pq
+func scanRow(rows *sql.Rows, columnCount int) ([]interface{}, error) {
+	rowValues := make([]interface{}, columnCount)
+	rowValuePointers := make([]interface{}, columnCount)
+	for i := range rowValues {
+		rowValuePointers[i] = &rowValues[i]
+	}
+
+	if err := rows.Scan(rowValuePointers...); err != nil {
+		return nil, err
+	}
+	return rowValues, nil
+}
+
+// legacyFieldText форматирует значение для устаревшего поля Entity.fields.
+// Формат сохранен для старых клиентов: NULL - пустая строка, дробные числа через %f.
+func legacyFieldText(raw interface{}) string {
+	if raw == nil {
+		return ""
+	}
+
+	switch v := raw.(type) {
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case int64:
+		return fmt.Sprintf("%d", v)
+	case float64:
+		return fmt.Sprintf("%f", v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// columnValue преобразует значение, прочитанное lib/pq, в Value по имени типа колонки
+// (sql.ColumnType.DatabaseTypeName: INT4, NUMERIC, TIMESTAMPTZ, ...)
+func columnValue(databaseType string, raw interface{}) *api.Value {
+	if raw == nil {
+		return api.NullValue()
+	}
+
+	switch databaseType {
+	case "NUMERIC":
+		// lib/pq отдает numeric текстом: передаем его как есть, без округления до float64
+		if text, ok := raw.([]byte); ok {
+			return &api.Value{Kind: &api.Value_DecimalValue{DecimalValue: string(text)}}
+		}
+	case "DATE":
+		if date, ok := raw.(time.Time); ok {
+			return &api.Value{Kind: &api.Value_DateValue{DateValue: &api.Date{
+				Year:  int32(date.Year()),
+				Month: int32(date.Month()),
+				Day:   int32(date.Day()),
+			}}}
+		}
+	case "JSON", "JSONB":
+		if text, ok := raw.([]byte); ok {
+			return &api.Value{Kind: &api.Value_JsonValue{JsonValue: string(text)}}
+		}
+	case "BYTEA":
+		if data, ok := raw.([]byte); ok {
+			return &api.Value{Kind: &api.Value_BytesValue{BytesValue: data}}
+		}
+	}
+
+	switch v := raw.(type) {
+	case bool:
+		return &api.Value{Kind: &api.Value_BoolValue{BoolValue: v}}
+	case int64:
+		return &api.Value{Kind: &api.Value_Int64Value{Int64Value: v}}
+	case float64:
+		return &api.Value{Kind: &api.Value_DoubleValue{DoubleValue: v}}
+	case time.Time:
+		return &api.Value{Kind: &api.Value_TimestampValue{TimestampValue: timestamppb.New(v)}}
+	case []byte:
+		return &api.Value{Kind: &api.Value_StringValue{StringValue: string(v)}}
+	case string:
+		return &api.Value{Kind: &api.Value_StringValue{StringValue: v}}
+	default:
+		return &api.Value{Kind: &api.Value_StringValue{StringValue: fmt.Sprintf("%v", v)}}
+	}
+}
+
+// valueArgument преобразует Value из запроса в параметр SQL запроса
+func valueArgument(value *api.Value) (interface{}, error) {
+	switch kind := value.GetKind().(type) {
+	case *api.Value_NullValue:
+		return nil, nil
+	case *api.Value_BoolValue:
+		return kind.BoolValue, nil
+	case *api.Value_Int64Value:
+		return kind.Int64Value, nil
+	case *api.Value_DoubleValue:
+		if math.IsNaN(kind.DoubleValue) || math.IsInf(kind.DoubleValue, 0) {
+			return nil, fmt.Errorf("double value must be finite")
+		}
+		return kind.DoubleValue, nil
+	case *api.Value_DecimalValue:
+		// Текст параметра PostgreSQL приводит к numeric сам, сохраняя все знаки
+		return kind.DecimalValue, nil
+	case *api.Value_TimestampValue:
+		if err := kind.TimestampValue.CheckValid(); err != nil {
+			return nil, err
+		}
+		return kind.TimestampValue.AsTime(), nil
+	case *api.Value_DateValue:
+		date := kind.DateValue
+		parsed := time.Date(int(date.Year), time.Month(date.Month), int(date.Day), 0, 0, 0, 0, time.UTC)
+		if date.Month < 1 || date.Month > 12 || parsed.Day() != int(date.Day) {
+			return nil, fmt.Errorf("invalid date %s", date.Text())
+		}
+		return date.Text(), nil
+	case *api.Value_BytesValue:
+		return kind.BytesValue, nil
+	case *api.Value_JsonValue:
+		if !json.Valid([]byte(kind.JsonValue)) {
+			return nil, fmt.Errorf("invalid JSON document")
+		}
+		return kind.JsonValue, nil
+	case *api.Value_StringValue:
+		return kind.StringValue, nil
+	default:
+		return nil, fmt.Errorf("value kind is not set")
+	}
+}
+
+// entityArguments собирает значения колонок записи для INSERT/UPDATE: поля fields,
+// перекрытые одноименными values. Колонки проверяются по схеме таблицы и
+// возвращаются в стабильном порядке, чтобы текст запроса не зависел от обхода map.
+func entityArguments(table *TableSchema, entity *api.Entity) ([]string, map[string]interface{}, error) {
+	arguments := make(map[string]interface{}, len(entity.GetFields())+len(entity.GetValues()))
+	for fieldName, fieldValue := range entity.GetFields() {
+		arguments[fieldName] = fieldValue
+	}
+	for fieldName, value := range entity.GetValues() {
+		argument, err := valueArgument(value)
+		if err != nil {
+			return nil, nil, &SchemaError{Table: table.Name, Column: fieldName, Reason: "invalid value (" + err.Error() + ")"}
+		}
+		arguments[fieldName] = argument
+	}
+
+	columnNames := make([]string, 0, len(arguments))
+	for fieldName := range arguments {
+		if _, err := table.Column(fieldName); err != nil {
+			return nil, nil, err
+		}
+		columnNames = append(columnNames, fieldName)
+	}
+	sort.Strings(columnNames)
+	return columnNames, arguments, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizationPolicy сопоставляет роль клиента (OU сертификата) со списком разрешенных методов.
+// Метод задается полным именем ("/api.DataService/Get") или шаблоном сервиса ("/api.DataService/*").
+type AuthorizationPolicy struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+// Authorizer проверяет, что роль из сертификата клиента допускает вызываемый метод.
+// Все, что не разрешено политикой явно, запрещено.
+type Authorizer struct {
+	policy AuthorizationPolicy
+
+	// Сочетания роли и метода, разрешение которых уже записано в журнал
+	loggedGrants sync.Map
+}
+
+// NewAuthorizer загружает политику доступа из файла
+func NewAuthorizer(path string) (*Authorizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read authorization policy: %v", err)
+	}
+
+	var policy AuthorizationPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse authorization policy %s: %v", path, err)
+	}
+	if len(policy.Roles) == 0 {
+		return nil, fmt.Errorf("authorization policy %s defines no roles", path)
+	}
+
+	for role, methods := range policy.Roles {
+		log.Printf("🛡️ Role %s may call: %s", role, strings.Join(methods, ", "))
+	}
+	return &Authorizer{policy: policy}, nil
+}
+
+// Validate проверяет, что каждое правило политики соответствует хотя бы одному
+// зарегистрированному методу: опечатка в политике иначе молча запретит доступ
+func (authorizer *Authorizer) Validate(services map[string]grpc.ServiceInfo) error {
+	var methods []string
+	for serviceName, info := range services {
+		for _, method := range info.Methods {
+			methods = append(methods, "/"+serviceName+"/"+method.Name)
+		}
+	}
+
+	for role, patterns := range authorizer.policy.Roles {
+		for _, pattern := range patterns {
+			matched := false
+			for _, method := range methods {
+				if methodMatches(pattern, method) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				sort.Strings(methods)
+				return fmt.Errorf("authorization policy for role %s references unknown method %q (known: %s)",
+					role, pattern, strings.Join(methods, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// UnaryInterceptor проверяет права на унарные вызовы
+func (authorizer *Authorizer) UnaryInterceptor(ctx context.Context, request interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := authorizer.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// StreamInterceptor проверяет права на потоковые вызовы (CommandStream, Export)
+func (authorizer *Authorizer) StreamInterceptor(server interface{}, stream grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorizer.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(server, stream)
+}
+
+// authorize принимает решение по роли клиента. Отказы журналируются всегда, а разрешения -
+// один раз на роль и метод: строка на каждый запрос пути данных засорила бы журнал
+func (authorizer *Authorizer) authorize(ctx context.Context, fullMethod string) error {
+	roles, subject, err := callerRoles(ctx)
+	if err != nil {
+		log.Printf("🚫 Access denied to %s: %v", fullMethod, err)
+		return status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	for _, role := range roles {
+		for _, pattern := range authorizer.policy.Roles[role] {
+			if methodMatches(pattern, fullMethod) {
+				if _, logged := authorizer.loggedGrants.LoadOrStore(role+" "+fullMethod, true); !logged {
+					log.Printf("✅ Access granted to %s for role %s (first call by %s, rule %s)", fullMethod, role, subject, pattern)
+				}
+				return nil
+			}
+		}
+	}
+
+	log.Printf("🚫 Access denied to %s for %s (roles %v)", fullMethod, subject, roles)
+	return status.Errorf(codes.PermissionDenied, "roles %v are not allowed to call %s", roles, fullMethod)
+}
+
+// callerRoles извлекает роли (OU) и имя клиента из проверенного сертификата
+func callerRoles(ctx context.Context) ([]string, string, error) {
+	peerInfo, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, "", fmt.Errorf("no peer information in context")
+	}
+
+	tlsAuth, ok := peerInfo.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, "", fmt.Errorf("connection is not using TLS")
+	}
+
+	if len(tlsAuth.State.PeerCertificates) == 0 {
+		return nil, "", fmt.Errorf("no client certificate provided")
+	}
+
+	clientCertificate := tlsAuth.State.PeerCertificates[0]
+	subject := fmt.Sprintf("%s (serial %s, %s)", clientCertificate.Subject.CommonName,
+		clientCertificate.SerialNumber.Text(16), peerInfo.Addr.String())
+	return clientCertificate.Subject.OrganizationalUnit, subject, nil
+}
+
+// methodMatches сравнивает метод с правилом политики
+func methodMatches(pattern, fullMethod string) bool {
+	if servicePrefix, isWildcard := strings.CutSuffix(pattern, "*"); isWildcard {
+		return strings.HasPrefix(fullMethod, servicePrefix)
+	}
+	return pattern == fullMethod
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// newTestAuthorizer политика: операторы читают данные, администраторы вызывают все
+// методы ManagementService, репликатор - только CommandStream
+func newTestAuthorizer() *Authorizer {
+	return &Authorizer{policy: AuthorizationPolicy{Roles: map[string][]string{
+		"operator":   {"/api.DataService/Get", "/api.DataService/List"},
+		"admin":      {"/api.ManagementService/*"},
+		"replicator": {"/api.DatabaseService/CommandStream"},
+	}}}
+}
+
+// peerContext контекст вызова с клиентским сертификатом; без OU - сертификат не передан
+func peerContext(authInfo credentials.AuthInfo) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 50000},
+		AuthInfo: authInfo,
+	})
+}
+
+// clientContext вызов клиента с сертификатом, у которого заданы роли (OU)
+func clientContext(roles ...string) context.Context {
+	certificate := &x509.Certificate{
+		SerialNumber: big.NewInt(0x2a),
+		Subject:      pkix.Name{CommonName: "client", OrganizationalUnit: roles},
+	}
+	return peerContext(credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}}})
+}
+
+func TestMethodMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		want    bool
+	}{
+		{pattern: "/api.DataService/Get", method: "/api.DataService/Get", want: true},
+		{pattern: "/api.DataService/Get", method: "/api.DataService/GetStats", want: false},
+		{pattern: "/api.DataService/Get", method: "/api.DataService/List", want: false},
+		{pattern: "/api.DataService/*", method: "/api.DataService/List", want: true},
+		{pattern: "/api.DataService/*", method: "/api.DataServiceAdmin/List", want: false},
+		{pattern: "/api.DataService/*", method: "/api.ManagementService/Drain", want: false},
+		{pattern: "/api.DataService/get", method: "/api.DataService/Get", want: false},
+	}
+
+	for _, test := range tests {
+		if matches := methodMatches(test.pattern, test.method); matches != test.want {
+			t.Errorf("methodMatches(%q, %q) = %t, want %t", test.pattern, test.method, matches, test.want)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	authorizer := newTestAuthorizer()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		code   codes.Code
+	}{
+		{name: "exact rule", ctx: clientContext("operator"), method: "/api.DataService/Get", code: codes.OK},
+		{name: "exact rule does not cover other methods", ctx: clientContext("operator"), method: "/api.DataService/Delete", code: codes.PermissionDenied},
+		{name: "wildcard rule", ctx: clientContext("admin"), method: "/api.ManagementService/Drain", code: codes.OK},
+		{name: "wildcard rule is limited to its service", ctx: clientContext("admin"), method: "/api.DataService/Get", code: codes.PermissionDenied},
+		{name: "any of several roles", ctx: clientContext("auditor", "replicator"), method: "/api.DatabaseService/CommandStream", code: codes.OK},
+		{name: "role missing from the policy", ctx: clientContext("auditor"), method: "/api.DataService/Get", code: codes.PermissionDenied},
+		{name: "certificate without roles", ctx: clientContext(), method: "/api.DataService/Get", code: codes.PermissionDenied},
+		{name: "no client certificate", ctx: peerContext(credentials.TLSInfo{}), method: "/api.DataService/Get", code: codes.Unauthenticated},
+		{name: "connection without TLS", ctx: peerContext(nil), method: "/api.DataService/Get", code: codes.Unauthenticated},
+		{name: "no peer", ctx: context.Background(), method: "/api.DataService/Get", code: codes.Unauthenticated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := authorizer.authorize(test.ctx, test.method)
+			if code := status.Code(err); code != test.code {
+				t.Errorf("authorize(%s) = %v, want code %s", test.method, err, test.code)
+			}
+		})
+	}
+}
+
+// TestAuthorizeLogsGrantOnce разрешение записывается в журнал один раз на роль и метод,
+// отказ - при каждом вызове
+func TestAuthorizeLogsGrantOnce(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	authorizer := newTestAuthorizer()
+	for i := 0; i < 3; i++ {
+		authorizer.authorize(clientContext("operator"), "/api.DataService/Get")
+		authorizer.authorize(clientContext("operator"), "/api.DataService/List")
+		authorizer.authorize(clientContext("auditor"), "/api.DataService/Get")
+	}
+
+	journal := output.String()
+	if count := strings.Count(journal, "Access granted to /api.DataService/Get for role operator"); count != 1 {
+		t.Errorf("Get grant logged %d times, want once:\n%s", count, journal)
+	}
+	if count := strings.Count(journal, "Access granted to /api.DataService/List for role operator"); count != 1 {
+		t.Errorf("List grant logged %d times, want once:\n%s", count, journal)
+	}
+	if count := strings.Count(journal, "Access denied to /api.DataService/Get"); count != 3 {
+		t.Errorf("denial logged %d times, want 3:\n%s", count, journal)
+	}
+}
+
+func TestAuthorizerValidate(t *testing.T) {
+	services := map[string]grpc.ServiceInfo{
+		"api.DataService":       {Methods: []grpc.MethodInfo{{Name: "Get"}, {Name: "List"}}},
+		"api.ManagementService": {Methods: []grpc.MethodInfo{{Name: "Drain"}}},
+		"api.DatabaseService":   {Methods: []grpc.MethodInfo{{Name: "CommandStream", IsClientStream: true, IsServerStream: true}}},
+	}
+
+	if err := newTestAuthorizer().Validate(services); err != nil {
+		t.Fatalf("Validate rejected a correct policy: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+	}{
+		{name: "typo in the method", pattern: "/api.DataService/Lsit"},
+		{name: "method name case", pattern: "/api.DataService/get"},
+		{name: "unknown service wildcard", pattern: "/api.DataServise/*"},
+		{name: "missing leading slash", pattern: "api.DataService/Get"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorizer := newTestAuthorizer()
+			authorizer.policy.Roles["operator"] = append(authorizer.policy.Roles["operator"], test.pattern)
+			err := authorizer.Validate(services)
+			if err == nil || !strings.Contains(err.Error(), test.pattern) {
+				t.Errorf("Validate error = %v, want it to name %q", err, test.pattern)
+			}
+		})
+	}
+}
+
+func TestNewAuthorizer(t *testing.T) {
+	directory := t.TempDir()
+	tests := []struct {
+		name    string
+		policy  string // Пусто - файла нет
+		wantErr bool
+	}{
+		{name: "valid policy", policy: `{"roles": {"operator": ["/api.DataService/*"]}}`},
+		{name: "missing file", wantErr: true},
+		{name: "malformed JSON", policy: `{"roles": [`, wantErr: true},
+		{name: "no roles", policy: `{"roles": {}}`, wantErr: true},
+	}
+
+	for index, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(directory, string(rune('a'+index))+".json")
+			if test.policy != "" {
+				writeFile(t, path, []byte(test.policy))
+			}
+			authorizer, err := NewAuthorizer(path)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("NewAuthorizer error = %v, want error %t", err, test.wantErr)
+			}
+			if err == nil && !methodMatches(authorizer.policy.Roles["operator"][0], "/api.DataService/Get") {
+				t.Errorf("loaded policy = %v", authorizer.policy.Roles)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"industrialregistrysystem/base/api"
+)
+
+// StrategyType тип стратегии выбора базы данных
+type StrategyType string
+
+const (
+	RoundRobinStrategyType      StrategyType = "round_robin"
+	LeastInFlightStrategyType   StrategyType = "least_in_flight"
+	LatencyWeightedStrategyType StrategyType = "latency_weighted"
+)
+
+const (
+	// maxConsecutiveFailures после стольких таймаутов подряд БД считается нездоровой
+	maxConsecutiveFailures = 3
+
+	// unhealthyCooldown через это время нездоровая БД снова получает пробные команды
+	unhealthyCooldown = 30 * time.Second
+
+	// latencySmoothing вес нового замера в экспоненциальном сглаживании задержки
+	latencySmoothing = 0.2
+)
+
+// SelectionStrategy выбирает базу данных для выполнения очередной команды
+type SelectionStrategy interface {
+	// Select возвращает одно из переданных подключений; список не пуст
+	Select(connections []*DatabaseConnection) *DatabaseConnection
+}
+
+// NewSelectionStrategy создает стратегию выбора БД по типу
+func NewSelectionStrategy(strategyType StrategyType) SelectionStrategy {
+	switch strategyType {
+	case RoundRobinStrategyType:
+		return &RoundRobinStrategy{}
+	case LatencyWeightedStrategyType:
+		return &LatencyWeightedStrategy{}
+	case LeastInFlightStrategyType:
+		return &LeastInFlightStrategy{}
+	default:
+		// По умолчанию выбираем наименее загруженную БД
+		return &LeastInFlightStrategy{}
+	}
+}
+
+// RoundRobinStrategy перебирает базы данных по очереди
+type RoundRobinStrategy struct {
+	next atomic.Uint64
+}
+
+func (strategy *RoundRobinStrategy) Select(connections []*DatabaseConnection) *DatabaseConnection {
+	index := strategy.next.Add(1) - 1
+	return connections[index%uint64(len(connections))]
+}
+
+// LeastInFlightStrategy выбирает БД с наименьшим числом выполняемых команд
+type LeastInFlightStrategy struct{}
+
+func (strategy *LeastInFlightStrategy) Select(connections []*DatabaseConnection) *DatabaseConnection {
+	selected := connections[0]
+	for _, connection := range connections[1:] {
+		if connection.InFlight() < selected.InFlight() {
+			selected = connection
+		}
+	}
+	return selected
+}
+
+// LatencyWeightedStrategy выбирает БД случайно с весом, обратно пропорциональным
+// ожидаемому времени ответа (средняя задержка с учетом очереди команд)
+type LatencyWeightedStrategy struct{}
+
+func (strategy *LatencyWeightedStrategy) Select(connections []*DatabaseConnection) *DatabaseConnection {
+	weights := make([]float64, len(connections))
+	totalWeight := 0.0
+	for i, connection := range connections {
+		// Для еще не измеренных БД берем оптимистичную оценку, чтобы они получали трафик
+		expectedLatency := max(connection.AverageLatency(), time.Millisecond)
+		expectedLatency *= time.Duration(connection.InFlight() + 1)
+		weights[i] = 1 / expectedLatency.Seconds()
+		totalWeight += weights[i]
+	}
+
+	point := rand.Float64() * totalWeight
+	for i, weight := range weights {
+		point -= weight
+		if point <= 0 {
+			return connections[i]
+		}
+	}
+	return connections[len(connections)-1]
+}
+
+// InFlight возвращает число команд, ожидающих ответа от этой БД
+func (connection *DatabaseConnection) InFlight() int64 {
+	return connection.inFlight.Load()
+}
+
+// AverageLatency возвращает сглаженное время ответа БД
+func (connection *DatabaseConnection) AverageLatency() time.Duration {
+	return time.Duration(connection.latencyEWMA.Load())
+}
+
+// Healthy сообщает, можно ли направлять команды в эту БД
+func (connection *DatabaseConnection) Healthy() bool {
+	select {
+	case <-connection.done:
+		return false
+	default:
+	}
+	if connection.heartbeatFailed.Load() {
+		return false
+	}
+	if connection.consecutiveFailures.Load() < maxConsecutiveFailures {
+		return true
+	}
+	lastFailure := time.Unix(0, connection.lastFailureAt.Load())
+	return time.Since(lastFailure) > unhealthyCooldown
+}
+
+// Done возвращает канал, закрывающийся при отключении БД
+func (connection *DatabaseConnection) Done() <-chan struct{} {
+	return connection.done
+}
+
+// recordSuccess учитывает успешный ответ и его задержку
+func (connection *DatabaseConnection) recordSuccess(latency time.Duration) {
+	connection.consecutiveFailures.Store(0)
+	for {
+		previous := connection.latencyEWMA.Load()
+		updated := int64(latency)
+		if previous != 0 {
+			updated = int64(float64(previous)*(1-latencySmoothing) + float64(latency)*latencySmoothing)
+		}
+		if connection.latencyEWMA.CompareAndSwap(previous, updated) {
+			return
+		}
+	}
+}
+
+// recordFailure учитывает таймаут или ошибку доставки команды
+func (connection *DatabaseConnection) recordFailure() {
+	connection.lastFailureAt.Store(time.Now().UnixNano())
+	if failures := connection.consecutiveFailures.Add(1); failures == maxConsecutiveFailures {
+		log.Printf("⚠️ Database %s marked unhealthy after %d consecutive failures", connection.ServiceID, failures)
+	}
+}
+
+// sendCommand ставит команду в очередь отправки этой БД
+func (connection *DatabaseConnection) sendCommand(ctx context.Context, command *api.CommandRequest) error {
+	select {
+	case connection.CommandChan <- command:
+		return nil
+	case <-connection.done:
+		return fmt.Errorf("database %s disconnected", connection.ServiceID)
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(5 * time.Second):
+		return fmt.Errorf("timeout sending command to database %s", connection.ServiceID)
+	}
+}
+
+// isIdempotentCommand сообщает, можно ли безопасно повторить команду на другой БД
+func isIdempotentCommand(request *api.CommandRequest) bool {
+	switch command := request.Command.(type) {
+	case *api.CommandRequest_Get,
+		*api.CommandRequest_List,
+		*api.CommandRequest_Search,
+		*api.CommandRequest_GetOrganization,
+		*api.CommandRequest_ListOrganizations,
+		*api.CommandRequest_SearchOrganizations,
+		*api.CommandRequest_GetUser,
+		*api.CommandRequest_ValidateInvite,
+		*api.CommandRequest_GetFinancialData,
+		*api.CommandRequest_GetStaffData:
+		return true
+	case *api.CommandRequest_SystemCommand:
+		return command.SystemCommand == "health_check"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,357 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// arcListID список ARC, в котором находится ключ
+type arcListID int
+
+const (
+	arcRecent        arcListID = iota // T1: записи, запрошенные один раз
+	arcFrequent                       // T2: записи, запрошенные повторно
+	arcRecentGhost                    // B1: ключи, недавно вытесненные из T1
+	arcFrequentGhost                  // B2: ключи, недавно вытесненные из T2
+)
+
+// arcEntry элемент списков ARC; у ключей в списках-призраках item равен nil
+type arcEntry struct {
+	key    string
+	item   *CacheItem
+	listID arcListID
+}
+
+// ARCCache реализует Adaptive Replacement Cache: кэш делится между недавно и часто
+// запрошенными записями, а граница между ними подстраивается по промахам в списках-призраках
+type ARCCache struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element // Значения элементов - *arcEntry
+	lists      [4]*list.List            // Начало - последние обращения
+	target     int                      // Целевой размер T1 (параметр p алгоритма)
+	maxSize    int
+	expiration ExpirationPolicy
+	budget     ByteBudget
+	usedBytes  int64
+	evictions  int
+}
+
+// NewARCCache создает ARC кэш с политикой времени жизни записей и ограничением по памяти
+func NewARCCache(maxSize int, expiration ExpirationPolicy, budget ByteBudget) Cache {
+	cache := &ARCCache{
+		entries:    make(map[string]*list.Element),
+		maxSize:    maxSize,
+		expiration: expiration,
+		budget:     budget,
+	}
+	for i := range cache.lists {
+		cache.lists[i] = list.New()
+	}
+	return cache
+}
+
+// Get возвращает значение по ключу, если его время жизни не истекло
+func (cache *ARCCache) Get(key string) (interface{}, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	item := cache.cached(key)
+	if item == nil {
+		return nil, false
+	}
+	now := time.Now()
+	if item.expired(now) {
+		return nil, false
+	}
+
+	item.lastAccess = now
+	item.accessCount++
+	cache.moveTo(cache.entries[key], arcFrequent)
+	return item.value, true
+}
+
+// GetStale возвращает значение с учетом окна stale-while-revalidate
+func (cache *ARCCache) GetStale(key string) (interface{}, bool, bool) {
+	if value, found := cache.Get(key); found {
+		return value, true, true
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	item := cache.cached(key)
+	if item == nil || !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false, false
+	}
+	return item.value, false, true
+}
+
+// Peek возвращает значение без учета обращения: запись не переходит в список частых
+func (cache *ARCCache) Peek(key string) (interface{}, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	item := cache.cached(key)
+	if item == nil || !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+// Set устанавливает значение по ключу
+func (cache *ARCCache) Set(key string, value interface{}) {
+	cache.SetWithTTL(key, value, cache.expiration.TTLFor(key))
+}
+
+// SetWithTTL устанавливает значение по ключу с заданным временем жизни
+func (cache *ARCCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	item := &CacheItem{
+		key:         key,
+		value:       value,
+		createdAt:   now,
+		lastAccess:  now,
+		expiresAt:   expirationTime(now, ttl),
+		accessCount: 1,
+		weight:      cache.budget.weigh(key, value),
+	}
+	defer cache.trimGhosts()
+
+	element, found := cache.entries[key]
+	if !cache.budget.fits(item.weight) {
+		// Запись больше всего бюджета не кэшируется; старое значение устарело
+		if found {
+			cache.remove(element)
+		}
+		return
+	}
+	if !found {
+		cache.insertNew(item)
+		return
+	}
+
+	entry := element.Value.(*arcEntry)
+	switch entry.listID {
+	case arcRecent, arcFrequent:
+		// Обновление закэшированной записи
+		entry.item.value = value
+		entry.item.lastAccess = now
+		entry.item.expiresAt = item.expiresAt
+		cache.usedBytes += item.weight - entry.item.weight
+		entry.item.weight = item.weight
+		cache.moveTo(element, arcFrequent)
+		for cache.budget.exceeds(cache.usedBytes, 0) && cache.cachedCount() > 1 {
+			cache.demote(false)
+		}
+
+	case arcRecentGhost:
+		// Промах по недавно вытесненной из T1 записи: T1 был слишком мал
+		delta := max(cache.lists[arcFrequentGhost].Len()/cache.lists[arcRecentGhost].Len(), 1)
+		cache.target = min(cache.target+delta, cache.maxSize)
+		cache.replace(false)
+		cache.makeRoom(item.weight, false)
+		cache.link(element, item)
+
+	case arcFrequentGhost:
+		// Промах по недавно вытесненной из T2 записи: T2 был слишком мал
+		delta := max(cache.lists[arcRecentGhost].Len()/cache.lists[arcFrequentGhost].Len(), 1)
+		cache.target = max(cache.target-delta, 0)
+		cache.replace(true)
+		cache.makeRoom(item.weight, true)
+		cache.link(element, item)
+	}
+}
+
+// Remove удаляет значение по ключу
+func (cache *ARCCache) Remove(key string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, found := cache.entries[key]; found {
+		cache.remove(element)
+	}
+}
+
+// Clear очищает весь кэш
+func (cache *ARCCache) Clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.entries = make(map[string]*list.Element)
+	for _, arcList := range cache.lists {
+		arcList.Init()
+	}
+	cache.target = 0
+	cache.usedBytes = 0
+}
+
+// GetStats возвращает статистику кэша: уровень 1 - часто запрашиваемые записи (T2),
+// уровень 2 - запрошенные один раз (T1), уровень 3 - ключи в списках-призраках
+func (cache *ARCCache) GetStats() (int, int, int, int) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	frequent := cache.lists[arcFrequent].Len()
+	recent := cache.lists[arcRecent].Len()
+	ghosts := cache.lists[arcRecentGhost].Len() + cache.lists[arcFrequentGhost].Len()
+	return frequent, recent, ghosts, frequent + recent
+}
+
+// Size возвращает текущий размер кэша (без ключей-призраков)
+func (cache *ARCCache) Size() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.cachedCount()
+}
+
+// MaxSize возвращает максимальный размер кэша
+func (cache *ARCCache) MaxSize() int {
+	return cache.maxSize
+}
+
+// UsedBytes возвращает суммарный вес записей
+func (cache *ARCCache) UsedBytes() int64 {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.usedBytes
+}
+
+// MaxBytes возвращает ограничение кэша по памяти (0 - без ограничения)
+func (cache *ARCCache) MaxBytes() int64 {
+	return cache.budget.MaxBytes
+}
+
+// Evictions возвращает число вытесненных записей
+func (cache *ARCCache) Evictions() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.evictions
+}
+
+// cached возвращает закэшированную запись (не призрак); вызывается под блокировкой
+func (cache *ARCCache) cached(key string) *CacheItem {
+	element, found := cache.entries[key]
+	if !found {
+		return nil
+	}
+	return element.Value.(*arcEntry).item
+}
+
+func (cache *ARCCache) cachedCount() int {
+	return cache.lists[arcRecent].Len() + cache.lists[arcFrequent].Len()
+}
+
+// insertNew добавляет ключ, которого нет ни в кэше, ни в призраках; вызывается под блокировкой
+func (cache *ARCCache) insertNew(item *CacheItem) {
+	recentGhost := cache.lists[arcRecentGhost]
+	frequentGhost := cache.lists[arcFrequentGhost]
+
+	if cache.lists[arcRecent].Len()+recentGhost.Len() >= cache.maxSize {
+		if cache.lists[arcRecent].Len() < cache.maxSize {
+			cache.dropOldest(arcRecentGhost)
+			cache.replace(false)
+		} else {
+			// T1 занимает весь кэш: вытесняем из него без сохранения призрака
+			cache.dropOldest(arcRecent)
+			cache.evictions++
+		}
+	} else if total := cache.cachedCount() + recentGhost.Len() + frequentGhost.Len(); total >= cache.maxSize {
+		if total >= 2*cache.maxSize {
+			cache.dropOldest(arcFrequentGhost)
+		}
+		cache.replace(false)
+	}
+
+	cache.makeRoom(item.weight, false)
+	entry := &arcEntry{key: item.key, item: item, listID: arcRecent}
+	cache.entries[item.key] = cache.lists[arcRecent].PushFront(entry)
+	cache.usedBytes += item.weight
+}
+
+// link возвращает в кэш (в T2) ключ из списка-призрака; вызывается под блокировкой
+func (cache *ARCCache) link(element *list.Element, item *CacheItem) {
+	element.Value.(*arcEntry).item = item
+	cache.moveTo(element, arcFrequent)
+	cache.usedBytes += item.weight
+}
+
+// replace освобождает место в кэше, если он заполнен по числу записей.
+// Вызывается под блокировкой.
+func (cache *ARCCache) replace(frequentGhostHit bool) {
+	if cache.cachedCount() < cache.maxSize {
+		return
+	}
+	cache.demote(frequentGhostHit)
+}
+
+// makeRoom вытесняет записи, пока запись веса weight не поместится в бюджет памяти.
+// Вызывается под блокировкой.
+func (cache *ARCCache) makeRoom(weight int64, frequentGhostHit bool) {
+	for cache.budget.exceeds(cache.usedBytes, weight) && cache.cachedCount() > 0 {
+		cache.demote(frequentGhostHit)
+	}
+}
+
+// demote переносит старейшую запись T1 или T2 в список-призрак; вызывается под блокировкой
+func (cache *ARCCache) demote(frequentGhostHit bool) {
+	recentLength := cache.lists[arcRecent].Len()
+	from, to := arcFrequent, arcFrequentGhost
+	if recentLength > 0 && (recentLength > cache.target || (frequentGhostHit && recentLength == cache.target) ||
+		cache.lists[arcFrequent].Len() == 0) {
+		from, to = arcRecent, arcRecentGhost
+	}
+
+	element := cache.lists[from].Back()
+	if element == nil {
+		return
+	}
+	entry := element.Value.(*arcEntry)
+	cache.usedBytes -= entry.item.weight
+	entry.item = nil
+	cache.moveTo(element, to)
+	cache.evictions++
+}
+
+// trimGhosts ограничивает общее число ключей 2*maxSize: вытеснения по памяти
+// могут наполнить списки-призраки сверх обычного для ARC. Вызывается под блокировкой.
+func (cache *ARCCache) trimGhosts() {
+	for cache.cachedCount()+cache.lists[arcRecentGhost].Len()+cache.lists[arcFrequentGhost].Len() > 2*cache.maxSize {
+		if cache.lists[arcRecentGhost].Len() > 0 {
+			cache.dropOldest(arcRecentGhost)
+		} else if cache.lists[arcFrequentGhost].Len() > 0 {
+			cache.dropOldest(arcFrequentGhost)
+		} else {
+			return
+		}
+	}
+}
+
+// remove удаляет ключ из кэша или списка-призрака; вызывается под блокировкой
+func (cache *ARCCache) remove(element *list.Element) {
+	entry := element.Value.(*arcEntry)
+	if entry.item != nil {
+		cache.usedBytes -= entry.item.weight
+	}
+	cache.lists[entry.listID].Remove(element)
+	delete(cache.entries, entry.key)
+}
+
+// moveTo переносит элемент в начало указанного списка; вызывается под блокировкой
+func (cache *ARCCache) moveTo(element *list.Element, listID arcListID) {
+	entry := element.Value.(*arcEntry)
+	cache.lists[entry.listID].Remove(element)
+	entry.listID = listID
+	cache.entries[entry.key] = cache.lists[listID].PushFront(entry)
+}
+
+// dropOldest удаляет старейший элемент списка вместе с весом записи, если это не
+// призрак; вызывается под блокировкой
+func (cache *ARCCache) dropOldest(listID arcListID) {
+	if element := cache.lists[listID].Back(); element != nil {
+		cache.remove(element)
+	}
+}
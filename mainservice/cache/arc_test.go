@@ -0,0 +1,122 @@
+package cache
+
+import "testing"
+
+// arcLengths длины списков ARC: T1, T2, B1, B2
+func arcLengths(cache *ARCCache) [4]int {
+	var lengths [4]int
+	for listID, arcList := range cache.lists {
+		lengths[listID] = arcList.Len()
+	}
+	return lengths
+}
+
+// arcListOf список, в котором находится ключ; -1, если ключа нет
+func arcListOf(cache *ARCCache, key string) arcListID {
+	element, found := cache.entries[key]
+	if !found {
+		return -1
+	}
+	return element.Value.(*arcEntry).listID
+}
+
+func TestARCPromotion(t *testing.T) {
+	cache := NewARCCache(4, ExpirationPolicy{}, ByteBudget{}).(*ARCCache)
+	cache.Set("a", "a")
+	cache.Set("b", "b")
+	if lengths := arcLengths(cache); lengths != [4]int{2, 0, 0, 0} {
+		t.Fatalf("after inserts lists = %v, want both keys in T1", lengths)
+	}
+
+	// Повторное обращение переносит запись в T2, Peek - нет
+	touch(t, cache, "a", 1)
+	cache.Peek("b")
+	if list := arcListOf(cache, "a"); list != arcFrequent {
+		t.Errorf("a is in list %d after Get, want T2", list)
+	}
+	if list := arcListOf(cache, "b"); list != arcRecent {
+		t.Errorf("b is in list %d after Peek, want T1", list)
+	}
+
+	// Обновление записи из T1 тоже повторное обращение
+	cache.Set("b", "b2")
+	if list := arcListOf(cache, "b"); list != arcFrequent {
+		t.Errorf("b is in list %d after update, want T2", list)
+	}
+
+	frequent, recent, ghosts, total := cache.GetStats()
+	if frequent != 2 || recent != 0 || ghosts != 0 || total != 2 {
+		t.Errorf("GetStats = %d, %d, %d, %d, want 2, 0, 0, 2", frequent, recent, ghosts, total)
+	}
+}
+
+func TestARCGhostHitsAdaptTarget(t *testing.T) {
+	cache := NewARCCache(2, ExpirationPolicy{}, ByteBudget{}).(*ARCCache)
+
+	// a - в T2, b вытесняется из T1 в призраки B1 новой записью c
+	cache.Set("a", "a")
+	touch(t, cache, "a", 1)
+	cache.Set("b", "b")
+	cache.Set("c", "c")
+	if lengths := arcLengths(cache); lengths != [4]int{1, 1, 1, 0} {
+		t.Fatalf("lists = %v, want T1=[c] T2=[a] B1=[b]", lengths)
+	}
+	if list := arcListOf(cache, "b"); list != arcRecentGhost {
+		t.Fatalf("b is in list %d, want B1", list)
+	}
+	if _, found := cache.Peek("b"); found {
+		t.Error("ghost b is served from cache")
+	}
+
+	// Промах по B1: T1 был мал, target растет; место освобождает T2
+	cache.Set("b", "b")
+	if cache.target != 1 {
+		t.Errorf("target = %d after a B1 hit, want 1", cache.target)
+	}
+	assertKeys(t, cache, []string{"b", "c"}, []string{"a"})
+	if list := arcListOf(cache, "b"); list != arcFrequent {
+		t.Errorf("b is in list %d after a ghost hit, want T2", list)
+	}
+	if list := arcListOf(cache, "a"); list != arcFrequentGhost {
+		t.Errorf("a is in list %d, want B2", list)
+	}
+
+	// Промах по B2: T2 был мал, target уменьшается; место освобождает T1
+	cache.Set("a", "a")
+	if cache.target != 0 {
+		t.Errorf("target = %d after a B2 hit, want 0", cache.target)
+	}
+	assertKeys(t, cache, []string{"a", "b"}, []string{"c"})
+	if lengths := arcLengths(cache); lengths != [4]int{0, 2, 1, 0} {
+		t.Errorf("lists = %v, want T2=[a b] B1=[c]", lengths)
+	}
+	if evictions := cache.Evictions(); evictions != 3 {
+		t.Errorf("Evictions = %d, want 3", evictions)
+	}
+}
+
+func TestARCGhostsAreBounded(t *testing.T) {
+	const maxSize = 4
+	cache := NewARCCache(maxSize, ExpirationPolicy{}, ByteBudget{}).(*ARCCache)
+	for i := 0; i < 100; i++ {
+		key := string(rune('a' + i%26))
+		cache.Set(key, key)
+		if i%3 == 0 {
+			cache.Get(key)
+		}
+
+		lengths := arcLengths(cache)
+		if cached := lengths[arcRecent] + lengths[arcFrequent]; cached > maxSize {
+			t.Fatalf("step %d: %d cached entries over capacity %d", i, cached, maxSize)
+		}
+		if total := lengths[0] + lengths[1] + lengths[2] + lengths[3]; total > 2*maxSize {
+			t.Fatalf("step %d: %d keys tracked, want at most %d", i, total, 2*maxSize)
+		}
+		if len(cache.entries) != lengths[0]+lengths[1]+lengths[2]+lengths[3] {
+			t.Fatalf("step %d: %d indexed keys, %v in lists", i, len(cache.entries), lengths)
+		}
+		if cache.target < 0 || cache.target > maxSize {
+			t.Fatalf("step %d: target %d out of [0, %d]", i, cache.target, maxSize)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package cache
+
+import "google.golang.org/protobuf/proto"
+
+// itemOverhead примерный размер служебных структур одной записи
+// (CacheItem, элемент списка, запись в map)
+const itemOverhead = 128
+
+// Weigher оценивает, сколько байт памяти занимает запись
+type Weigher func(key string, value interface{}) int64
+
+// ByteBudget ограничение кэша по занимаемой памяти
+type ByteBudget struct {
+	// MaxBytes максимальный суммарный вес записей (0 - без ограничения)
+	MaxBytes int64
+
+	// Weigher оценка веса записи; по умолчанию DefaultWeigher
+	Weigher Weigher
+}
+
+// DefaultWeigher оценивает вес записи: для protobuf сообщений (*api.CommandResponse)
+// используется proto.Size, для строк и байтов - их длина
+func DefaultWeigher(key string, value interface{}) int64 {
+	weight := int64(len(key)) + itemOverhead
+	switch typedValue := value.(type) {
+	case proto.Message:
+		weight += int64(proto.Size(typedValue))
+	case string:
+		weight += int64(len(typedValue))
+	case []byte:
+		weight += int64(len(typedValue))
+	}
+	return weight
+}
+
+// enabled сообщает, ограничен ли кэш по памяти
+func (budget ByteBudget) enabled() bool {
+	return budget.MaxBytes > 0
+}
+
+// weigh возвращает вес записи; без ограничения по памяти вес не считается
+func (budget ByteBudget) weigh(key string, value interface{}) int64 {
+	if !budget.enabled() {
+		return 0
+	}
+	if budget.Weigher != nil {
+		return budget.Weigher(key, value)
+	}
+	return DefaultWeigher(key, value)
+}
+
+// fits сообщает, может ли запись такого веса вообще поместиться в кэш
+func (budget ByteBudget) fits(weight int64) bool {
+	return !budget.enabled() || weight <= budget.MaxBytes
+}
+
+// exceeds сообщает, превысит ли добавление веса extra бюджет при занятых used байтах
+func (budget ByteBudget) exceeds(used, extra int64) bool {
+	return budget.enabled() && used+extra > budget.MaxBytes
+}
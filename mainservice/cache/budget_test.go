@@ -0,0 +1,234 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// allCacheTypes все политики вытеснения фабрики NewCache
+var allCacheTypes = []CacheType{FIFO3CacheType, LRUCacheType, LFUCacheType, ARCCacheType}
+
+// liveBytes суммарный вес записей, которые кэш действительно хранит (без призраков ARC)
+func liveBytes(t *testing.T, cache Cache) int64 {
+	t.Helper()
+	var total int64
+	switch typed := cache.(type) {
+	case *FIFO3Cache:
+		for _, shard := range typed.shards {
+			for _, element := range shard.items {
+				total += element.Value.(*CacheItem).weight
+			}
+		}
+	case *LRUCache:
+		for _, element := range typed.items {
+			total += element.Value.(*CacheItem).weight
+		}
+	case *LFUCache:
+		for _, element := range typed.items {
+			total += element.Value.(*CacheItem).weight
+		}
+	case *ARCCache:
+		for _, listID := range []arcListID{arcRecent, arcFrequent} {
+			for element := typed.lists[listID].Front(); element != nil; element = element.Next() {
+				total += element.Value.(*arcEntry).item.weight
+			}
+		}
+	default:
+		t.Fatalf("unknown cache type %T", cache)
+	}
+	return total
+}
+
+// TestUsedBytesAfterChurn учет памяти не расходится с записями после длительной работы:
+// вытеснения, обновления, удаления и повторные обращения к ключам
+func TestUsedBytesAfterChurn(t *testing.T) {
+	const (
+		maxSize    = 4
+		maxBytes   = 10000
+		operations = 2000
+		keySpace   = 60
+	)
+
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := NewCache(Config{Type: cacheType, MaxSize: maxSize, MaxBytes: maxBytes})
+			reporter := cache.(byteReporter)
+			random := rand.New(rand.NewPCG(1, 2))
+
+			for i := 0; i < operations; i++ {
+				key := fmt.Sprintf("org:%d", random.IntN(keySpace))
+				switch random.IntN(10) {
+				case 0:
+					cache.Remove(key)
+				case 1, 2, 3:
+					cache.Get(key)
+				default:
+					cache.Set(key, key)
+				}
+
+				if used, live := reporter.UsedBytes(), liveBytes(t, cache); used != live {
+					t.Fatalf("operation %d: UsedBytes = %d, live entries weigh %d", i, used, live)
+				}
+				if used := reporter.UsedBytes(); used > maxBytes {
+					t.Fatalf("operation %d: UsedBytes = %d over budget %d", i, used, maxBytes)
+				}
+			}
+
+			// Веса записей малы: бюджет по памяти не должен сокращать кэш ниже maxSize
+			for i := 0; i < maxSize*3; i++ {
+				cache.Set(fmt.Sprintf("fill:%d", i), "value")
+			}
+			if size := cache.Size(); size != maxSize {
+				t.Errorf("Size = %d after filling, want %d", size, maxSize)
+			}
+		})
+	}
+}
+
+// lengthWeigher вес записи - длина строкового значения: веса в тестах точно известны
+func lengthWeigher(key string, value interface{}) int64 {
+	return int64(len(value.(string)))
+}
+
+// newBudgetCache кэш с большим MaxSize: вытесняет только бюджет памяти
+func newBudgetCache(cacheType CacheType, maxBytes int64) Cache {
+	return NewCache(Config{Type: cacheType, MaxSize: 100, MaxBytes: maxBytes, Weigher: lengthWeigher})
+}
+
+func TestByteBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		budget  ByteBudget
+		weight  int64
+		used    int64
+		fits    bool
+		exceeds bool
+	}{
+		{name: "unlimited", budget: ByteBudget{}, weight: 1 << 40, used: 1 << 40, fits: true, exceeds: false},
+		{name: "fits exactly", budget: ByteBudget{MaxBytes: 100}, weight: 100, used: 0, fits: true, exceeds: false},
+		{name: "larger than the whole budget", budget: ByteBudget{MaxBytes: 100}, weight: 101, used: 0, fits: false, exceeds: true},
+		{name: "fits, but not next to used bytes", budget: ByteBudget{MaxBytes: 100}, weight: 50, used: 51, fits: true, exceeds: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if fits := test.budget.fits(test.weight); fits != test.fits {
+				t.Errorf("fits(%d) = %t, want %t", test.weight, fits, test.fits)
+			}
+			if exceeds := test.budget.exceeds(test.used, test.weight); exceeds != test.exceeds {
+				t.Errorf("exceeds(%d, %d) = %t, want %t", test.used, test.weight, exceeds, test.exceeds)
+			}
+		})
+	}
+
+	if weight := (ByteBudget{}).weigh("key", "value"); weight != 0 {
+		t.Errorf("unlimited budget weighs entries: %d", weight)
+	}
+	if weight, want := (ByteBudget{MaxBytes: 1}).weigh("key", "value"), int64(len("key")+len("value")+itemOverhead); weight != want {
+		t.Errorf("DefaultWeigher = %d, want %d", weight, want)
+	}
+}
+
+func TestCacheRejectsOversizedEntry(t *testing.T) {
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := newBudgetCache(cacheType, 100)
+			cache.Set("small", strings.Repeat("s", 10))
+			cache.Set("key", strings.Repeat("x", 50))
+
+			// Новое значение больше всего бюджета: оно не кэшируется, а старое уже неверно
+			cache.Set("key", strings.Repeat("x", 101))
+			cache.Set("huge", strings.Repeat("h", 101))
+			assertKeys(t, cache, []string{"small"}, []string{"key", "huge"})
+			if used := cache.(byteReporter).UsedBytes(); used != 10 {
+				t.Errorf("UsedBytes = %d, want 10", used)
+			}
+		})
+	}
+}
+
+func TestCacheEvictsByWeight(t *testing.T) {
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := newBudgetCache(cacheType, 100)
+			reporter := cache.(byteReporter)
+			for i := 0; i < 10; i++ {
+				cache.Set(fmt.Sprintf("key:%d", i), strings.Repeat("v", 30))
+				if used := reporter.UsedBytes(); used > reporter.MaxBytes() {
+					t.Fatalf("UsedBytes = %d over MaxBytes %d", used, reporter.MaxBytes())
+				}
+			}
+			if size, used := cache.Size(), reporter.UsedBytes(); size != 3 || used != 90 {
+				t.Errorf("Size = %d, UsedBytes = %d, want 3 entries of 90 bytes", size, used)
+			}
+
+			// Тяжелая запись вытесняет столько легких, сколько нужно
+			cache.Set("heavy", strings.Repeat("h", 80))
+			if _, found := cache.Peek("heavy"); !found {
+				t.Fatal("heavy entry was not cached")
+			}
+			if size, used := cache.Size(), reporter.UsedBytes(); size != 1 || used != 80 {
+				t.Errorf("Size = %d, UsedBytes = %d, want only the heavy entry", size, used)
+			}
+			if used, live := reporter.UsedBytes(), liveBytes(t, cache); used != live {
+				t.Errorf("UsedBytes = %d, live entries weigh %d", used, live)
+			}
+		})
+	}
+}
+
+func TestCacheReplaceAdjustsWeight(t *testing.T) {
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := newBudgetCache(cacheType, 100)
+			reporter := cache.(byteReporter)
+			// b старше: FIFO3 при обновлении сохраняет место записи в очереди
+			cache.Set("b", strings.Repeat("b", 20))
+			cache.Set("a", strings.Repeat("a", 20))
+
+			cache.Set("a", strings.Repeat("a", 5))
+			if used := reporter.UsedBytes(); used != 25 {
+				t.Errorf("UsedBytes = %d after shrinking a, want 25", used)
+			}
+			cache.Set("a", strings.Repeat("a", 60))
+			if used := reporter.UsedBytes(); used != 80 {
+				t.Errorf("UsedBytes = %d after growing a, want 80", used)
+			}
+
+			// Выросшая запись не помещается рядом с b: вытесняется b, а не она сама
+			cache.Set("a", strings.Repeat("a", 90))
+			assertKeys(t, cache, []string{"a"}, []string{"b"})
+			if used := reporter.UsedBytes(); used != 90 {
+				t.Errorf("UsedBytes = %d after growing a over the budget, want 90", used)
+			}
+		})
+	}
+}
+
+func TestCacheMetricsReportBytes(t *testing.T) {
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := NewCacheWithMetrics(Config{Type: cacheType, MaxSize: 10, MaxBytes: 100, Weigher: lengthWeigher})
+			cache.Set("a", strings.Repeat("a", 30))
+			cache.Set("b", strings.Repeat("b", 12))
+
+			metrics := cache.GetMetrics()
+			if metrics.Type != cacheType {
+				t.Errorf("Type = %s, want %s", metrics.Type, cacheType)
+			}
+			if metrics.UsedBytes != 42 || metrics.MaxBytes != 100 {
+				t.Errorf("UsedBytes = %d, MaxBytes = %d, want 42 and 100", metrics.UsedBytes, metrics.MaxBytes)
+			}
+			if text := metrics.String(); !strings.Contains(text, "Bytes=42/100") {
+				t.Errorf("String() = %q, want it to report Bytes=42/100", text)
+			}
+
+			unlimited := NewCacheWithMetrics(Config{Type: cacheType, MaxSize: 10}).GetMetrics()
+			if unlimited.MaxBytes != 0 || strings.Contains(unlimited.String(), "Bytes=") {
+				t.Errorf("unlimited cache reports a memory limit: %s", unlimited.String())
+			}
+		})
+	}
+}
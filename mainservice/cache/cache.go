@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"container/list"
+	"hash/maphash"
 	"sync"
 	"time"
 )
@@ -10,9 +12,20 @@ type Cache interface {
 	// Get возвращает значение по ключу и флаг наличия
 	Get(key string) (interface{}, bool)
 	
-	// Set устанавливает значение по ключу
+	// GetStale возвращает значение, даже если его время жизни истекло, но еще не вышло
+	// окно stale-while-revalidate; fresh сообщает, не истекло ли время жизни
+	GetStale(key string) (value interface{}, fresh bool, found bool)
+	
+	// Peek возвращает значение, которое кэш еще может отдать (в том числе устаревшее),
+	// не учитывая обращение в метриках и не меняя порядок вытеснения
+	Peek(key string) (interface{}, bool)
+	
+	// Set устанавливает значение по ключу со временем жизни из политики кэша
 	Set(key string, value interface{})
 	
+	// SetWithTTL устанавливает значение с собственным временем жизни (0 - без ограничения)
+	SetWithTTL(key string, value interface{}, ttl time.Duration)
+	
 	// Remove удаляет значение по ключу
 	Remove(key string)
 	
@@ -35,147 +48,287 @@ type CacheItem struct {
 	value       interface{}
 	createdAt   time.Time
 	lastAccess  time.Time
+	expiresAt   time.Time // Нулевое значение - без ограничения времени жизни
 	accessCount int
+	weight      int64      // Оценка занимаемой памяти, если кэш ограничен по байтам
+	level       fifo3Level // Уровень FIFO3, в очереди которого находится элемент
 }
 
-// FIFO3Cache реализует алгоритм FIFO с тремя уровнями приоритета
+// fifo3Level уровень приоритета FIFO3
+type fifo3Level int
+
+const (
+	hotLevel  fifo3Level = iota // Уровень 1: горячие данные (часто запрашиваемые)
+	warmLevel                   // Уровень 2: теплые данные
+	coldLevel                   // Уровень 3: холодные данные, сюда попадают новые записи
+)
+
+const (
+	// warmPromotionAccesses после стольких обращений запись переходит с уровня 3 на уровень 2
+	warmPromotionAccesses = 3
+
+	// hotPromotionAccesses после стольких обращений запись переходит с уровня 2 на уровень 1
+	hotPromotionAccesses = 5
+
+	// maxFIFO3Shards максимальное число сегментов FIFO3 кэша
+	maxFIFO3Shards = 16
+
+	// minFIFO3ShardSize меньше стольких записей на сегмент кэш не делится,
+	// иначе вытеснение внутри сегмента слишком далеко от общего FIFO
+	minFIFO3ShardSize = 64
+)
+
+// FIFO3Cache реализует алгоритм FIFO с тремя уровнями приоритета.
+// Ключи распределяются по сегментам по хэшу, у каждого сегмента своя блокировка
+// и своя доля общего размера, поэтому параллельные запросы к разным ключам не конкурируют.
 type FIFO3Cache struct {
-	mu          sync.RWMutex
-	level1      map[string]*CacheItem // Горячие данные (часто запрашиваемые)
-	level2      map[string]*CacheItem // Теплые данные
-	level3      map[string]*CacheItem // Холодные данные
-	maxSize     int
-	currentSize int
+	shards     []*fifo3Shard
+	seed       maphash.Seed
+	maxSize    int
+	expiration ExpirationPolicy
+	budget     ByteBudget
+}
+
+// fifo3Shard сегмент FIFO3 кэша: три очереди в порядке поступления на уровень
+type fifo3Shard struct {
+	mu        sync.Mutex
+	items     map[string]*list.Element // Значения элементов - *CacheItem
+	levels    [3]*list.List            // Начало очереди - кандидаты на вытеснение
+	maxSize   int
+	budget    ByteBudget // Доля общего бюджета памяти, приходящаяся на сегмент
+	usedBytes int64
+	evictions int
 }
 
-// NewFIFO3Cache создает новый FIFO3 кэш
+// NewFIFO3Cache создает новый FIFO3 кэш без ограничения времени жизни записей
 func NewFIFO3Cache(maxSize int) Cache {
-	return &FIFO3Cache{
-		level1:  make(map[string]*CacheItem),
-		level2:  make(map[string]*CacheItem),
-		level3:  make(map[string]*CacheItem),
-		maxSize: maxSize,
-	}
+	return NewFIFO3CacheWithExpiration(maxSize, ExpirationPolicy{})
 }
 
-// Get возвращает значение по ключу
-func (cache *FIFO3Cache) Get(key string) (interface{}, bool) {
-	cache.mu.RLock()
-	defer cache.mu.RUnlock()
+// NewFIFO3CacheWithExpiration создает FIFO3 кэш с политикой времени жизни записей
+func NewFIFO3CacheWithExpiration(maxSize int, expiration ExpirationPolicy) Cache {
+	return NewFIFO3CacheWithBudget(maxSize, expiration, ByteBudget{})
+}
 
-	if item, found := cache.level1[key]; found {
-		item.lastAccess = time.Now()
-		item.accessCount++
-		return item.value, true
+// NewFIFO3CacheWithBudget создает FIFO3 кэш, ограниченный и по числу записей, и по памяти
+func NewFIFO3CacheWithBudget(maxSize int, expiration ExpirationPolicy, budget ByteBudget) Cache {
+	shardCount := maxFIFO3Shards
+	for shardCount > 1 && maxSize/shardCount < minFIFO3ShardSize {
+		shardCount /= 2
 	}
+	return newFIFO3Cache(maxSize, shardCount, expiration, budget)
+}
 
-	if item, found := cache.level2[key]; found {
-		item.lastAccess = time.Now()
-		item.accessCount++
-		if item.accessCount > 5 {
-			cache.promoteToLevel1(key, item)
+// newFIFO3Cache создает FIFO3 кэш с заданным числом сегментов
+func newFIFO3Cache(maxSize int, shardCount int, expiration ExpirationPolicy, budget ByteBudget) *FIFO3Cache {
+	cache := &FIFO3Cache{
+		shards:     make([]*fifo3Shard, shardCount),
+		seed:       maphash.MakeSeed(),
+		maxSize:    maxSize,
+		expiration: expiration,
+		budget:     budget,
+	}
+	for i := range cache.shards {
+		// Остаток от деления распределяем по первым сегментам, чтобы сумма была равна maxSize
+		shardSize := maxSize / shardCount
+		if i < maxSize%shardCount {
+			shardSize++
+		}
+		shardBudget := budget
+		if budget.enabled() {
+			shardBudget.MaxBytes = max(budget.MaxBytes/int64(shardCount), 1)
 		}
-		return item.value, true
+		shard := &fifo3Shard{
+			items:   make(map[string]*list.Element),
+			maxSize: shardSize,
+			budget:  shardBudget,
+		}
+		for level := range shard.levels {
+			shard.levels[level] = list.New()
+		}
+		cache.shards[i] = shard
 	}
+	return cache
+}
+
+// shard возвращает сегмент, в котором хранится ключ
+func (cache *FIFO3Cache) shard(key string) *fifo3Shard {
+	return cache.shards[maphash.String(cache.seed, key)%uint64(len(cache.shards))]
+}
 
-	if item, found := cache.level3[key]; found {
-		item.lastAccess = time.Now()
-		item.accessCount++
-		if item.accessCount > 3 {
-			cache.promoteToLevel2(key, item)
+// Get возвращает значение по ключу, если его время жизни не истекло
+func (cache *FIFO3Cache) Get(key string) (interface{}, bool) {
+	shard := cache.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	element, found := shard.items[key]
+	if !found {
+		return nil, false
+	}
+	item := element.Value.(*CacheItem)
+	now := time.Now()
+	if item.expired(now) {
+		// Запись, которую уже нельзя отдать даже устаревшей, сразу освобождает место
+		if !cache.expiration.servableStale(item, now) {
+			shard.remove(element)
 		}
-		return item.value, true
+		return nil, false
 	}
 
-	return nil, false
+	item.lastAccess = now
+	item.accessCount++
+	switch {
+	case item.level == coldLevel && item.accessCount > warmPromotionAccesses:
+		shard.moveToLevel(element, warmLevel)
+	case item.level == warmLevel && item.accessCount > hotPromotionAccesses:
+		shard.moveToLevel(element, hotLevel)
+	}
+	return item.value, true
+}
+
+// GetStale возвращает значение с учетом окна stale-while-revalidate
+func (cache *FIFO3Cache) GetStale(key string) (interface{}, bool, bool) {
+	if value, found := cache.Get(key); found {
+		return value, true, true
+	}
+
+	shard := cache.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	element, found := shard.items[key]
+	if !found {
+		return nil, false, false
+	}
+	item := element.Value.(*CacheItem)
+	if !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false, false
+	}
+	return item.value, false, true
+}
+
+// Peek возвращает значение без учета обращения: уровень записи не меняется
+func (cache *FIFO3Cache) Peek(key string) (interface{}, bool) {
+	shard := cache.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	element, found := shard.items[key]
+	if !found {
+		return nil, false
+	}
+	item := element.Value.(*CacheItem)
+	if !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false
+	}
+	return item.value, true
 }
 
 // Set устанавливает значение по ключу
 func (cache *FIFO3Cache) Set(key string, value interface{}) {
-	cache.mu.Lock()
-	defer cache.mu.Unlock()
+	cache.SetWithTTL(key, value, cache.expiration.TTLFor(key))
+}
+
+// SetWithTTL устанавливает значение по ключу с заданным временем жизни
+func (cache *FIFO3Cache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	shard := cache.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	now := time.Now()
+	expiresAt := expirationTime(now, ttl)
+	weight := shard.budget.weigh(key, value)
 
-	// Обновляем существующий элемент если есть
-	if _, exists := cache.level1[key]; exists {
-		cache.level1[key].value = value
-		cache.level1[key].lastAccess = time.Now()
+	element, found := shard.items[key]
+	if !shard.budget.fits(weight) {
+		// Запись больше всего бюджета сегмента не кэшируется; старое значение устарело
+		if found {
+			shard.remove(element)
+		}
 		return
 	}
-	if _, exists := cache.level2[key]; exists {
-		cache.level2[key].value = value
-		cache.level2[key].lastAccess = time.Now()
+
+	// Обновляем существующий элемент если есть; место в очереди не меняется
+	if found {
+		item := element.Value.(*CacheItem)
+		item.value = value
+		item.lastAccess = now
+		item.expiresAt = expiresAt
+		shard.usedBytes += weight - item.weight
+		item.weight = weight
+		for shard.budget.exceeds(shard.usedBytes, 0) && len(shard.items) > 1 {
+			shard.evict()
+		}
 		return
 	}
-	if _, exists := cache.level3[key]; exists {
-		cache.level3[key].value = value
-		cache.level3[key].lastAccess = time.Now()
-		return
+
+	// Вытесняем элементы если нужно
+	for (len(shard.items) >= shard.maxSize || shard.budget.exceeds(shard.usedBytes, weight)) && len(shard.items) > 0 {
+		shard.evict()
 	}
 
-	// Создаем новый элемент
+	// Новый элемент попадает в конец очереди холодного уровня
 	item := &CacheItem{
 		key:         key,
 		value:       value,
-		createdAt:   time.Now(),
-		lastAccess:  time.Now(),
+		createdAt:   now,
+		lastAccess:  now,
+		expiresAt:   expiresAt,
 		accessCount: 1,
+		weight:      weight,
+		level:       coldLevel,
 	}
-
-	// Вытесняем элемент если нужно
-	if cache.currentSize >= cache.maxSize {
-		cache.evict()
-	}
-
-	cache.level3[key] = item
-	cache.currentSize++
+	shard.items[key] = shard.levels[coldLevel].PushBack(item)
+	shard.usedBytes += weight
 }
 
 // Remove удаляет значение по ключу
 func (cache *FIFO3Cache) Remove(key string) {
-	cache.mu.Lock()
-	defer cache.mu.Unlock()
+	shard := cache.shard(key)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
-	if _, exists := cache.level1[key]; exists {
-		delete(cache.level1, key)
-		cache.currentSize--
-		return
-	}
-	if _, exists := cache.level2[key]; exists {
-		delete(cache.level2, key)
-		cache.currentSize--
-		return
-	}
-	if _, exists := cache.level3[key]; exists {
-		delete(cache.level3, key)
-		cache.currentSize--
-		return
+	if element, found := shard.items[key]; found {
+		shard.remove(element)
 	}
 }
 
 // Clear очищает весь кэш
 func (cache *FIFO3Cache) Clear() {
-	cache.mu.Lock()
-	defer cache.mu.Unlock()
-
-	cache.level1 = make(map[string]*CacheItem)
-	cache.level2 = make(map[string]*CacheItem)
-	cache.level3 = make(map[string]*CacheItem)
-	cache.currentSize = 0
+	for _, shard := range cache.shards {
+		shard.mu.Lock()
+		shard.items = make(map[string]*list.Element)
+		for _, queue := range shard.levels {
+			queue.Init()
+		}
+		shard.usedBytes = 0
+		shard.mu.Unlock()
+	}
 }
 
 // GetStats возвращает статистику кэша
 func (cache *FIFO3Cache) GetStats() (int, int, int, int) {
-	cache.mu.RLock()
-	defer cache.mu.RUnlock()
-
-	return len(cache.level1), len(cache.level2), len(cache.level3), cache.currentSize
+	var level1, level2, level3 int
+	for _, shard := range cache.shards {
+		shard.mu.Lock()
+		level1 += shard.levels[hotLevel].Len()
+		level2 += shard.levels[warmLevel].Len()
+		level3 += shard.levels[coldLevel].Len()
+		shard.mu.Unlock()
+	}
+	return level1, level2, level3, level1 + level2 + level3
 }
 
 // Size возвращает текущий размер кэша
 func (cache *FIFO3Cache) Size() int {
-	cache.mu.RLock()
-	defer cache.mu.RUnlock()
-	return cache.currentSize
+	size := 0
+	for _, shard := range cache.shards {
+		shard.mu.Lock()
+		size += len(shard.items)
+		shard.mu.Unlock()
+	}
+	return size
 }
 
 // MaxSize возвращает максимальный размер кэша
@@ -183,47 +336,57 @@ func (cache *FIFO3Cache) MaxSize() int {
 	return cache.maxSize
 }
 
-// promoteToLevel1 перемещает элемент на уровень 1
-func (cache *FIFO3Cache) promoteToLevel1(key string, item *CacheItem) {
-	cache.mu.Lock()
-	defer cache.mu.Unlock()
-
-	delete(cache.level2, key)
-	cache.level1[key] = item
+// UsedBytes возвращает суммарный вес записей
+func (cache *FIFO3Cache) UsedBytes() int64 {
+	var usedBytes int64
+	for _, shard := range cache.shards {
+		shard.mu.Lock()
+		usedBytes += shard.usedBytes
+		shard.mu.Unlock()
+	}
+	return usedBytes
 }
 
-// promoteToLevel2 перемещает элемент на уровень 2
-func (cache *FIFO3Cache) promoteToLevel2(key string, item *CacheItem) {
-	cache.mu.Lock()
-	defer cache.mu.Unlock()
-
-	delete(cache.level3, key)
-	cache.level2[key] = item
+// MaxBytes возвращает ограничение кэша по памяти (0 - без ограничения)
+func (cache *FIFO3Cache) MaxBytes() int64 {
+	return cache.budget.MaxBytes
 }
 
-// evict вытесняет один элемент по алгоритму FIFO
-func (cache *FIFO3Cache) evict() {
-	if len(cache.level3) > 0 {
-		for key := range cache.level3 {
-			delete(cache.level3, key)
-			cache.currentSize--
-			return
-		}
+// Evictions возвращает число вытесненных записей
+func (cache *FIFO3Cache) Evictions() int {
+	evictions := 0
+	for _, shard := range cache.shards {
+		shard.mu.Lock()
+		evictions += shard.evictions
+		shard.mu.Unlock()
 	}
+	return evictions
+}
 
-	if len(cache.level2) > 0 {
-		for key := range cache.level2 {
-			delete(cache.level2, key)
-			cache.currentSize--
-			return
-		}
-	}
+// moveToLevel переносит элемент в конец очереди другого уровня; вызывается под блокировкой
+func (shard *fifo3Shard) moveToLevel(element *list.Element, level fifo3Level) {
+	item := element.Value.(*CacheItem)
+	shard.levels[item.level].Remove(element)
+	item.level = level
+	shard.items[item.key] = shard.levels[level].PushBack(item)
+}
+
+// remove удаляет элемент из сегмента; вызывается под блокировкой
+func (shard *fifo3Shard) remove(element *list.Element) {
+	item := element.Value.(*CacheItem)
+	shard.levels[item.level].Remove(element)
+	delete(shard.items, item.key)
+	shard.usedBytes -= item.weight
+}
 
-	if len(cache.level1) > 0 {
-		for key := range cache.level1 {
-			delete(cache.level1, key)
-			cache.currentSize--
+// evict вытесняет старейший элемент самого холодного непустого уровня;
+// вызывается под блокировкой
+func (shard *fifo3Shard) evict() {
+	for _, level := range []fifo3Level{coldLevel, warmLevel, hotLevel} {
+		if element := shard.levels[level].Front(); element != nil {
+			shard.remove(element)
+			shard.evictions++
 			return
 		}
 	}
-}
\ No newline at end of file
+}
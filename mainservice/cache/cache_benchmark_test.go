@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"testing"
+)
+
+const (
+	// benchmarkConcurrentLookups число одновременных горутин, выполняющих поиск
+	benchmarkConcurrentLookups = 10000
+
+	benchmarkCacheSize = 1000
+	benchmarkKeySpace  = 4000
+)
+
+// benchmarkKeys ключи вида организаций; распределение запросов неравномерное,
+// как у реального трафика поиска организаций
+var benchmarkKeys = func() []string {
+	keys := make([]string, benchmarkKeySpace)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("org:%d", i)
+	}
+	return keys
+}()
+
+func benchmarkKey(random *rand.Rand) string {
+	return benchmarkKeys[min(int(random.ExpFloat64()*benchmarkKeySpace/8), benchmarkKeySpace-1)]
+}
+
+// runConcurrentLookups выполняет поиск с долей промахов, заполняемых через Set,
+// в benchmarkConcurrentLookups горутинах
+func runConcurrentLookups(b *testing.B, cache Cache) {
+	for _, key := range benchmarkKeys[:benchmarkCacheSize] {
+		cache.Set(key, key)
+	}
+
+	b.SetParallelism((benchmarkConcurrentLookups + runtime.GOMAXPROCS(0) - 1) / runtime.GOMAXPROCS(0))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		random := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+		for pb.Next() {
+			key := benchmarkKey(random)
+			if _, found := cache.Get(key); !found {
+				cache.Set(key, key)
+			}
+		}
+	})
+}
+
+// BenchmarkFIFO3CacheConcurrentLookups сравнивает сегментированный FIFO3 с одним сегментом
+func BenchmarkFIFO3CacheConcurrentLookups(b *testing.B) {
+	for _, shardCount := range []int{1, 4, maxFIFO3Shards} {
+		b.Run(fmt.Sprintf("shards=%d", shardCount), func(b *testing.B) {
+			runConcurrentLookups(b, newFIFO3Cache(benchmarkCacheSize, shardCount, ExpirationPolicy{}, ByteBudget{}))
+		})
+	}
+}
+
+// BenchmarkCacheConcurrentLookups сравнивает все типы кэша с подсчетом метрик
+func BenchmarkCacheConcurrentLookups(b *testing.B) {
+	for _, cacheType := range []CacheType{FIFO3CacheType, LRUCacheType, LFUCacheType, ARCCacheType} {
+		b.Run(string(cacheType), func(b *testing.B) {
+			cache := NewCacheWithMetrics(Config{Type: cacheType, MaxSize: benchmarkCacheSize})
+			runConcurrentLookups(b, cache)
+			b.ReportMetric(cache.GetMetrics().HitRate*100, "hit%")
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+)
+
+// newSingleShardCache FIFO3 кэш из одного сегмента: порядок вытеснения полностью предсказуем
+func newSingleShardCache(maxSize int) *FIFO3Cache {
+	return newFIFO3Cache(maxSize, 1, ExpirationPolicy{}, ByteBudget{})
+}
+
+// touch обращается к ключу count раз
+func touch(t *testing.T, cache Cache, key string, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		if _, found := cache.Get(key); !found {
+			t.Fatalf("Get(%q) missed on access %d", key, i+1)
+		}
+	}
+}
+
+// assertKeys проверяет набор ключей в кэше через Peek, не меняя уровни записей
+func assertKeys(t *testing.T, cache Cache, present []string, absent []string) {
+	t.Helper()
+	for _, key := range present {
+		if _, found := cache.Peek(key); !found {
+			t.Errorf("key %q was evicted, expected it to stay", key)
+		}
+	}
+	for _, key := range absent {
+		if _, found := cache.Peek(key); found {
+			t.Errorf("key %q is still cached, expected it to be evicted", key)
+		}
+	}
+}
+
+func TestFIFO3EvictionOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxSize int
+		steps   func(t *testing.T, cache Cache)
+		present []string
+		absent  []string
+		levels  [3]int // Ожидаемые размеры уровней: горячий, теплый, холодный
+	}{
+		{
+			name:    "new entries leave the cold level in insertion order",
+			maxSize: 3,
+			steps: func(t *testing.T, cache Cache) {
+				for _, key := range []string{"a", "b", "c", "d", "e"} {
+					cache.Set(key, key)
+				}
+			},
+			present: []string{"c", "d", "e"},
+			absent:  []string{"a", "b"},
+			levels:  [3]int{0, 0, 3},
+		},
+		{
+			name:    "warm entry outlives newer cold entries",
+			maxSize: 3,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+				touch(t, cache, "a", warmPromotionAccesses)
+				cache.Set("d", "d")
+				cache.Set("e", "e")
+			},
+			present: []string{"a", "d", "e"},
+			absent:  []string{"b", "c"},
+			levels:  [3]int{0, 1, 2},
+		},
+		{
+			name:    "warm level is evicted before hot once cold is empty",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("hot", "hot")
+				cache.Set("warm", "warm")
+				touch(t, cache, "hot", warmPromotionAccesses+2)
+				touch(t, cache, "warm", warmPromotionAccesses)
+				cache.Set("new", "new")
+			},
+			present: []string{"hot", "new"},
+			absent:  []string{"warm"},
+			levels:  [3]int{1, 0, 1},
+		},
+		{
+			name:    "updating a key keeps its place in the queue",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("a", "a2")
+				cache.Set("c", "c")
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+			levels:  [3]int{0, 0, 2},
+		},
+		{
+			name:    "peek does not promote",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				for i := 0; i < hotPromotionAccesses+1; i++ {
+					cache.Peek("a")
+				}
+				cache.Set("c", "c")
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+			levels:  [3]int{0, 0, 2},
+		},
+		{
+			name:    "removed key frees its slot",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Remove("a")
+				cache.Set("c", "c")
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+			levels:  [3]int{0, 0, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := newSingleShardCache(test.maxSize)
+			test.steps(t, cache)
+			assertKeys(t, cache, test.present, test.absent)
+
+			hot, warm, cold, total := cache.GetStats()
+			if [3]int{hot, warm, cold} != test.levels {
+				t.Errorf("levels = %v, want %v", [3]int{hot, warm, cold}, test.levels)
+			}
+			if total != cache.Size() {
+				t.Errorf("GetStats total = %d, Size = %d", total, cache.Size())
+			}
+		})
+	}
+}
+
+func TestFIFO3ShardCapacity(t *testing.T) {
+	tests := []struct {
+		maxSize int
+		shards  int
+	}{
+		{maxSize: 10, shards: 1},
+		{maxSize: 127, shards: 1},
+		{maxSize: 128, shards: 2},
+		{maxSize: 1000, shards: 8},
+		{maxSize: 1024, shards: 16},
+		{maxSize: 100000, shards: maxFIFO3Shards},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("size %d", test.maxSize), func(t *testing.T) {
+			cache := NewFIFO3Cache(test.maxSize).(*FIFO3Cache)
+			if len(cache.shards) != test.shards {
+				t.Fatalf("shards = %d, want %d", len(cache.shards), test.shards)
+			}
+
+			capacity := 0
+			for _, shard := range cache.shards {
+				capacity += shard.maxSize
+			}
+			if capacity != test.maxSize {
+				t.Fatalf("shard capacities sum to %d, want %d", capacity, test.maxSize)
+			}
+
+			// Ключей заметно больше емкости: каждый сегмент заполняется до предела, но не сверх
+			for i := 0; i < test.maxSize*4; i++ {
+				cache.Set(fmt.Sprintf("key:%d", i), i)
+			}
+			for index, shard := range cache.shards {
+				if len(shard.items) != shard.maxSize {
+					t.Errorf("shard %d holds %d items, capacity %d", index, len(shard.items), shard.maxSize)
+				}
+			}
+			if cache.Size() != test.maxSize {
+				t.Errorf("Size = %d, want %d", cache.Size(), test.maxSize)
+			}
+			if evictions := cache.Evictions(); evictions != test.maxSize*3 {
+				t.Errorf("Evictions = %d, want %d", evictions, test.maxSize*3)
+			}
+		})
+	}
+}
+
+// TestFIFO3ConcurrentAccess предназначен для запуска с -race: параллельные Get, Set,
+// Peek и Remove не должны гоняться за данными и нарушать структуру сегментов
+func TestFIFO3ConcurrentAccess(t *testing.T) {
+	const (
+		workers    = 32
+		operations = 5000
+		keySpace   = 2000
+	)
+	cache := NewFIFO3Cache(1024).(*FIFO3Cache)
+
+	var group sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		group.Add(1)
+		go func(seed uint64) {
+			defer group.Done()
+			random := rand.New(rand.NewPCG(seed, seed))
+			for i := 0; i < operations; i++ {
+				key := fmt.Sprintf("key:%d", random.IntN(keySpace))
+				switch random.IntN(10) {
+				case 0:
+					cache.Remove(key)
+				case 1, 2, 3:
+					cache.Set(key, key)
+				case 4:
+					cache.Peek(key)
+				default:
+					if value, found := cache.Get(key); found && value != key {
+						t.Errorf("Get(%q) = %v", key, value)
+					}
+				}
+			}
+		}(uint64(worker))
+	}
+	group.Wait()
+
+	for index, shard := range cache.shards {
+		queued := 0
+		for level, queue := range shard.levels {
+			queued += queue.Len()
+			for element := queue.Front(); element != nil; element = element.Next() {
+				item := element.Value.(*CacheItem)
+				if item.level != fifo3Level(level) {
+					t.Errorf("shard %d: item %q queued on level %d, marked %d", index, item.key, level, item.level)
+				}
+				if shard.items[item.key] != element {
+					t.Errorf("shard %d: item %q is queued but not indexed", index, item.key)
+				}
+			}
+		}
+		if queued != len(shard.items) {
+			t.Errorf("shard %d: %d queued items, %d indexed", index, queued, len(shard.items))
+		}
+		if len(shard.items) > shard.maxSize {
+			t.Errorf("shard %d: %d items over capacity %d", index, len(shard.items), shard.maxSize)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"strings"
+	"time"
+)
+
+// ExpirationPolicy определяет время жизни записей кэша
+type ExpirationPolicy struct {
+	// DefaultTTL время жизни записей без подходящего префикса (0 - без ограничения)
+	DefaultTTL time.Duration
+
+	// PrefixTTLs время жизни по префиксу ключа; выбирается самый длинный совпавший префикс
+	PrefixTTLs map[string]time.Duration
+
+	// StaleWhileRevalidate сколько после истечения времени жизни запись еще отдается
+	// через GetStale, пока вызывающая сторона обновляет ее в фоне (0 - не отдается)
+	StaleWhileRevalidate time.Duration
+}
+
+// TTLFor возвращает время жизни для ключа
+func (policy ExpirationPolicy) TTLFor(key string) time.Duration {
+	ttl := policy.DefaultTTL
+	matchedLength := -1
+	for prefix, prefixTTL := range policy.PrefixTTLs {
+		if len(prefix) > matchedLength && strings.HasPrefix(key, prefix) {
+			ttl = prefixTTL
+			matchedLength = len(prefix)
+		}
+	}
+	return ttl
+}
+
+// servableStale сообщает, можно ли еще отдать истекшую запись
+func (policy ExpirationPolicy) servableStale(item *CacheItem, now time.Time) bool {
+	if !item.expired(now) {
+		return true
+	}
+	return policy.StaleWhileRevalidate > 0 && now.Before(item.expiresAt.Add(policy.StaleWhileRevalidate))
+}
+
+// expired сообщает, истекло ли время жизни записи
+func (item *CacheItem) expired(now time.Time) bool {
+	return !item.expiresAt.IsZero() && !now.Before(item.expiresAt)
+}
+
+// expirationTime вычисляет момент истечения записи (нулевое время - без ограничения)
+func expirationTime(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
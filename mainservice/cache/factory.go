@@ -1,29 +1,75 @@
 package cache
 
+import "time"
+
 // CacheType тип кэша
 type CacheType string
 
 const (
 	FIFO3CacheType CacheType = "fifo3"
-	// Можно добавить другие типы кэшей в будущем
-	// LRUCacheType    CacheType = "lru"
-	// LFUCacheType    CacheType = "lfu"
+	LRUCacheType   CacheType = "lru"
+	LFUCacheType   CacheType = "lfu"
+	ARCCacheType   CacheType = "arc"
 )
 
 // Config конфигурация для создания кэша
 type Config struct {
-	Type    CacheType
-	MaxSize int
+	Type    CacheType `yaml:"type" usage:"cache policy: fifo3, lru, lfu or arc"`
+	MaxSize int       `yaml:"max_size" usage:"maximum number of cached entries"`
+
+	// DefaultTTL время жизни записей по умолчанию (0 - без ограничения)
+	DefaultTTL time.Duration `yaml:"default_ttl" usage:"default entry TTL (0 - no expiration)"`
+
+	// PrefixTTLs время жизни записей по префиксу ключа, например "org:" или "user:"
+	PrefixTTLs map[string]time.Duration `yaml:"prefix_ttls" usage:"TTL by key prefix, e.g. org:=10m,user:=2m"`
+
+	// NegativeTTL время жизни отрицательных записей ("не найдено");
+	// 0 - отрицательные ответы не кэшируются
+	NegativeTTL time.Duration `yaml:"negative_ttl" usage:"TTL of not found responses (0 - not cached)"`
+
+	// StaleWhileRevalidate сколько после истечения TTL запись еще можно отдать,
+	// пока в фоне запрашивается свежая (0 - режим выключен)
+	StaleWhileRevalidate time.Duration `yaml:"stale_while_revalidate" usage:"how long an expired entry may be served while refreshing"`
+
+	// MaxBytes ограничение кэша по занимаемой памяти в дополнение к MaxSize
+	// (0 - без ограничения); при превышении вытесняются записи по политике кэша
+	MaxBytes int64 `yaml:"max_bytes" usage:"cache memory limit in bytes (0 - unlimited)"`
+
+	// Weigher оценка веса записи; по умолчанию DefaultWeigher (proto.Size для ответов БД)
+	Weigher Weigher `yaml:"-"`
+}
+
+// Expiration возвращает политику времени жизни записей из конфигурации
+func (config Config) Expiration() ExpirationPolicy {
+	return ExpirationPolicy{
+		DefaultTTL:           config.DefaultTTL,
+		PrefixTTLs:           config.PrefixTTLs,
+		StaleWhileRevalidate: config.StaleWhileRevalidate,
+	}
+}
+
+// Budget возвращает ограничение кэша по памяти из конфигурации
+func (config Config) Budget() ByteBudget {
+	return ByteBudget{
+		MaxBytes: config.MaxBytes,
+		Weigher:  config.Weigher,
+	}
 }
 
 // NewCache создает новый кэш по конфигурации
 func NewCache(config Config) Cache {
 	switch config.Type {
 	case FIFO3CacheType:
-		return NewFIFO3Cache(config.MaxSize)
+		return NewFIFO3CacheWithBudget(config.MaxSize, config.Expiration(), config.Budget())
+	case LRUCacheType:
+		return NewLRUCache(config.MaxSize, config.Expiration(), config.Budget())
+	case LFUCacheType:
+		return NewLFUCache(config.MaxSize, config.Expiration(), config.Budget())
+	case ARCCacheType:
+		return NewARCCache(config.MaxSize, config.Expiration(), config.Budget())
 	default:
 		// По умолчанию используем FIFO3
-		return NewFIFO3Cache(config.MaxSize)
+		return NewFIFO3CacheWithBudget(config.MaxSize, config.Expiration(), config.Budget())
 	}
 }
 
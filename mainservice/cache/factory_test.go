@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestCacheCapacity каждая политика держит не больше MaxSize записей и считает вытеснения
+func TestCacheCapacity(t *testing.T) {
+	const maxSize = 8
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := NewCache(Config{Type: cacheType, MaxSize: maxSize})
+			for i := 0; i < maxSize*3; i++ {
+				cache.Set(fmt.Sprintf("key:%d", i), i)
+				if size := cache.Size(); size > maxSize {
+					t.Fatalf("Size = %d after %d inserts, capacity %d", size, i+1, maxSize)
+				}
+			}
+
+			if size := cache.Size(); size != maxSize {
+				t.Errorf("Size = %d, want %d", size, maxSize)
+			}
+			if _, _, _, total := cache.GetStats(); total != maxSize {
+				t.Errorf("GetStats total = %d, want %d", total, maxSize)
+			}
+			if evictions := cache.(evictionReporter).Evictions(); evictions != maxSize*2 {
+				t.Errorf("Evictions = %d, want %d", evictions, maxSize*2)
+			}
+			// Без обращений все политики оставляют последние записи
+			for i := maxSize * 2; i < maxSize*3; i++ {
+				if _, found := cache.Peek(fmt.Sprintf("key:%d", i)); !found {
+					t.Errorf("key:%d evicted, expected the newest entries to stay", i)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheExpiration(t *testing.T) {
+	const ttl = 30 * time.Millisecond
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := NewCache(Config{
+				Type:                 cacheType,
+				MaxSize:              10,
+				DefaultTTL:           ttl,
+				PrefixTTLs:           map[string]time.Duration{"org:": time.Hour},
+				StaleWhileRevalidate: ttl,
+			})
+			cache.Set("user:1", "user")
+			cache.Set("org:1", "org")
+			cache.SetWithTTL("forever", "value", 0)
+
+			if value, found := cache.Get("user:1"); !found || value != "user" {
+				t.Fatalf("Get before expiry = %v, %t", value, found)
+			}
+
+			time.Sleep(ttl + 5*time.Millisecond)
+			if _, found := cache.Get("user:1"); found {
+				t.Error("Get returned an expired entry")
+			}
+			if value, fresh, found := cache.GetStale("user:1"); !found || fresh || value != "user" {
+				t.Errorf("GetStale in the stale window = %v, fresh %t, found %t; want a stale value", value, fresh, found)
+			}
+			if _, found := cache.Peek("user:1"); !found {
+				t.Error("Peek does not see an entry in the stale window")
+			}
+			for _, key := range []string{"org:1", "forever"} {
+				if _, found := cache.Get(key); !found {
+					t.Errorf("%s expired, its TTL has not passed", key)
+				}
+			}
+
+			time.Sleep(ttl)
+			if _, _, found := cache.GetStale("user:1"); found {
+				t.Error("GetStale returned an entry after the stale window")
+			}
+			if _, found := cache.Peek("user:1"); found {
+				t.Error("Peek returned an entry after the stale window")
+			}
+		})
+	}
+}
+
+func TestExpirationPolicyTTLFor(t *testing.T) {
+	policy := ExpirationPolicy{
+		DefaultTTL: time.Minute,
+		PrefixTTLs: map[string]time.Duration{"org:": 10 * time.Minute, "org:search:": time.Second},
+	}
+	tests := []struct {
+		key  string
+		want time.Duration
+	}{
+		{key: "user:1", want: time.Minute},
+		{key: "org:1", want: 10 * time.Minute},
+		{key: "org:search:завод", want: time.Second},
+	}
+	for _, test := range tests {
+		if ttl := policy.TTLFor(test.key); ttl != test.want {
+			t.Errorf("TTLFor(%q) = %s, want %s", test.key, ttl, test.want)
+		}
+	}
+}
+
+// TestCacheRemoveAndClear удаление освобождает место и вес записи, а после Clear
+// кэш снова вмещает MaxSize записей без вытеснений
+func TestCacheRemoveAndClear(t *testing.T) {
+	const maxSize = 4
+	for _, cacheType := range allCacheTypes {
+		t.Run(string(cacheType), func(t *testing.T) {
+			cache := NewCache(Config{Type: cacheType, MaxSize: maxSize, MaxBytes: 1 << 20})
+			reporter := cache.(byteReporter)
+			for i := 0; i < maxSize; i++ {
+				cache.Set(fmt.Sprintf("key:%d", i), "value")
+			}
+			touch(t, cache, "key:0", 2)
+			weight := DefaultWeigher("key:1", "value")
+
+			usedBefore := reporter.UsedBytes()
+			cache.Remove("key:1")
+			cache.Remove("missing")
+			if size := cache.Size(); size != maxSize-1 {
+				t.Errorf("Size = %d after Remove, want %d", size, maxSize-1)
+			}
+			if used := reporter.UsedBytes(); used != usedBefore-weight {
+				t.Errorf("UsedBytes = %d after Remove, want %d", used, usedBefore-weight)
+			}
+			if _, found := cache.Get("key:1"); found {
+				t.Error("removed key is still served")
+			}
+			cache.Set("key:new", "value")
+			assertKeys(t, cache, []string{"key:0", "key:2", "key:3", "key:new"}, []string{"key:1"})
+
+			cache.Clear()
+			if size := cache.Size(); size != 0 {
+				t.Errorf("Size = %d after Clear", size)
+			}
+			if used := reporter.UsedBytes(); used != 0 {
+				t.Errorf("UsedBytes = %d after Clear", used)
+			}
+			if level1, level2, level3, total := cache.GetStats(); level1+level2+level3+total != 0 {
+				t.Errorf("GetStats = %d, %d, %d, %d after Clear", level1, level2, level3, total)
+			}
+
+			evictions := cache.(evictionReporter).Evictions()
+			for i := 0; i < maxSize; i++ {
+				cache.Set(fmt.Sprintf("again:%d", i), "value")
+			}
+			if size := cache.Size(); size != maxSize {
+				t.Errorf("Size = %d after refilling, want %d", size, maxSize)
+			}
+			if evicted := cache.(evictionReporter).Evictions() - evictions; evicted != 0 {
+				t.Errorf("%d evictions while refilling a cleared cache", evicted)
+			}
+		})
+	}
+}
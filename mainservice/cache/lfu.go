@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LFUCache вытесняет запись с наименьшим числом обращений,
+// среди равных - ту, к которой дольше всего не обращались
+type LFUCache struct {
+	mu           sync.Mutex
+	items        map[string]*list.Element // Значения элементов - *CacheItem
+	frequencies  map[int]*list.List       // Записи по числу обращений, начало - последние обращения
+	minFrequency int
+	maxSize      int
+	expiration   ExpirationPolicy
+	budget       ByteBudget
+	usedBytes    int64
+	evictions    int
+}
+
+// NewLFUCache создает LFU кэш с политикой времени жизни записей и ограничением по памяти
+func NewLFUCache(maxSize int, expiration ExpirationPolicy, budget ByteBudget) Cache {
+	return &LFUCache{
+		items:       make(map[string]*list.Element),
+		frequencies: make(map[int]*list.List),
+		maxSize:     maxSize,
+		expiration:  expiration,
+		budget:      budget,
+	}
+}
+
+// Get возвращает значение по ключу, если его время жизни не истекло
+func (cache *LFUCache) Get(key string) (interface{}, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.items[key]
+	if !found {
+		return nil, false
+	}
+	item := element.Value.(*CacheItem)
+	now := time.Now()
+	if item.expired(now) {
+		return nil, false
+	}
+
+	item.lastAccess = now
+	cache.touch(element)
+	return item.value, true
+}
+
+// GetStale возвращает значение с учетом окна stale-while-revalidate
+func (cache *LFUCache) GetStale(key string) (interface{}, bool, bool) {
+	if value, found := cache.Get(key); found {
+		return value, true, true
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.items[key]
+	if !found {
+		return nil, false, false
+	}
+	item := element.Value.(*CacheItem)
+	if !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false, false
+	}
+	return item.value, false, true
+}
+
+// Peek возвращает значение без учета обращения: частота обращений не растет
+func (cache *LFUCache) Peek(key string) (interface{}, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.items[key]
+	if !found {
+		return nil, false
+	}
+	item := element.Value.(*CacheItem)
+	if !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+// Set устанавливает значение по ключу
+func (cache *LFUCache) Set(key string, value interface{}) {
+	cache.SetWithTTL(key, value, cache.expiration.TTLFor(key))
+}
+
+// SetWithTTL устанавливает значение по ключу с заданным временем жизни.
+// Обновление значения считается обращением к записи.
+func (cache *LFUCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	weight := cache.budget.weigh(key, value)
+
+	element, found := cache.items[key]
+	if !cache.budget.fits(weight) {
+		// Запись больше всего бюджета не кэшируется; старое значение устарело
+		if found {
+			cache.remove(element)
+		}
+		return
+	}
+
+	if found {
+		item := element.Value.(*CacheItem)
+		item.value = value
+		item.lastAccess = now
+		item.expiresAt = expirationTime(now, ttl)
+		cache.usedBytes += weight - item.weight
+		item.weight = weight
+		cache.touch(element)
+		for cache.budget.exceeds(cache.usedBytes, 0) && len(cache.items) > 1 {
+			cache.evict()
+		}
+		return
+	}
+
+	for (len(cache.items) >= cache.maxSize || cache.budget.exceeds(cache.usedBytes, weight)) && len(cache.items) > 0 {
+		cache.evict()
+	}
+
+	item := &CacheItem{
+		key:         key,
+		value:       value,
+		createdAt:   now,
+		lastAccess:  now,
+		expiresAt:   expirationTime(now, ttl),
+		accessCount: 1,
+		weight:      weight,
+	}
+	cache.items[key] = cache.bucket(1).PushFront(item)
+	cache.usedBytes += weight
+	cache.minFrequency = 1
+}
+
+// Remove удаляет значение по ключу
+func (cache *LFUCache) Remove(key string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, found := cache.items[key]; found {
+		cache.remove(element)
+	}
+}
+
+// Clear очищает весь кэш
+func (cache *LFUCache) Clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.items = make(map[string]*list.Element)
+	cache.frequencies = make(map[int]*list.List)
+	cache.minFrequency = 0
+	cache.usedBytes = 0
+}
+
+// GetStats возвращает статистику кэша; у LFU один уровень
+func (cache *LFUCache) GetStats() (int, int, int, int) {
+	size := cache.Size()
+	return size, 0, 0, size
+}
+
+// Size возвращает текущий размер кэша
+func (cache *LFUCache) Size() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return len(cache.items)
+}
+
+// MaxSize возвращает максимальный размер кэша
+func (cache *LFUCache) MaxSize() int {
+	return cache.maxSize
+}
+
+// UsedBytes возвращает суммарный вес записей
+func (cache *LFUCache) UsedBytes() int64 {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.usedBytes
+}
+
+// MaxBytes возвращает ограничение кэша по памяти (0 - без ограничения)
+func (cache *LFUCache) MaxBytes() int64 {
+	return cache.budget.MaxBytes
+}
+
+// Evictions возвращает число вытесненных записей
+func (cache *LFUCache) Evictions() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.evictions
+}
+
+// touch переносит запись в список следующей частоты; вызывается под блокировкой
+func (cache *LFUCache) touch(element *list.Element) {
+	item := element.Value.(*CacheItem)
+	frequency := item.accessCount
+	cache.unlink(element)
+	if frequency == cache.minFrequency && cache.frequencies[frequency] == nil {
+		cache.minFrequency = frequency + 1
+	}
+
+	item.accessCount++
+	cache.items[item.key] = cache.bucket(item.accessCount).PushFront(item)
+}
+
+// unlink убирает запись из списка ее частоты; вызывается под блокировкой
+func (cache *LFUCache) unlink(element *list.Element) {
+	frequency := element.Value.(*CacheItem).accessCount
+	bucket := cache.frequencies[frequency]
+	bucket.Remove(element)
+	if bucket.Len() == 0 {
+		delete(cache.frequencies, frequency)
+	}
+}
+
+// bucket возвращает список записей с заданной частотой, создавая его при необходимости
+func (cache *LFUCache) bucket(frequency int) *list.List {
+	bucket, found := cache.frequencies[frequency]
+	if !found {
+		bucket = list.New()
+		cache.frequencies[frequency] = bucket
+	}
+	return bucket
+}
+
+// evict вытесняет наименее часто используемую запись; вызывается под блокировкой
+func (cache *LFUCache) evict() {
+	bucket, found := cache.frequencies[cache.minFrequency]
+	if !found {
+		// После удалений минимальная частота могла устареть - ищем заново
+		cache.minFrequency = 0
+		for frequency := range cache.frequencies {
+			if cache.minFrequency == 0 || frequency < cache.minFrequency {
+				cache.minFrequency = frequency
+			}
+		}
+		bucket = cache.frequencies[cache.minFrequency]
+	}
+
+	cache.remove(bucket.Back())
+	cache.evictions++
+}
+
+// remove удаляет элемент; вызывается под блокировкой
+func (cache *LFUCache) remove(element *list.Element) {
+	item := element.Value.(*CacheItem)
+	cache.unlink(element)
+	delete(cache.items, item.key)
+	cache.usedBytes -= item.weight
+}
@@ -0,0 +1,116 @@
+package cache
+
+import "testing"
+
+func TestLFUEvictionOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxSize int
+		steps   func(t *testing.T, cache Cache)
+		present []string
+		absent  []string
+	}{
+		{
+			name:    "least frequently used entry is evicted",
+			maxSize: 3,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+				touch(t, cache, "a", 2)
+				touch(t, cache, "c", 1)
+				cache.Set("d", "d")
+			},
+			present: []string{"a", "c", "d"},
+			absent:  []string{"b"},
+		},
+		{
+			name:    "equal frequency evicts the least recently used",
+			maxSize: 3,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+				cache.Set("d", "d")
+			},
+			present: []string{"b", "c", "d"},
+			absent:  []string{"a"},
+		},
+		{
+			name:    "tie at a higher frequency evicts the least recently used",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				touch(t, cache, "a", 1)
+				touch(t, cache, "b", 1)
+				cache.Set("c", "c")
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+		},
+		{
+			name:    "a new entry is evicted before frequently used ones",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				touch(t, cache, "a", 3)
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+			},
+			present: []string{"a", "c"},
+			absent:  []string{"b"},
+		},
+		{
+			name:    "updating a key counts as a use",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("a", "a2")
+				cache.Set("c", "c")
+			},
+			present: []string{"a", "c"},
+			absent:  []string{"b"},
+		},
+		{
+			name:    "peek does not count as a use",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Peek("a")
+				cache.Peek("a")
+				cache.Set("c", "c")
+			},
+			present: []string{"b", "c"},
+			absent:  []string{"a"},
+		},
+		{
+			name:    "removing the least frequent entry keeps eviction consistent",
+			maxSize: 2,
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				touch(t, cache, "b", 2)
+				touch(t, cache, "a", 1)
+				cache.Remove("a")
+				cache.Set("c", "c")
+				cache.Set("d", "d")
+			},
+			present: []string{"b", "d"},
+			absent:  []string{"a", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := NewLFUCache(test.maxSize, ExpirationPolicy{}, ByteBudget{})
+			test.steps(t, cache)
+			assertKeys(t, cache, test.present, test.absent)
+			if size := cache.Size(); size != test.maxSize {
+				t.Errorf("Size = %d, want %d", size, test.maxSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRUCache вытесняет запись, к которой дольше всего не обращались
+type LRUCache struct {
+	mu         sync.Mutex
+	items      map[string]*list.Element // Значения элементов - *CacheItem
+	order      *list.List               // Начало - последние обращения, конец - кандидаты на вытеснение
+	maxSize    int
+	expiration ExpirationPolicy
+	budget     ByteBudget
+	usedBytes  int64
+	evictions  int
+}
+
+// NewLRUCache создает LRU кэш с политикой времени жизни записей и ограничением по памяти
+func NewLRUCache(maxSize int, expiration ExpirationPolicy, budget ByteBudget) Cache {
+	return &LRUCache{
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+		maxSize:    maxSize,
+		expiration: expiration,
+		budget:     budget,
+	}
+}
+
+// Get возвращает значение по ключу, если его время жизни не истекло
+func (cache *LRUCache) Get(key string) (interface{}, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.items[key]
+	if !found {
+		return nil, false
+	}
+	item := element.Value.(*CacheItem)
+	now := time.Now()
+	if item.expired(now) {
+		return nil, false
+	}
+
+	item.lastAccess = now
+	item.accessCount++
+	cache.order.MoveToFront(element)
+	return item.value, true
+}
+
+// GetStale возвращает значение с учетом окна stale-while-revalidate
+func (cache *LRUCache) GetStale(key string) (interface{}, bool, bool) {
+	if value, found := cache.Get(key); found {
+		return value, true, true
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.items[key]
+	if !found {
+		return nil, false, false
+	}
+	item := element.Value.(*CacheItem)
+	if !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false, false
+	}
+	return item.value, false, true
+}
+
+// Peek возвращает значение без учета обращения: запись не становится недавней
+func (cache *LRUCache) Peek(key string) (interface{}, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	element, found := cache.items[key]
+	if !found {
+		return nil, false
+	}
+	item := element.Value.(*CacheItem)
+	if !cache.expiration.servableStale(item, time.Now()) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+// Set устанавливает значение по ключу
+func (cache *LRUCache) Set(key string, value interface{}) {
+	cache.SetWithTTL(key, value, cache.expiration.TTLFor(key))
+}
+
+// SetWithTTL устанавливает значение по ключу с заданным временем жизни
+func (cache *LRUCache) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	weight := cache.budget.weigh(key, value)
+
+	element, found := cache.items[key]
+	if !cache.budget.fits(weight) {
+		// Запись больше всего бюджета не кэшируется; старое значение устарело
+		if found {
+			cache.remove(element)
+		}
+		return
+	}
+
+	if found {
+		item := element.Value.(*CacheItem)
+		item.value = value
+		item.lastAccess = now
+		item.expiresAt = expirationTime(now, ttl)
+		cache.usedBytes += weight - item.weight
+		item.weight = weight
+		cache.order.MoveToFront(element)
+		for cache.budget.exceeds(cache.usedBytes, 0) && cache.order.Len() > 1 {
+			cache.evict()
+		}
+		return
+	}
+
+	for (cache.order.Len() >= cache.maxSize || cache.budget.exceeds(cache.usedBytes, weight)) && cache.order.Len() > 0 {
+		cache.evict()
+	}
+
+	cache.items[key] = cache.order.PushFront(&CacheItem{
+		key:         key,
+		value:       value,
+		createdAt:   now,
+		lastAccess:  now,
+		expiresAt:   expirationTime(now, ttl),
+		accessCount: 1,
+		weight:      weight,
+	})
+	cache.usedBytes += weight
+}
+
+// Remove удаляет значение по ключу
+func (cache *LRUCache) Remove(key string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, found := cache.items[key]; found {
+		cache.remove(element)
+	}
+}
+
+// Clear очищает весь кэш
+func (cache *LRUCache) Clear() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cache.items = make(map[string]*list.Element)
+	cache.order.Init()
+	cache.usedBytes = 0
+}
+
+// GetStats возвращает статистику кэша; у LRU один уровень
+func (cache *LRUCache) GetStats() (int, int, int, int) {
+	size := cache.Size()
+	return size, 0, 0, size
+}
+
+// Size возвращает текущий размер кэша
+func (cache *LRUCache) Size() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.order.Len()
+}
+
+// MaxSize возвращает максимальный размер кэша
+func (cache *LRUCache) MaxSize() int {
+	return cache.maxSize
+}
+
+// UsedBytes возвращает суммарный вес записей
+func (cache *LRUCache) UsedBytes() int64 {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.usedBytes
+}
+
+// MaxBytes возвращает ограничение кэша по памяти (0 - без ограничения)
+func (cache *LRUCache) MaxBytes() int64 {
+	return cache.budget.MaxBytes
+}
+
+// Evictions возвращает число вытесненных записей
+func (cache *LRUCache) Evictions() int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.evictions
+}
+
+// evict вытесняет наиболее давно использованную запись; вызывается под блокировкой
+func (cache *LRUCache) evict() {
+	cache.remove(cache.order.Back())
+	cache.evictions++
+}
+
+// remove удаляет элемент; вызывается под блокировкой
+func (cache *LRUCache) remove(element *list.Element) {
+	item := element.Value.(*CacheItem)
+	cache.order.Remove(element)
+	delete(cache.items, item.key)
+	cache.usedBytes -= item.weight
+}
@@ -0,0 +1,70 @@
+package cache
+
+import "testing"
+
+func TestLRUEvictionOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   func(t *testing.T, cache Cache)
+		present []string
+		absent  []string
+	}{
+		{
+			name: "least recently inserted entry is evicted first",
+			steps: func(t *testing.T, cache Cache) {
+				for _, key := range []string{"a", "b", "c", "d"} {
+					cache.Set(key, key)
+				}
+			},
+			present: []string{"b", "c", "d"},
+			absent:  []string{"a"},
+		},
+		{
+			name: "get makes an entry recent",
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+				touch(t, cache, "a", 1)
+				cache.Set("d", "d")
+			},
+			present: []string{"a", "c", "d"},
+			absent:  []string{"b"},
+		},
+		{
+			name: "updating a key makes it recent",
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+				cache.Set("a", "a2")
+				cache.Set("d", "d")
+			},
+			present: []string{"a", "c", "d"},
+			absent:  []string{"b"},
+		},
+		{
+			name: "peek does not make an entry recent",
+			steps: func(t *testing.T, cache Cache) {
+				cache.Set("a", "a")
+				cache.Set("b", "b")
+				cache.Set("c", "c")
+				cache.Peek("a")
+				cache.Set("d", "d")
+			},
+			present: []string{"b", "c", "d"},
+			absent:  []string{"a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := NewLRUCache(3, ExpirationPolicy{}, ByteBudget{})
+			test.steps(t, cache)
+			assertKeys(t, cache, test.present, test.absent)
+			if size := cache.Size(); size != 3 {
+				t.Errorf("Size = %d, want 3", size)
+			}
+		})
+	}
+}
@@ -2,19 +2,25 @@ package cache
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
 // Metrics метрики кэша
 type Metrics struct {
+	Type           CacheType
 	Level1Size     int
 	Level2Size     int
 	Level3Size     int
 	TotalSize      int
 	MaxSize        int
 	HitRate        float64
+	HitCount       int64
+	MissCount      int64
 	EvictionCount  int
 	AverageAccessTime time.Duration
+	UsedBytes      int64 // Суммарный вес записей, если кэш ограничен по памяти
+	MaxBytes       int64 // Ограничение по памяти (0 - без ограничения)
 }
 
 // CacheWithMetrics расширенный интерфейс кэша с метриками
@@ -23,20 +29,47 @@ type CacheWithMetrics interface {
 	GetMetrics() Metrics
 }
 
-// FIFO3CacheWithMetrics обертка для FIFO3 кэша с метриками
+// evictionReporter реализуется кэшами, которые сами считают вытесненные записи
+type evictionReporter interface {
+	Evictions() int
+}
+
+// byteReporter реализуется кэшами с учетом занимаемой памяти
+type byteReporter interface {
+	UsedBytes() int64
+	MaxBytes() int64
+}
+
+// FIFO3CacheWithMetrics обертка с метриками для кэша любого типа
+// (название сохранено с тех пор, когда был только FIFO3).
+// Счетчики атомарные: обертку вызывают параллельно из обработчиков gRPC.
 type FIFO3CacheWithMetrics struct {
 	Cache
-	hitCount      int
-	missCount     int
-	evictionCount int
-	totalAccessTime time.Duration
-	accessCount   int
+	cacheType       CacheType
+	hitCount        atomic.Int64
+	missCount       atomic.Int64
+	evictionCount   atomic.Int64
+	totalAccessTime atomic.Int64 // Наносекунды
+	accessCount     atomic.Int64
 }
 
 // NewFIFO3CacheWithMetrics создает кэш с метриками
 func NewFIFO3CacheWithMetrics(maxSize int) CacheWithMetrics {
 	return &FIFO3CacheWithMetrics{
-		Cache: NewFIFO3Cache(maxSize),
+		Cache:     NewFIFO3Cache(maxSize),
+		cacheType: FIFO3CacheType,
+	}
+}
+
+// NewCacheWithMetrics создает кэш по конфигурации с подсчетом метрик
+func NewCacheWithMetrics(config Config) CacheWithMetrics {
+	cacheType := config.Type
+	if cacheType == "" {
+		cacheType = FIFO3CacheType
+	}
+	return &FIFO3CacheWithMetrics{
+		Cache:     NewCache(config),
+		cacheType: cacheType,
 	}
 }
 
@@ -44,30 +77,57 @@ func NewFIFO3CacheWithMetrics(maxSize int) CacheWithMetrics {
 func (c *FIFO3CacheWithMetrics) Get(key string) (interface{}, bool) {
 	start := time.Now()
 	value, found := c.Cache.Get(key)
-	duration := time.Since(start)
-	
-	c.totalAccessTime += duration
-	c.accessCount++
+	c.recordAccess(start, found)
+	return value, found
+}
+
+// GetStale возвращает значение с учетом окна stale-while-revalidate;
+// истекшее значение считается промахом
+func (c *FIFO3CacheWithMetrics) GetStale(key string) (interface{}, bool, bool) {
+	start := time.Now()
+	value, fresh, found := c.Cache.GetStale(key)
+	c.recordAccess(start, found && fresh)
+	return value, fresh, found
+}
+
+// recordAccess учитывает одно обращение к кэшу
+func (c *FIFO3CacheWithMetrics) recordAccess(start time.Time, hit bool) {
+	c.totalAccessTime.Add(int64(time.Since(start)))
+	c.accessCount.Add(1)
 	
-	if found {
-		c.hitCount++
+	if hit {
+		c.hitCount.Add(1)
 	} else {
-		c.missCount++
+		c.missCount.Add(1)
 	}
-	
-	return value, found
 }
 
 // Set устанавливает значение
 func (c *FIFO3CacheWithMetrics) Set(key string, value interface{}) {
+	c.countEviction(func() { c.Cache.Set(key, value) })
+}
+
+// SetWithTTL устанавливает значение с собственным временем жизни
+func (c *FIFO3CacheWithMetrics) SetWithTTL(key string, value interface{}, ttl time.Duration) {
+	c.countEviction(func() { c.Cache.SetWithTTL(key, value, ttl) })
+}
+
+// countEviction выполняет запись и учитывает вытеснение, если оно произошло
+func (c *FIFO3CacheWithMetrics) countEviction(set func()) {
+	if _, ok := c.Cache.(evictionReporter); ok {
+		// Кэш считает вытеснения сам
+		set()
+		return
+	}
+	
 	// Проверяем, нужно ли вытеснение
 	beforeSize := c.Cache.Size()
-	c.Cache.Set(key, value)
+	set()
 	afterSize := c.Cache.Size()
 	
 	// Если размер не изменился, значит произошло вытеснение
 	if beforeSize == afterSize && beforeSize >= c.Cache.MaxSize() {
-		c.evictionCount++
+		c.evictionCount.Add(1)
 	}
 }
 
@@ -75,32 +135,52 @@ func (c *FIFO3CacheWithMetrics) Set(key string, value interface{}) {
 func (c *FIFO3CacheWithMetrics) GetMetrics() Metrics {
 	l1, l2, l3, total := c.Cache.GetStats()
 	
+	hitCount, missCount := c.hitCount.Load(), c.missCount.Load()
 	hitRate := 0.0
-	if c.hitCount+c.missCount > 0 {
-		hitRate = float64(c.hitCount) / float64(c.hitCount+c.missCount)
+	if hitCount+missCount > 0 {
+		hitRate = float64(hitCount) / float64(hitCount+missCount)
+	}
+	
+	evictionCount := int(c.evictionCount.Load())
+	if reporter, ok := c.Cache.(evictionReporter); ok {
+		evictionCount = reporter.Evictions()
+	}
+	
+	var usedBytes, maxBytes int64
+	if reporter, ok := c.Cache.(byteReporter); ok {
+		usedBytes, maxBytes = reporter.UsedBytes(), reporter.MaxBytes()
 	}
 	
 	avgAccessTime := time.Duration(0)
-	if c.accessCount > 0 {
-		avgAccessTime = c.totalAccessTime / time.Duration(c.accessCount)
+	if accessCount := c.accessCount.Load(); accessCount > 0 {
+		avgAccessTime = time.Duration(c.totalAccessTime.Load() / accessCount)
 	}
 	
 	return Metrics{
+		Type:           c.cacheType,
 		Level1Size:     l1,
 		Level2Size:     l2,
 		Level3Size:     l3,
 		TotalSize:      total,
 		MaxSize:        c.Cache.MaxSize(),
 		HitRate:        hitRate,
-		EvictionCount:  c.evictionCount,
+		HitCount:       hitCount,
+		MissCount:      missCount,
+		EvictionCount:  evictionCount,
 		AverageAccessTime: avgAccessTime,
+		UsedBytes:      usedBytes,
+		MaxBytes:       maxBytes,
 	}
 }
 
 // String возвращает строковое представление метрик
 func (m Metrics) String() string {
-	return fmt.Sprintf(
-		"Cache Metrics: Level1=%d, Level2=%d, Level3=%d, Total=%d/%d, HitRate=%.2f%%, Evictions=%d, AvgAccessTime=%v",
-		m.Level1Size, m.Level2Size, m.Level3Size, m.TotalSize, m.MaxSize, m.HitRate*100, m.EvictionCount, m.AverageAccessTime,
+	text := fmt.Sprintf(
+		"Cache Metrics (%s): Level1=%d, Level2=%d, Level3=%d, Total=%d/%d, HitRate=%.2f%%, Evictions=%d, AvgAccessTime=%v",
+		m.Type, m.Level1Size, m.Level2Size, m.Level3Size, m.TotalSize, m.MaxSize, m.HitRate*100, m.EvictionCount, m.AverageAccessTime,
 	)
+	if m.MaxBytes > 0 {
+		text += fmt.Sprintf(", Bytes=%d/%d", m.UsedBytes, m.MaxBytes)
+	}
+	return text
 }
\ No newline at end of file
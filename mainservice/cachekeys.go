@@ -0,0 +1,440 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"industrialregistrysystem/base/api"
+)
+
+// Ключи кэша ответов БД:
+//   org:<id>, org:inn:<inn>         - OrganizationResponse
+//   user:<id>, user:email:<email>   - UserResponse
+//   entity:<table>:<id>             - EntityResponse
+
+// organizationTables таблицы, изменения в которых меняют OrganizationResponse.
+// Представления (active_organizations) сюда не входят: универсальные команды
+// работают только с базовыми таблицами из реестра схемы воркера.
+var organizationTables = map[string]bool{
+	"organisation":  true,
+	"organizations": true,
+}
+
+// userTables таблицы, изменения в которых меняют UserResponse
+var userTables = map[string]bool{
+	"users": true,
+}
+
+// staleWhileRevalidatePrefixes ключи, которые после истечения TTL отдаются устаревшими,
+// пока в фоне запрашивается свежий ответ
+var staleWhileRevalidatePrefixes = []string{"org:"}
+
+// notFoundEntry отрицательная запись кэша: БД ответила, что объекта нет
+type notFoundEntry struct {
+	message string
+}
+
+// staleReadWindow ответы на чтения, отправленные раньше, в кэш не попадают:
+// за это время успевает истечь история инвалидаций, по которой ловится гонка с изменением
+const staleReadWindow = defaultCommandTimeout
+
+func organizationCacheKey(id int32) string      { return fmt.Sprintf("org:%d", id) }
+func organizationInnCacheKey(inn string) string { return "org:inn:" + inn }
+func userCacheKey(id int32) string              { return fmt.Sprintf("user:%d", id) }
+func userEmailCacheKey(email string) string     { return "user:email:" + email }
+func entityCacheKey(tableName string, id int32) string {
+	return fmt.Sprintf("entity:%s:%d", tableName, id)
+}
+
+// requestCacheKey возвращает ключ кэша, под которым может лежать ответ на запрос
+func requestCacheKey(request *api.CommandRequest) string {
+	switch cmd := request.Command.(type) {
+	case *api.CommandRequest_GetOrganization:
+		switch identifier := cmd.GetOrganization.GetIdentifier().(type) {
+		case *api.GetOrganizationRequest_Id:
+			return organizationCacheKey(identifier.Id)
+		case *api.GetOrganizationRequest_Inn:
+			return organizationInnCacheKey(identifier.Inn)
+		}
+	case *api.CommandRequest_GetUser:
+		switch identifier := cmd.GetUser.GetIdentifier().(type) {
+		case *api.GetUserRequest_Id:
+			return userCacheKey(identifier.Id)
+		case *api.GetUserRequest_Email:
+			return userEmailCacheKey(identifier.Email)
+		}
+	case *api.CommandRequest_Get:
+		if cmd.Get.GetId() != 0 {
+			return entityCacheKey(cmd.Get.TableName, cmd.Get.Id)
+		}
+	}
+	return ""
+}
+
+// responseCacheKeys возвращает все ключи, по которым ответ можно найти в кэше,
+// включая вторичные (ИНН организации, email пользователя)
+func responseCacheKeys(response *api.CommandResponse) []string {
+	switch resp := response.Response.(type) {
+	case *api.CommandResponse_Organization:
+		if organization := resp.Organization.GetOrganization(); organization != nil {
+			keys := []string{organizationCacheKey(organization.Id)}
+			if organization.Inn != "" {
+				keys = append(keys, organizationInnCacheKey(organization.Inn))
+			}
+			return keys
+		}
+	case *api.CommandResponse_User:
+		if user := resp.User.GetUser(); user != nil {
+			keys := []string{userCacheKey(user.Id)}
+			if user.Email != "" {
+				keys = append(keys, userEmailCacheKey(user.Email))
+			}
+			return keys
+		}
+	case *api.CommandResponse_Entity:
+		if entity := resp.Entity.GetEntity(); entity != nil {
+			if id, ok := entityID(entity.Fields); ok {
+				return []string{entityCacheKey(resp.Entity.TableName, id)}
+			}
+		}
+	}
+	return nil
+}
+
+// entityID извлекает id записи из строковых полей Entity
+func entityID(fields map[string]string) (int32, bool) {
+	return parseID(fields["id"])
+}
+
+func parseID(value string) (int32, bool) {
+	if value == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// invalidationTracker помнит недавние инвалидации ключей, чтобы ответ на чтение,
+// отправленное до изменения, не вернул в кэш устаревшие данные
+type invalidationTracker struct {
+	mu            sync.Mutex
+	invalidatedAt map[string]time.Time
+}
+
+func newInvalidationTracker() *invalidationTracker {
+	return &invalidationTracker{invalidatedAt: make(map[string]time.Time)}
+}
+
+func (tracker *invalidationTracker) markInvalidated(keys ...string) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	now := time.Now()
+	for _, key := range keys {
+		tracker.invalidatedAt[key] = now
+	}
+
+	// Старые записи больше не нужны: такие чтения все равно не кэшируются
+	if len(tracker.invalidatedAt) > 4096 {
+		for key, invalidatedAt := range tracker.invalidatedAt {
+			if now.Sub(invalidatedAt) > staleReadWindow {
+				delete(tracker.invalidatedAt, key)
+			}
+		}
+	}
+}
+
+func (tracker *invalidationTracker) invalidatedSince(key string, since time.Time) bool {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	invalidatedAt, found := tracker.invalidatedAt[key]
+	return found && !invalidatedAt.Before(since)
+}
+
+// cacheResponse кэширует ответ на читающую команду, отправленную в dispatchedAt
+func (service *UserDataService) cacheResponse(request *api.CommandRequest, response *api.CommandResponse, dispatchedAt time.Time) {
+	if time.Since(dispatchedAt) > staleReadWindow {
+		return
+	}
+
+	keys := responseCacheKeys(response)
+	if requestKey := requestCacheKey(request); requestKey != "" && !slices.Contains(keys, requestKey) {
+		keys = append(keys, requestKey)
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	// Если пока шло чтение, данные были изменены, ответ уже мог устареть
+	for _, key := range keys {
+		if service.invalidations.invalidatedSince(key, dispatchedAt) {
+			log.Printf("🚫 Skipping cache for request %s: %s changed while it was in flight", request.RequestId, key)
+			return
+		}
+	}
+
+	for _, key := range keys {
+		service.cache.Set(key, response)
+	}
+	log.Printf("💾 Response cached with keys: %v", keys)
+}
+
+// cacheNotFound кэширует ответ "не найдено" на короткое время (NegativeTTL)
+func (service *UserDataService) cacheNotFound(request *api.CommandRequest, err error, dispatchedAt time.Time) {
+	key := requestCacheKey(request)
+	if key == "" || service.cacheConfig.NegativeTTL <= 0 {
+		return
+	}
+	if time.Since(dispatchedAt) > staleReadWindow || service.invalidations.invalidatedSince(key, dispatchedAt) {
+		return
+	}
+
+	service.cache.SetWithTTL(key, notFoundEntry{message: status.Convert(err).Message()}, service.cacheConfig.NegativeTTL)
+	log.Printf("💾 Not-found response cached with key %s for %v", key, service.cacheConfig.NegativeTTL)
+}
+
+// startRevalidation запускает фоновое обновление истекшей записи. Возвращает false,
+// если устаревшее значение отдавать нельзя: ключ не поддерживает stale-while-revalidate
+// или обновление уже идет (устаревший ответ отдается только один раз).
+func (service *UserDataService) startRevalidation(key string, request *api.CommandRequest) bool {
+	if !slices.ContainsFunc(staleWhileRevalidatePrefixes, func(prefix string) bool {
+		return strings.HasPrefix(key, prefix)
+	}) {
+		return false
+	}
+	if _, inProgress := service.revalidating.LoadOrStore(key, struct{}{}); inProgress {
+		return false
+	}
+
+	refresh := &api.CommandRequest{
+		RequestId: service.newRequestID("revalidate"),
+		Command:   request.Command,
+	}
+	go func() {
+		defer service.revalidating.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultCommandTimeout)
+		defer cancel()
+
+		if _, err := service.executeCoalesced(ctx, key, refresh); err != nil {
+			log.Printf("⚠️ Background refresh of %s failed: %v", key, err)
+			return
+		}
+		log.Printf("🔄 Cache entry %s refreshed in background", key)
+	}()
+
+	log.Printf("⏳ Serving stale cache entry %s while refreshing", key)
+	return true
+}
+
+// applyMutation обновляет или инвалидирует ключи кэша, затронутые изменяющей командой
+func (service *UserDataService) applyMutation(request *api.CommandRequest, response *api.CommandResponse) {
+	switch cmd := request.Command.(type) {
+	case *api.CommandRequest_Create:
+		service.invalidateRelatedOrganization(cmd.Create.GetEntity().TextFields())
+		// Новая запись могла быть закэширована как отсутствующая
+		fields := response.GetEntity().GetEntity().GetFields()
+		if id, ok := entityID(fields); ok {
+			switch {
+			case organizationTables[cmd.Create.TableName]:
+				service.invalidateOrganization(id, fields["inn"])
+			case userTables[cmd.Create.TableName]:
+				service.invalidateUser(id, fields["email"])
+			}
+		}
+		service.refreshCache(response)
+
+	case *api.CommandRequest_Update:
+		key := entityCacheKey(cmd.Update.TableName, cmd.Update.Id)
+		previousFields := service.cachedEntityFields(key)
+		service.invalidateEntity(cmd.Update.TableName, cmd.Update.Id, previousFields, response.GetEntity().GetEntity().GetFields())
+		service.refreshCache(response)
+
+	case *api.CommandRequest_Delete:
+		key := entityCacheKey(cmd.Delete.TableName, cmd.Delete.Id)
+		service.invalidateEntity(cmd.Delete.TableName, cmd.Delete.Id, service.cachedEntityFields(key), nil)
+
+	case *api.CommandRequest_BatchCreate:
+		for _, entity := range cmd.BatchCreate.Entities {
+			service.invalidateRelatedOrganization(entity.TextFields())
+		}
+
+	case *api.CommandRequest_BatchUpdate:
+		for _, entity := range cmd.BatchUpdate.Entities {
+			// Поля запроса могут быть заданы типизированными values
+			fields := entity.TextFields()
+			id, ok := entityID(fields)
+			if !ok {
+				continue
+			}
+			key := entityCacheKey(cmd.BatchUpdate.TableName, id)
+			service.invalidateEntity(cmd.BatchUpdate.TableName, id, service.cachedEntityFields(key), fields)
+		}
+
+	case *api.CommandRequest_CreateUser:
+		service.refreshCache(response)
+
+	case *api.CommandRequest_UpdateUser:
+		service.invalidateUser(cmd.UpdateUser.Id)
+		service.refreshCache(response)
+
+	case *api.CommandRequest_UseInvite:
+		// Приглашение создало пользователя с этим email
+		service.invalidateKeys(userEmailCacheKey(cmd.UseInvite.Email))
+		if invite := response.GetInvite().GetInvite(); invite != nil {
+			service.invalidateKeys(entityCacheKey("invite_codes", invite.Id))
+		}
+	}
+}
+
+// invalidateUncertainMutation инвалидирует ключи изменяющей команды, результат которой
+// неизвестен: таймаут или отключение БД не означают, что транзакция не зафиксирована.
+// Ответа нет, поэтому затронутые ключи определяются по запросу и по кэшу.
+func (service *UserDataService) invalidateUncertainMutation(request *api.CommandRequest) {
+	switch cmd := request.Command.(type) {
+	case *api.CommandRequest_Create:
+		fields := cmd.Create.GetEntity().TextFields()
+		service.invalidateRelatedOrganization(fields)
+		if id, ok := entityID(fields); ok {
+			service.invalidateEntity(cmd.Create.TableName, id, nil, fields)
+			return
+		}
+		// id назначит БД: инвалидируем то, что известно из запроса
+		switch {
+		case organizationTables[cmd.Create.TableName] && fields["inn"] != "":
+			service.invalidateKeys(organizationInnCacheKey(fields["inn"]))
+		case userTables[cmd.Create.TableName] && fields["email"] != "":
+			service.invalidateKeys(userEmailCacheKey(fields["email"]))
+		}
+
+	case *api.CommandRequest_Update:
+		key := entityCacheKey(cmd.Update.TableName, cmd.Update.Id)
+		service.invalidateEntity(cmd.Update.TableName, cmd.Update.Id, service.cachedEntityFields(key), cmd.Update.GetEntity().TextFields())
+
+	case *api.CommandRequest_Delete, *api.CommandRequest_BatchCreate, *api.CommandRequest_BatchUpdate:
+		// Для этих команд applyMutation берет ключи только из запроса
+		service.applyMutation(request, nil)
+
+	case *api.CommandRequest_CreateUser:
+		service.invalidateKeys(userEmailCacheKey(cmd.CreateUser.Email))
+
+	case *api.CommandRequest_UpdateUser:
+		service.invalidateUser(cmd.UpdateUser.Id)
+
+	case *api.CommandRequest_UseInvite:
+		service.invalidateKeys(userEmailCacheKey(cmd.UseInvite.Email))
+	}
+}
+
+// refreshCache записывает в кэш свежий результат изменяющей команды (write-through)
+func (service *UserDataService) refreshCache(response *api.CommandResponse) {
+	keys := responseCacheKeys(response)
+	if len(keys) == 0 {
+		return
+	}
+
+	service.invalidations.markInvalidated(keys...)
+	for _, key := range keys {
+		// Чтения, начатые до изменения, не должны достаться новым вызовам
+		service.inFlightReads.Forget(key)
+		service.cache.Set(key, response)
+	}
+	log.Printf("💾 Cache refreshed with keys: %v", keys)
+}
+
+// invalidateEntity удаляет из кэша запись таблицы и все производные от нее ответы.
+// previousFields - поля из кэша до изменения, currentFields - новые значения.
+func (service *UserDataService) invalidateEntity(tableName string, id int32, previousFields, currentFields map[string]string) {
+	service.invalidateKeys(entityCacheKey(tableName, id))
+
+	switch {
+	case organizationTables[tableName]:
+		service.invalidateOrganization(id, previousFields["inn"], currentFields["inn"])
+	case userTables[tableName]:
+		service.invalidateUser(id, previousFields["email"], currentFields["email"])
+	default:
+		service.invalidateRelatedOrganization(previousFields)
+		service.invalidateRelatedOrganization(currentFields)
+	}
+}
+
+// invalidateRelatedOrganization инвалидирует организацию, к которой относится запись
+// (адреса, контакты, показатели входят в OrganizationResponse)
+func (service *UserDataService) invalidateRelatedOrganization(fields map[string]string) {
+	if organizationID, ok := parseID(fields["organization_id"]); ok {
+		service.invalidateOrganization(organizationID)
+	}
+}
+
+// invalidateOrganization удаляет все ключи организации, включая ключи по старому и новому ИНН
+func (service *UserDataService) invalidateOrganization(id int32, inns ...string) {
+	key := organizationCacheKey(id)
+	if cached, found := service.cache.Peek(key); found {
+		if response, ok := cached.(*api.CommandResponse); ok {
+			inns = append(inns, response.GetOrganization().GetOrganization().GetInn())
+		}
+	}
+
+	keys := []string{key}
+	for tableName := range organizationTables {
+		keys = append(keys, entityCacheKey(tableName, id))
+	}
+	for _, inn := range inns {
+		if inn != "" {
+			keys = append(keys, organizationInnCacheKey(inn))
+		}
+	}
+	service.invalidateKeys(keys...)
+}
+
+// invalidateUser удаляет все ключи пользователя, включая ключи по email
+func (service *UserDataService) invalidateUser(id int32, emails ...string) {
+	key := userCacheKey(id)
+	if cached, found := service.cache.Peek(key); found {
+		if response, ok := cached.(*api.CommandResponse); ok {
+			emails = append(emails, response.GetUser().GetUser().GetEmail())
+		}
+	}
+
+	keys := []string{key}
+	for tableName := range userTables {
+		keys = append(keys, entityCacheKey(tableName, id))
+	}
+	for _, email := range emails {
+		if email != "" {
+			keys = append(keys, userEmailCacheKey(email))
+		}
+	}
+	service.invalidateKeys(keys...)
+}
+
+func (service *UserDataService) invalidateKeys(keys ...string) {
+	service.invalidations.markInvalidated(keys...)
+	for _, key := range keys {
+		service.inFlightReads.Forget(key)
+		service.cache.Remove(key)
+	}
+	log.Printf("🗑️ Cache invalidated: %v", keys)
+}
+
+// cachedEntityFields возвращает поля закэшированной записи (до ее изменения)
+func (service *UserDataService) cachedEntityFields(key string) map[string]string {
+	if cached, found := service.cache.Peek(key); found {
+		if response, ok := cached.(*api.CommandResponse); ok {
+			return response.GetEntity().GetEntity().GetFields()
+		}
+	}
+	return nil
+}
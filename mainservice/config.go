@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"industrialregistrysystem/base/config"
+	"industrialregistrysystem/mainservice/cache"
+)
+
+// Config настройки mainservice: config/mainservice.yaml, переменные IRS_MAINSERVICE_* и флаги
+type Config struct {
+	// ListenAddress адрес gRPC сервера (DataService, DatabaseService, ManagementService)
+	ListenAddress string `yaml:"listen_address" usage:"gRPC listen address"`
+
+	// MetricsAddress адрес HTTP сервера с метриками в формате Prometheus
+	MetricsAddress string `yaml:"metrics_address" usage:"Prometheus metrics listen address"`
+
+	TLS        config.TLSFiles  `yaml:"tls"`
+	Revocation RevocationConfig `yaml:"revocation"`
+
+	// AuthorizationPolicy политика доступа ролей к методам gRPC
+	AuthorizationPolicy string `yaml:"authorization_policy" usage:"role to gRPC method policy file"`
+
+	// BalancingStrategy стратегия выбора БД для команды
+	BalancingStrategy StrategyType `yaml:"balancing_strategy" usage:"round_robin, least_in_flight or latency_weighted"`
+
+	Cache     cache.Config    `yaml:"cache"`
+	Heartbeat HeartbeatConfig `yaml:"heartbeat"`
+
+	// ShutdownTimeout сколько при остановке ждать выполняемые вызовы и закрытие потоков воркеров
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" usage:"how long to wait for pending calls and workers on shutdown"`
+}
+
+// RevocationConfig источники отозванных сертификатов клиентов
+type RevocationConfig struct {
+	// CRL список отозванных сертификатов, подписанный CA (PEM или DER)
+	CRL string `yaml:"crl" usage:"CRL signed by the CA (optional file)"`
+
+	// DenyList локальный список запрещенных серийных номеров (hex, по одному в строке)
+	DenyList string `yaml:"deny_list" usage:"denied certificate serials, one hex serial per line (optional file)"`
+
+	// ReloadInterval как часто перечитывать CRL и список запрещенных номеров
+	ReloadInterval time.Duration `yaml:"reload_interval" usage:"how often to reload revocation lists"`
+}
+
+// defaultConfig значения по умолчанию, совпадающие с прежними константами
+func defaultConfig() *Config {
+	return &Config{
+		ListenAddress:  ":5051",
+		MetricsAddress: ":9090",
+		TLS:            config.ServiceTLSFiles("mainservice"),
+		Revocation: RevocationConfig{
+			CRL:            "certs/ca/ca.crl",
+			DenyList:       "certs/ca/denied_serials.txt",
+			ReloadInterval: 5 * time.Minute,
+		},
+		AuthorizationPolicy: "config/authorization.json",
+		BalancingStrategy:   LeastInFlightStrategyType,
+		Cache:               defaultCacheConfig(),
+		Heartbeat:           defaultHeartbeatConfig(),
+		ShutdownTimeout:     30 * time.Second,
+	}
+}
+
+// Validate проверяет настройки при запуске
+func (configuration *Config) Validate() error {
+	if err := config.CheckAddress("listen_address", configuration.ListenAddress); err != nil {
+		return err
+	}
+	if err := config.CheckAddress("metrics_address", configuration.MetricsAddress); err != nil {
+		return err
+	}
+	if err := configuration.TLS.Validate(); err != nil {
+		return err
+	}
+	if err := config.CheckPositive("revocation.reload_interval", configuration.Revocation.ReloadInterval); err != nil {
+		return err
+	}
+	if err := config.CheckFile("authorization_policy", configuration.AuthorizationPolicy); err != nil {
+		return err
+	}
+
+	switch configuration.BalancingStrategy {
+	case RoundRobinStrategyType, LeastInFlightStrategyType, LatencyWeightedStrategyType:
+	default:
+		return fmt.Errorf("balancing_strategy: unknown strategy %q", configuration.BalancingStrategy)
+	}
+
+	switch configuration.Cache.Type {
+	case cache.FIFO3CacheType, cache.LRUCacheType, cache.LFUCacheType, cache.ARCCacheType:
+	default:
+		return fmt.Errorf("cache.type: unknown cache type %q", configuration.Cache.Type)
+	}
+	if configuration.Cache.MaxSize <= 0 {
+		return fmt.Errorf("cache.max_size: must be positive, got %d", configuration.Cache.MaxSize)
+	}
+	if configuration.Cache.MaxBytes < 0 {
+		return fmt.Errorf("cache.max_bytes: must not be negative, got %d", configuration.Cache.MaxBytes)
+	}
+
+	if err := config.CheckPositive("shutdown_timeout", configuration.ShutdownTimeout); err != nil {
+		return err
+	}
+
+	heartbeat := configuration.Heartbeat
+	if err := config.CheckPositive("heartbeat.interval", heartbeat.Interval); err != nil {
+		return err
+	}
+	if err := config.CheckPositive("heartbeat.timeout", heartbeat.Timeout); err != nil {
+		return err
+	}
+	if heartbeat.MissedThreshold <= 0 {
+		return fmt.Errorf("heartbeat.missed_threshold: must be positive, got %d", heartbeat.MissedThreshold)
+	}
+	if heartbeat.DisconnectThreshold != 0 && heartbeat.DisconnectThreshold < heartbeat.MissedThreshold {
+		return fmt.Errorf("heartbeat.disconnect_threshold (%d) must not be below missed_threshold (%d)",
+			heartbeat.DisconnectThreshold, heartbeat.MissedThreshold)
+	}
+	return nil
+}
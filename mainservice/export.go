@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"industrialregistrysystem/base/api"
+)
+
+// exportWindow фрагменты выгрузки, которые воркер может отправить без подтверждения;
+// передается воркеру в ExportRequest.window вместе с командой.
+// Канал ожидания вмещает их все, поэтому медленный клиент не блокирует прием
+// ответов БД на другие команды.
+const exportWindow = 4
+
+// Export передает клиенту таблицу фрагментами по мере чтения курсора воркером.
+// Следующий фрагмент разрешается воркеру только после того, как клиент принял
+// предыдущий, поэтому скорость выгрузки определяет клиент. Выгрузка не повторяется
+// на другой БД: часть строк к этому моменту уже может быть отправлена.
+func (service *UserDataService) Export(request *api.ExportRequest, stream api.DataService_ExportServer) error {
+	ctx := stream.Context()
+	connection := service.databaseRegistry.SelectDatabase(service.strategy, nil)
+	if connection == nil {
+		return status.Error(codes.Unavailable, "no databases available")
+	}
+
+	requestID := service.newRequestID("export")
+	responseChan := make(chan *api.CommandResponse, exportWindow+1)
+	service.pendingRequests.Store(requestID, responseChan)
+	defer service.pendingRequests.Delete(requestID)
+
+	connection.inFlight.Add(1)
+	defer connection.inFlight.Add(-1)
+
+	// Окно задает только mainservice: значение клиента не учитывается
+	exportRequest := proto.Clone(request).(*api.ExportRequest)
+	exportRequest.Window = exportWindow
+
+	command := &api.CommandRequest{
+		RequestId: requestID,
+		Command: &api.CommandRequest_Export{
+			Export: exportRequest,
+		},
+	}
+	if err := connection.sendCommand(ctx, command); err != nil {
+		return status.Errorf(codes.Unavailable, "failed to send command to database: %v", err)
+	}
+	log.Printf("📤 Export %s of table %s started on database %s", requestID, request.TableName, connection.ServiceID)
+
+	var totalRows int64
+	for {
+		select {
+		case response := <-responseChan:
+			if errorResponse := response.GetError(); errorResponse != nil {
+				service.metrics.recordDatabaseError(errorResponse.Code)
+				return errorResponseToStatus(errorResponse)
+			}
+			chunk := response.GetExportChunk()
+			if chunk == nil {
+				service.cancelExport(connection, requestID)
+				return status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+			}
+
+			// Send ждет, пока клиент примет данные: до этого воркер не получит новое разрешение
+			if err := stream.Send(chunk); err != nil {
+				service.cancelExport(connection, requestID)
+				return err
+			}
+			totalRows += int64(len(chunk.Entities))
+			if chunk.Last {
+				log.Printf("📤 Export %s finished: %d rows", requestID, totalRows)
+				return nil
+			}
+
+			if err := service.grantExportCredit(ctx, connection, requestID, 1); err != nil {
+				return err
+			}
+
+		case <-connection.Done():
+			return status.Errorf(codes.Unavailable,
+				"database %s disconnected during export %s", connection.ServiceID, requestID)
+
+		case <-ctx.Done():
+			log.Printf("🚫 Export %s cancelled by client after %d rows", requestID, totalRows)
+			service.cancelExport(connection, requestID)
+			return status.FromContextError(ctx.Err()).Err()
+
+		case <-time.After(defaultCommandTimeout):
+			connection.recordFailure()
+			service.cancelExport(connection, requestID)
+			return status.Errorf(codes.DeadlineExceeded,
+				"no export chunk from database %s for request %s", connection.ServiceID, requestID)
+		}
+	}
+}
+
+// grantExportCredit разрешает воркеру отправить еще chunks фрагментов выгрузки
+func (service *UserDataService) grantExportCredit(ctx context.Context, connection *DatabaseConnection, requestID string, chunks int32) error {
+	credit := &api.CommandRequest{
+		RequestId: requestID,
+		Command: &api.CommandRequest_ExportCredit{
+			ExportCredit: &api.ExportCredit{Chunks: chunks},
+		},
+	}
+	if err := connection.sendCommand(ctx, credit); err != nil {
+		if ctx.Err() != nil {
+			service.cancelExport(connection, requestID)
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Unavailable, "failed to send export credit to database: %v", err)
+	}
+	return nil
+}
+
+// cancelExport просит воркер закрыть курсор выгрузки, которую больше никто не читает
+func (service *UserDataService) cancelExport(connection *DatabaseConnection, requestID string) {
+	cancel := &api.CommandRequest{
+		RequestId: requestID,
+		Command: &api.CommandRequest_ExportCredit{
+			ExportCredit: &api.ExportCredit{Cancel: true},
+		},
+	}
+	// Контекст вызова к этому моменту может быть отменен
+	if err := connection.sendCommand(context.Background(), cancel); err != nil {
+		log.Printf("⚠️ Failed to cancel export %s on database %s: %v", requestID, connection.ServiceID, err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"industrialregistrysystem/base/api"
+)
+
+// HeartbeatConfig настройки проверки живости воркеров БД командой health_check
+type HeartbeatConfig struct {
+	// Interval как часто отправлять health_check каждой БД
+	Interval time.Duration `yaml:"interval" usage:"how often to send health_check to each database"`
+
+	// Timeout сколько ждать ответ на один health_check
+	Timeout time.Duration `yaml:"timeout" usage:"how long to wait for a health_check response"`
+
+	// MissedThreshold после стольких пропусков подряд БД перестает получать команды
+	MissedThreshold int32 `yaml:"missed_threshold" usage:"missed heartbeats before a database stops receiving commands"`
+
+	// DisconnectThreshold после стольких пропусков подряд поток команд закрывается
+	// и БД удаляется из реестра (0 - не закрывается)
+	DisconnectThreshold int32 `yaml:"disconnect_threshold" usage:"missed heartbeats before a database is disconnected (0 - never)"`
+}
+
+func defaultHeartbeatConfig() HeartbeatConfig {
+	return HeartbeatConfig{
+		Interval:            15 * time.Second,
+		Timeout:             10 * time.Second,
+		MissedThreshold:     3,
+		DisconnectThreshold: 20,
+	}
+}
+
+// monitorHeartbeats периодически проверяет БД, пока ее поток команд открыт
+func (service *UserDataService) monitorHeartbeats(connection *DatabaseConnection) {
+	ticker := time.NewTicker(service.heartbeat.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-connection.Done():
+			return
+		case <-ticker.C:
+		}
+
+		health, err := service.sendHeartbeat(connection)
+		if err == nil {
+			connection.recordHeartbeat(health)
+			continue
+		}
+
+		missed := connection.missedHeartbeats.Add(1)
+		log.Printf("💔 Heartbeat %d/%d missed by database %s: %v",
+			missed, service.heartbeat.MissedThreshold, connection.ServiceID, err)
+
+		if missed == service.heartbeat.MissedThreshold {
+			connection.heartbeatFailed.Store(true)
+			log.Printf("⚠️ Database %s marked unhealthy: no heartbeat for %d intervals", connection.ServiceID, missed)
+		}
+		if service.heartbeat.DisconnectThreshold > 0 && missed >= service.heartbeat.DisconnectThreshold {
+			log.Printf("🔌 Disconnecting database %s: no heartbeat for %d intervals", connection.ServiceID, missed)
+			service.databaseRegistry.RemoveDatabase(connection)
+			return
+		}
+	}
+}
+
+// sendHeartbeat отправляет health_check именно этой БД, минуя балансировку и повторы
+func (service *UserDataService) sendHeartbeat(connection *DatabaseConnection) (*api.SystemResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), service.heartbeat.Timeout)
+	defer cancel()
+
+	request := &api.CommandRequest{
+		RequestId: service.newRequestID("heartbeat"),
+		Command: &api.CommandRequest_SystemCommand{
+			SystemCommand: "health_check",
+		},
+	}
+
+	responseChan := make(chan *api.CommandResponse, 1)
+	service.pendingRequests.Store(request.RequestId, responseChan)
+	defer service.pendingRequests.Delete(request.RequestId)
+
+	if err := connection.sendCommand(ctx, request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response := <-responseChan:
+		if errorResponse := response.GetError(); errorResponse != nil {
+			return nil, fmt.Errorf("health check failed: %s", errorResponse.Message)
+		}
+		health := response.GetSystem()
+		if health == nil {
+			return nil, fmt.Errorf("invalid health check response type %T", response.Response)
+		}
+		if !health.Success {
+			return nil, fmt.Errorf("%s: %s", health.Message, health.Data["error"])
+		}
+		return health, nil
+	case <-connection.Done():
+		return nil, fmt.Errorf("database disconnected")
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no response within %v", service.heartbeat.Timeout)
+	}
+}
+
+// recordHeartbeat учитывает успешный health_check
+func (connection *DatabaseConnection) recordHeartbeat(health *api.SystemResponse) {
+	connection.lastHeartbeatAt.Store(time.Now().UnixNano())
+	connection.lastHealth.Store(health)
+	connection.missedHeartbeats.Store(0)
+	if connection.heartbeatFailed.Swap(false) {
+		log.Printf("💚 Database %s is healthy again", connection.ServiceID)
+	}
+}
+
+// LastHeartbeat возвращает время последнего успешного health_check и его результат
+func (connection *DatabaseConnection) LastHeartbeat() (time.Time, *api.SystemResponse) {
+	lastHeartbeatAt := connection.lastHeartbeatAt.Load()
+	if lastHeartbeatAt == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(0, lastHeartbeatAt), connection.lastHealth.Load()
+}
+
+// MissedHeartbeats возвращает число пропущенных подряд health_check
+func (connection *DatabaseConnection) MissedHeartbeats() int32 {
+	return connection.missedHeartbeats.Load()
+}
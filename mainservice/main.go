@@ -8,30 +8,53 @@ import (
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"industrialregistrysystem/base/api"
+	"industrialregistrysystem/base/config"
+	"industrialregistrysystem/base/tlsreload"
 	"industrialregistrysystem/mainservice/cache"
 )
 
 // DatabaseConnection представляет аутентифицированное подключение к базе данных
 type DatabaseConnection struct {
-	ServiceID    string
-	DNSName      string
-	ConnectedAt  time.Time
-	PeerInfo     *peer.Peer
-	CommandChan  chan *api.CommandRequest  // Канал для отправки команд этой БД
-	ResponseChan chan *api.CommandResponse // Канал для получения ответов от этой БД
+	ServiceID   string // Уникальный идентификатор: личность сертификата + ID экземпляра
+	InstanceID  string // ID экземпляра из ReadyMessage
+	CertSerial  string // Серийный номер клиентского сертификата (hex)
+	DNSName     string
+	ConnectedAt time.Time
+	PeerInfo    *peer.Peer
+	CommandChan chan *api.CommandRequest // Канал для отправки команд этой БД
+
+	done                chan struct{} // Закрывается при отключении БД
+	closeOnce           sync.Once
+	inFlight            atomic.Int64  // Команды, ожидающие ответа
+	latencyEWMA         atomic.Int64  // Сглаженное время ответа, нс
+	consecutiveFailures atomic.Int32  // Таймауты подряд
+	lastFailureAt       atomic.Int64  // Время последнего таймаута, UnixNano
+	missedHeartbeats    atomic.Int32  // health_check без ответа подряд
+	heartbeatFailed     atomic.Bool   // Пропущено MissedThreshold проверок - команды не направляются
+	lastHeartbeatAt     atomic.Int64  // Время последнего успешного health_check, UnixNano
+	lastHealth          atomic.Pointer[api.SystemResponse]
 }
 
 // DatabaseRegistry реестр аутентифицированных подключений к БД
 type DatabaseRegistry struct {
-	mu          sync.RWMutex
-	connections map[string]*DatabaseConnection
+	mu            sync.RWMutex
+	connections   map[string]*DatabaseConnection
+	streamCounter atomic.Uint64 // Для потоков старых воркеров, не сообщающих ID экземпляра
 }
 
 func NewDatabaseRegistry() *DatabaseRegistry {
@@ -40,21 +63,21 @@ func NewDatabaseRegistry() *DatabaseRegistry {
 	}
 }
 
-// RegisterDatabase регистрирует новое аутентифицированное подключение БД
-func (registry *DatabaseRegistry) RegisterDatabase(ctx context.Context, serviceID string) (*DatabaseConnection, error) {
+// authenticateDatabase проверяет, что подключение использует валидный сертификат роли database
+func (registry *DatabaseRegistry) authenticateDatabase(ctx context.Context) (*peer.Peer, *x509.Certificate, string, error) {
 	peerInfo, ok := peer.FromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("no peer information in context")
+		return nil, nil, "", fmt.Errorf("no peer information in context")
 	}
 
 	// Проверяем TLS аутентификацию
 	tlsAuth, ok := peerInfo.AuthInfo.(credentials.TLSInfo)
 	if !ok {
-		return nil, fmt.Errorf("connection is not using TLS")
+		return nil, nil, "", fmt.Errorf("connection is not using TLS")
 	}
 
 	if len(tlsAuth.State.PeerCertificates) == 0 {
-		return nil, fmt.Errorf("no client certificate provided")
+		return nil, nil, "", fmt.Errorf("no client certificate provided")
 	}
 
 	clientCertificate := tlsAuth.State.PeerCertificates[0]
@@ -62,70 +85,60 @@ func (registry *DatabaseRegistry) RegisterDatabase(ctx context.Context, serviceI
 	// Проверяем, что сертификат выдан для роли database
 	dnsName, valid := registry.validateDatabaseCertificate(clientCertificate)
 	if !valid {
-		return nil, fmt.Errorf("invalid database certificate: DNSNames=%v, OU=%v",
+		return nil, nil, "", fmt.Errorf("invalid database certificate: DNSNames=%v, OU=%v",
 			clientCertificate.DNSNames, clientCertificate.Subject.OrganizationalUnit)
 	}
 
-	connection := &DatabaseConnection{
-		ServiceID:    serviceID,
-		DNSName:      dnsName,
-		ConnectedAt:  time.Now(),
-		PeerInfo:     peerInfo,
-		CommandChan:  make(chan *api.CommandRequest, 100),
-		ResponseChan: make(chan *api.CommandResponse, 100),
-	}
-
-	registry.mu.Lock()
-	registry.connections[serviceID] = connection
-	registry.mu.Unlock()
-
-	log.Printf("✅ Database registered: %s (DNS: %s, Addr: %s)",
-		serviceID, connection.DNSName, peerInfo.Addr.String())
-
-	return connection, nil
+	return peerInfo, clientCertificate, dnsName, nil
 }
 
-// RegisterDatabaseFromStream регистрирует базу данных из CommandStream
-func (registry *DatabaseRegistry) RegisterDatabaseFromStream(stream context.Context, serviceID string) (*DatabaseConnection, error) {
-	peerInfo, ok := peer.FromContext(stream)
-	if !ok {
-		return nil, fmt.Errorf("no peer information in stream context")
-	}
-
-	// Проверяем TLS аутентификацию
-	tlsAuth, ok := peerInfo.AuthInfo.(credentials.TLSInfo)
-	if !ok {
-		return nil, fmt.Errorf("connection is not using TLS")
+// databaseServiceID строит уникальный идентификатор БД из сертификата и ID экземпляра.
+// Предпочитаем URI SAN (уникален для каждого выпущенного воркеру сертификата),
+// иначе используем серийный номер сертификата.
+func databaseServiceID(certificate *x509.Certificate, instanceID string) string {
+	certificateIdentity := "serial:" + certificate.SerialNumber.Text(16)
+	if len(certificate.URIs) > 0 {
+		certificateIdentity = certificate.URIs[0].String()
 	}
+	return certificateIdentity + "/" + instanceID
+}
 
-	if len(tlsAuth.State.PeerCertificates) == 0 {
-		return nil, fmt.Errorf("no client certificate provided")
+// RegisterDatabase регистрирует подключение БД из CommandStream под уникальным идентификатором
+func (registry *DatabaseRegistry) RegisterDatabase(ctx context.Context, instanceID string) (*DatabaseConnection, error) {
+	peerInfo, clientCertificate, dnsName, err := registry.authenticateDatabase(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	clientCertificate := tlsAuth.State.PeerCertificates[0]
-
-	// Проверяем, что сертификат выдан для роли database
-	dnsName, valid := registry.validateDatabaseCertificate(clientCertificate)
-	if !valid {
-		return nil, fmt.Errorf("invalid database certificate: DNSNames=%v, OU=%v",
-			clientCertificate.DNSNames, clientCertificate.Subject.OrganizationalUnit)
+	if instanceID == "" {
+		instanceID = fmt.Sprintf("stream-%d", registry.streamCounter.Add(1))
 	}
 
 	connection := &DatabaseConnection{
-		ServiceID:    serviceID,
-		DNSName:      dnsName,
-		ConnectedAt:  time.Now(),
-		PeerInfo:     peerInfo,
-		CommandChan:  make(chan *api.CommandRequest, 100),
-		ResponseChan: make(chan *api.CommandResponse, 100),
+		ServiceID:   databaseServiceID(clientCertificate, instanceID),
+		InstanceID:  instanceID,
+		CertSerial:  clientCertificate.SerialNumber.Text(16),
+		DNSName:     dnsName,
+		ConnectedAt: time.Now(),
+		PeerInfo:    peerInfo,
+		CommandChan: make(chan *api.CommandRequest, 100),
+		done:        make(chan struct{}),
 	}
 
 	registry.mu.Lock()
-	registry.connections[serviceID] = connection
+	previous, replaced := registry.connections[connection.ServiceID]
+	registry.connections[connection.ServiceID] = connection
 	registry.mu.Unlock()
 
-	log.Printf("✅ Database registered from stream: %s (DNS: %s, Addr: %s)",
-		serviceID, connection.DNSName, peerInfo.Addr.String())
+	// Тот же экземпляр переподключился раньше, чем мы заметили обрыв старого потока
+	if replaced {
+		log.Printf("♻️ Database %s reconnected, closing previous stream from %s",
+			connection.ServiceID, previous.PeerInfo.Addr.String())
+		previous.close()
+	}
+
+	log.Printf("✅ Database registered: %s (DNS: %s, Addr: %s)",
+		connection.ServiceID, connection.DNSName, peerInfo.Addr.String())
 
 	return connection, nil
 }
@@ -184,6 +197,16 @@ func (registry *DatabaseRegistry) GetDatabase(serviceID string) (*DatabaseConnec
 	return connection, exists
 }
 
+// DisconnectRevoked закрывает потоки БД, чьи сертификаты были отозваны после подключения
+func (registry *DatabaseRegistry) DisconnectRevoked(revocationChecker *RevocationChecker) {
+	for _, connection := range registry.ListDatabases() {
+		if reason, revoked := revocationChecker.IsRevoked(connection.CertSerial); revoked {
+			log.Printf("🚫 Disconnecting database %s: certificate %s", connection.ServiceID, reason)
+			registry.RemoveDatabase(connection)
+		}
+	}
+}
+
 // ListDatabases возвращает список всех зарегистрированных БД
 func (registry *DatabaseRegistry) ListDatabases() []*DatabaseConnection {
 	registry.mu.RLock()
@@ -197,17 +220,27 @@ func (registry *DatabaseRegistry) ListDatabases() []*DatabaseConnection {
 	return connections
 }
 
-// RemoveDatabase удаляет подключение БД из реестра
-func (registry *DatabaseRegistry) RemoveDatabase(serviceID string) {
+// RemoveDatabase удаляет подключение БД из реестра.
+// Удаляется только переданное подключение: если под тем же ID уже зарегистрирован
+// новый поток, он не затрагивается.
+func (registry *DatabaseRegistry) RemoveDatabase(connection *DatabaseConnection) {
 	registry.mu.Lock()
 	defer registry.mu.Unlock()
 
-	if connection, exists := registry.connections[serviceID]; exists {
-		log.Printf("🗑️ Database unregistered: %s (DNS: %s)", serviceID, connection.DNSName)
-		close(connection.CommandChan)
-		close(connection.ResponseChan)
-		delete(registry.connections, serviceID)
+	if current, exists := registry.connections[connection.ServiceID]; exists && current == connection {
+		delete(registry.connections, connection.ServiceID)
+		log.Printf("🗑️ Database unregistered: %s (DNS: %s)", connection.ServiceID, connection.DNSName)
 	}
+	connection.close()
+}
+
+// close помечает подключение отключенным.
+// CommandChan не закрываем: в него могут параллельно писать ExecuteCommand,
+// отправители и ожидающие вызовы узнают об отключении через done
+func (connection *DatabaseConnection) close() {
+	connection.closeOnce.Do(func() {
+		close(connection.done)
+	})
 }
 
 // SendCommandToDatabase отправляет команду конкретной базе данных
@@ -220,13 +253,36 @@ func (registry *DatabaseRegistry) SendCommandToDatabase(serviceID string, comman
 		return fmt.Errorf("database with ID %s not found", serviceID)
 	}
 
-	select {
-	case connection.CommandChan <- command:
-		log.Printf("📤 Command sent to database %s: %s", serviceID, command.RequestId)
-		return nil
-	case <-time.After(5 * time.Second):
-		return fmt.Errorf("timeout sending command to database %s", serviceID)
+	if err := connection.sendCommand(context.Background(), command); err != nil {
+		return err
+	}
+	log.Printf("📤 Command sent to database %s: %s", serviceID, command.RequestId)
+	return nil
+}
+
+// SelectDatabase выбирает БД для команды, пропуская уже опробованные.
+// Нездоровые БД используются, только если других не осталось.
+func (registry *DatabaseRegistry) SelectDatabase(strategy SelectionStrategy, exclude map[*DatabaseConnection]bool) *DatabaseConnection {
+	var healthy, unhealthy []*DatabaseConnection
+	for _, connection := range registry.ListDatabases() {
+		// БД, не отвечающая на health_check, не получает команд даже как запасная
+		if exclude[connection] || connection.heartbeatFailed.Load() {
+			continue
+		}
+		if connection.Healthy() {
+			healthy = append(healthy, connection)
+		} else {
+			unhealthy = append(unhealthy, connection)
+		}
+	}
+
+	if len(healthy) > 0 {
+		return strategy.Select(healthy)
+	}
+	if len(unhealthy) > 0 {
+		return strategy.Select(unhealthy)
 	}
+	return nil
 }
 
 // UserDataService реализует оба сервиса: DataService и DatabaseService
@@ -234,23 +290,70 @@ type UserDataService struct {
 	api.UnimplementedDataServiceServer
 	api.UnimplementedDatabaseServiceServer
 	cache            cache.Cache
+	cacheConfig      cache.Config
+	revalidating     sync.Map           // map[string]struct{} - ключи, обновляемые в фоне
+	inFlightReads    singleflight.Group // Одинаковые чтения по ключу кэша выполняются одной командой
 	databaseRegistry *DatabaseRegistry
-	pendingRequests  sync.Map // map[string]chan *api.CommandResponse - ожидающие ответы
+	pendingRequests  sync.Map           // map[string]chan *api.CommandResponse - ожидающие ответы
+	requestCounter   atomic.Uint64
+	strategy         SelectionStrategy
+	invalidations    *invalidationTracker
+	metrics          *ServiceMetrics
+	heartbeat        HeartbeatConfig
+	drain            drainState
+}
+
+const (
+	// defaultCommandTimeout время ожидания ответа БД, если у вызова нет собственного дедлайна
+	defaultCommandTimeout = 30 * time.Second
+
+	// readAttemptTimeout время ожидания одной попытки чтения до повтора на другой БД
+	readAttemptTimeout = 10 * time.Second
+
+	// maxDispatchAttempts максимальное число БД, которым отправляется одна команда
+	maxDispatchAttempts = 3
+)
+
+// defaultCacheConfig настройки кэша ответов БД
+func defaultCacheConfig() cache.Config {
+	return cache.Config{
+		Type:       cache.FIFO3CacheType,
+		MaxSize:    1000,
+		DefaultTTL: 5 * time.Minute,
+		PrefixTTLs: map[string]time.Duration{
+			"org:":    10 * time.Minute,
+			"user:":   2 * time.Minute,
+			"entity:": 5 * time.Minute,
+		},
+		// Проверки здоровья админки постоянно запрашивают несуществующую организацию
+		NegativeTTL:          5 * time.Second,
+		StaleWhileRevalidate: time.Minute,
+		// Организации с показателями и записи с бинарными полями на порядки больше пользователей
+		MaxBytes: 64 << 20,
+	}
 }
 
-func NewUserDataService() *UserDataService {
+func NewUserDataService(configuration *Config) *UserDataService {
 	// Используем фабрику для создания кэша с метриками
-	cacheWithMetrics := cache.NewFIFO3CacheWithMetrics(1000)
+	cacheConfig := configuration.Cache
+	cacheWithMetrics := cache.NewCacheWithMetrics(cacheConfig)
 	
-	return &UserDataService{
+	service := &UserDataService{
 		cache:            cacheWithMetrics,
+		cacheConfig:      cacheConfig,
 		databaseRegistry: NewDatabaseRegistry(),
+		strategy:         NewSelectionStrategy(configuration.BalancingStrategy),
+		invalidations:    newInvalidationTracker(),
+		heartbeat:        configuration.Heartbeat,
 	}
+	service.metrics = NewServiceMetrics(service)
+	return service
 }
 
-// RegisterDatabase регистрирует подключение базы данных с аутентификацией
+// RegisterDatabase проверяет сертификат базы данных и сообщает, под каким ID она будет
+// зарегистрирована. Сама регистрация происходит при открытии CommandStream.
 func (service *UserDataService) RegisterDatabase(ctx context.Context, request *api.DatabaseRegistrationRequest) (*api.DatabaseRegistrationResponse, error) {
-	connection, err := service.databaseRegistry.RegisterDatabase(ctx, request.ServiceId)
+	_, clientCertificate, dnsName, err := service.databaseRegistry.authenticateDatabase(ctx)
 	if err != nil {
 		log.Printf("❌ Database registration failed: %v", err)
 		return &api.DatabaseRegistrationResponse{
@@ -259,13 +362,14 @@ func (service *UserDataService) RegisterDatabase(ctx context.Context, request *a
 		}, nil
 	}
 
-	log.Printf("✅ Database %s ready to receive commands", request.ServiceId)
+	serviceID := databaseServiceID(clientCertificate, request.ServiceId)
+	log.Printf("✅ Database %s authenticated, waiting for command stream", serviceID)
 
 	return &api.DatabaseRegistrationResponse{
 		Success:    true,
-		ServiceId:  connection.ServiceID,
-		CommonName: connection.DNSName,
-		Timestamp:  connection.ConnectedAt.Format(time.RFC3339),
+		ServiceId:  serviceID,
+		CommonName: dnsName,
+		Timestamp:  time.Now().Format(time.RFC3339),
 	}, nil
 }
 
@@ -279,97 +383,89 @@ func (service *UserDataService) CommandStream(stream api.DatabaseService_Command
 
 	log.Printf("🔗 New command stream connection from: %s", peerInfo.Addr.String())
 
-	// Получаем ServiceID из TLS сертификата
-	serviceID := service.getServiceIDFromContext(stream.Context())
-	if serviceID == "" {
-		return fmt.Errorf("cannot determine service ID from certificate")
+	// Первым сообщением воркер присылает ReadyMessage с ID своего экземпляра
+	firstResponse, receiveError := stream.Recv()
+	if receiveError != nil {
+		log.Printf("❌ Database stream from %s closed before ready message: %v", peerInfo.Addr.String(), receiveError)
+		return receiveError
 	}
 
-	// Проверяем, зарегистрирована ли уже база данных
-	_, exists := service.databaseRegistry.GetDatabase(serviceID)
-	if !exists {
-		// Если не зарегистрирована, регистрируем её автоматически из потока
-		log.Printf("📝 Auto-registering database from stream: %s", serviceID)
-		_, err := service.databaseRegistry.RegisterDatabaseFromStream(stream.Context(), serviceID)
-		if err != nil {
-			log.Printf("❌ Failed to auto-register database %s: %v", serviceID, err)
-			return err
-		}
+	connection, err := service.databaseRegistry.RegisterDatabase(stream.Context(), firstResponse.GetReady().GetInstanceId())
+	if err != nil {
+		log.Printf("❌ Failed to register database from %s: %v", peerInfo.Addr.String(), err)
+		return status.Error(codes.PermissionDenied, err.Error())
 	}
+	defer service.databaseRegistry.RemoveDatabase(connection)
 
-	// Получаем соединение с БД
-	connection, exists := service.databaseRegistry.GetDatabase(serviceID)
-	if !exists {
-		return fmt.Errorf("database %s not found after registration", serviceID)
-	}
+	service.metrics.streamConnects.Inc()
+	defer service.metrics.streamDisconnects.Inc()
+
+	go service.monitorHeartbeats(connection)
+
+	serviceID := connection.ServiceID
+	service.processDatabaseResponse(firstResponse)
 
 	log.Printf("🔧 Database %s connected to command stream", serviceID)
 
 	// Горутина для отправки команд клиенту
 	go func() {
-		for command := range connection.CommandChan {
-			log.Printf("📤 Sending command to database %s: %s", serviceID, command.RequestId)
-			if err := stream.Send(command); err != nil {
-				log.Printf("❌ Failed to send command to database %s: %v", serviceID, err)
+		for {
+			select {
+			case command := <-connection.CommandChan:
+				log.Printf("📤 Sending command to database %s: %s", serviceID, command.RequestId)
+				if err := stream.Send(command); err != nil {
+					log.Printf("❌ Failed to send command to database %s: %v", serviceID, err)
+					return
+				}
+			case <-connection.Done():
+				return
+			case <-stream.Context().Done():
 				return
 			}
 		}
 	}()
 
-	// Основной цикл обработки ответов от клиента
-	for {
-		// Получаем CommandResponse от клиента (базы данных)
-		commandResponse, receiveError := stream.Recv()
-		if receiveError != nil {
-			log.Printf("❌ Error receiving from database %s: %v", serviceID, receiveError)
-			
-			// Удаляем базу данных из реестра при отключении
-			service.databaseRegistry.RemoveDatabase(serviceID)
-			return receiveError
-		}
-
-		log.Printf("📨 Received response from database %s for request: %s", serviceID, commandResponse.RequestId)
-
-		// Обрабатываем ответ от базы данных
-		service.processDatabaseResponse(commandResponse)
-	}
-}
+	// Цикл обработки ответов от клиента выполняется в отдельной горутине,
+	// чтобы сервер мог закрыть поток, когда подключение вытеснено или отключено
+	receiveErrors := make(chan error, 1)
+	go func() {
+		for {
+			// Получаем CommandResponse от клиента (базы данных)
+			commandResponse, receiveError := stream.Recv()
+			if receiveError != nil {
+				receiveErrors <- receiveError
+				return
+			}
 
-// getServiceIDFromContext извлекает ServiceID из TLS контекста
-func (service *UserDataService) getServiceIDFromContext(ctx context.Context) string {
-	peerInfo, ok := peer.FromContext(ctx)
-	if !ok {
-		return ""
-	}
+			log.Printf("📨 Received response from database %s for request: %s", serviceID, commandResponse.RequestId)
 
-	tlsAuth, ok := peerInfo.AuthInfo.(credentials.TLSInfo)
-	if !ok || len(tlsAuth.State.PeerCertificates) == 0 {
-		return ""
-	}
+			// Обрабатываем ответ от базы данных
+			service.processDatabaseResponse(commandResponse)
+		}
+	}()
 
-	// Используем первый DNS Name как ServiceID
-	// В сертификате database DNSNames должно содержать "database"
-	cert := tlsAuth.State.PeerCertificates[0]
-	if len(cert.DNSNames) > 0 {
-		return cert.DNSNames[0]
+	select {
+	case receiveError := <-receiveErrors:
+		log.Printf("❌ Error receiving from database %s: %v", serviceID, receiveError)
+		return receiveError
+	case <-connection.Done():
+		log.Printf("🔌 Command stream of database %s closed by server", serviceID)
+		return status.Error(codes.Aborted, "database connection closed by server")
 	}
-	
-	return ""
 }
 
 // processDatabaseResponse обрабатывает ответ от базы данных
 func (service *UserDataService) processDatabaseResponse(response *api.CommandResponse) {
-	// Здесь можно добавить логику обработки ответов от базы данных
-	// Например, кэширование результатов, уведомление ожидающих горутин и т.д.
 	log.Printf("🔧 Processing response for request: %s", response.RequestId)
-	
-	// Кэшируем успешные ответы
-	if response.Response != nil {
-		cacheKey := service.generateCacheKey(response.RequestId, response.Response)
-		if cacheKey != "" {
-			service.cache.Set(cacheKey, response)
-			log.Printf("💾 Response cached with key: %s", cacheKey)
+
+	// Передаем ответ ожидающему вызову ExecuteCommand. Выгрузка получает несколько
+	// фрагментов с одним request_id, поэтому ожидание снимается только после последнего.
+	if chunk := response.GetExportChunk(); chunk != nil && !chunk.Last {
+		if waiter, found := service.pendingRequests.Load(response.RequestId); found {
+			waiter.(chan *api.CommandResponse) <- response
 		}
+	} else if waiter, found := service.pendingRequests.LoadAndDelete(response.RequestId); found {
+		waiter.(chan *api.CommandResponse) <- response
 	}
 	
 	// Простая обработка: логируем тип ответа
@@ -382,6 +478,10 @@ func (service *UserDataService) processDatabaseResponse(response *api.CommandRes
 		log.Printf("📄 Received entity data for request: %s", response.RequestId)
 	case *api.CommandResponse_List:
 		log.Printf("📋 Received list data for request: %s", response.RequestId)
+	case *api.CommandResponse_Schema:
+		log.Printf("🗂️ Received schema for request: %s", response.RequestId)
+	case *api.CommandResponse_ExportChunk:
+		log.Printf("📤 Received export chunk %d for request: %s", response.GetExportChunk().Sequence, response.RequestId)
 	case *api.CommandResponse_Error:
 		errorResp := response.GetError()
 		log.Printf("❌ Received error for request %s: %s", response.RequestId, errorResp.Message)
@@ -393,119 +493,224 @@ func (service *UserDataService) processDatabaseResponse(response *api.CommandRes
 	}
 }
 
-// generateCacheKey генерирует ключ кэша на основе запроса и ответа
-func (service *UserDataService) generateCacheKey(requestId string, response interface{}) string {
-	// Генерируем ключ кэша на основе типа данных и идентификатора
-	switch resp := response.(type) {
-	case *api.CommandResponse_Organization:
-		if org := resp.Organization; org != nil && org.Organization != nil {
-			return fmt.Sprintf("org:%d", org.Organization.Id)
-		}
-	case *api.CommandResponse_User:
-		if user := resp.User; user != nil && user.User != nil {
-			return fmt.Sprintf("user:%d", user.User.Id)
-		}
-	case *api.CommandResponse_Entity:
-		if entityResp := resp.Entity; entityResp != nil && entityResp.Entity != nil {
-			// Для Entity используем комбинацию table_name и полей
-			if id, ok := entityResp.Entity.Fields["id"]; ok {
-				return fmt.Sprintf("entity:%s:%s", entityResp.TableName, id)
-			}
-		}
-	}
-	return ""
-}
-
-// ExecuteCommand отправляет команду зарегистрированной базе данных
+// ExecuteCommand отправляет команду одной из зарегистрированных баз данных и ожидает ответ.
+// Читающие команды при отключении или таймауте БД повторяются на другой БД.
 func (service *UserDataService) ExecuteCommand(ctx context.Context, request *api.CommandRequest) (*api.CommandResponse, error) {
 	// Проверяем кэш перед выполнением команды
-	if cachedResponse, found := service.tryGetFromCache(request); found {
+	if cachedResponse, cachedError, found := service.tryGetFromCache(request); found {
 		log.Printf("💾 Using cached response for request: %s", request.RequestId)
-		return cachedResponse, nil
+		return cachedResponse, cachedError
 	}
 
-	// Выбираем базу данных для выполнения команды
-	databases := service.databaseRegistry.ListDatabases()
-	if len(databases) == 0 {
-		return nil, fmt.Errorf("no databases available")
+	// Одновременные промахи по одному ключу кэша ждут одну команду
+	if cacheKey := requestCacheKey(request); cacheKey != "" {
+		return service.executeCoalesced(ctx, cacheKey, request)
 	}
 
-	// Простая стратегия: выбираем первую доступную БД
-	targetDatabase := databases[0].ServiceID
+	return service.executeOnDatabase(ctx, request)
+}
 
-	log.Printf("🔧 Executing command via database %s: %s", targetDatabase, request.RequestId)
+// executeCoalesced выполняет чтение так, что по одному ключу в БД уходит только одна команда,
+// а ее ответ получают все ожидающие. Команда не привязана к контексту первого вызова:
+// его отмена не должна завершать ожидание остальных.
+func (service *UserDataService) executeCoalesced(ctx context.Context, cacheKey string, request *api.CommandRequest) (*api.CommandResponse, error) {
+	resultChan := service.inFlightReads.DoChan(cacheKey, func() (interface{}, error) {
+		commandContext, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultCommandTimeout)
+		defer cancel()
+		return service.executeOnDatabase(commandContext, request)
+	})
 
-	// Отправляем команду выбранной БД
-	err := service.databaseRegistry.SendCommandToDatabase(targetDatabase, request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send command to database: %v", err)
-	}
-
-	// В реальной реализации здесь должно быть ожидание ответа от БД
-	// Для простоты возвращаем заглушку с использованием SystemResponse
-	return &api.CommandResponse{
-		RequestId: request.RequestId,
-		Response: &api.CommandResponse_System{
-			System: &api.SystemResponse{
-				Success: true,
-				Message: "Command sent to database for processing",
-				Data:    map[string]string{"database": targetDatabase},
-			},
-		},
-	}, nil
+	select {
+	case result := <-resultChan:
+		if result.Shared {
+			log.Printf("🤝 Request %s coalesced with in-flight read of %s", request.RequestId, cacheKey)
+		}
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*api.CommandResponse), nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
 }
 
-// tryGetFromCache пытается получить результат из кэша
-func (service *UserDataService) tryGetFromCache(request *api.CommandRequest) (*api.CommandResponse, bool) {
-	var cacheKey string
-	
-	// Генерируем ключ кэша в зависимости от типа команды
-	switch cmd := request.Command.(type) {
-	case *api.CommandRequest_GetOrganization:
-		if cmd.GetOrganization != nil {
-			// Получаем идентификатор из oneof
-			switch identifier := cmd.GetOrganization.Identifier.(type) {
-			case *api.GetOrganizationRequest_Id:
-				cacheKey = fmt.Sprintf("org:%d", identifier.Id)
-			case *api.GetOrganizationRequest_Inn:
-				cacheKey = fmt.Sprintf("org:inn:%s", identifier.Inn)
-			}
+// executeOnDatabase выполняет команду на базе данных, минуя чтение из кэша
+func (service *UserDataService) executeOnDatabase(ctx context.Context, request *api.CommandRequest) (*api.CommandResponse, error) {
+	// Если вызывающая сторона не задала дедлайн, ограничиваем ожидание сами
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultCommandTimeout)
+		defer cancel()
+	}
+
+	// Регистрируем ожидание ответа до отправки команды, чтобы не пропустить быстрый ответ.
+	// При повторах используется тот же RequestId, поэтому годится ответ любой из БД.
+	responseChan := make(chan *api.CommandResponse, 1)
+	if _, duplicate := service.pendingRequests.LoadOrStore(request.RequestId, responseChan); duplicate {
+		return nil, status.Errorf(codes.AlreadyExists, "request %s is already pending", request.RequestId)
+	}
+	defer service.pendingRequests.Delete(request.RequestId)
+
+	idempotent := isIdempotentCommand(request)
+	attempted := make(map[*DatabaseConnection]bool)
+	lastError := status.Error(codes.Unavailable, "no databases available")
+	dispatchedAt := time.Now()
+
+	for attempt := 0; attempt < maxDispatchAttempts; attempt++ {
+		connection := service.databaseRegistry.SelectDatabase(service.strategy, attempted)
+		if connection == nil {
+			break
 		}
-	case *api.CommandRequest_GetUser:
-		if cmd.GetUser != nil {
-			// Получаем идентификатор из oneof
-			switch identifier := cmd.GetUser.Identifier.(type) {
-			case *api.GetUserRequest_Id:
-				cacheKey = fmt.Sprintf("user:%d", identifier.Id)
-			case *api.GetUserRequest_Email:
-				cacheKey = fmt.Sprintf("user:email:%s", identifier.Email)
+		attempted[connection] = true
+
+		response, retryable, err := service.dispatchCommand(ctx, connection, request, responseChan, idempotent)
+		if err == nil {
+			// Чтения кэшируем, изменения обновляют или инвалидируют затронутые ключи
+			if idempotent {
+				service.cacheResponse(request, response, dispatchedAt)
+			} else {
+				service.applyMutation(request, response)
+			}
+		} else if !idempotent {
+			switch status.Code(err) {
+			case codes.DeadlineExceeded, codes.Unavailable:
+				// Ответа нет, но изменение могло быть зафиксировано: инвалидируем с запасом
+				service.invalidateUncertainMutation(request)
 			}
 		}
-	case *api.CommandRequest_Get:
-		if cmd.Get != nil {
-			if cmd.Get.Id != 0 {
-				cacheKey = fmt.Sprintf("entity:%s:%d", cmd.Get.TableName, cmd.Get.Id)
+		if !retryable {
+			if idempotent && status.Code(err) == codes.NotFound {
+				service.cacheNotFound(request, err, dispatchedAt)
 			}
+			return response, err
 		}
+
+		lastError = err
+		log.Printf("🔁 Retrying request %s on another database after failure on %s: %v",
+			request.RequestId, connection.ServiceID, err)
 	}
-	
+
+	return nil, lastError
+}
+
+// dispatchCommand выполняет одну попытку отправки команды конкретной БД.
+// retryable означает, что команду можно повторить на другой БД.
+func (service *UserDataService) dispatchCommand(ctx context.Context, connection *DatabaseConnection, request *api.CommandRequest,
+	responseChan chan *api.CommandResponse, idempotent bool) (response *api.CommandResponse, retryable bool, err error) {
+	attemptContext := ctx
+	if idempotent {
+		var cancel context.CancelFunc
+		attemptContext, cancel = context.WithTimeout(ctx, readAttemptTimeout)
+		defer cancel()
+	}
+
+	connection.inFlight.Add(1)
+	defer connection.inFlight.Add(-1)
+
+	log.Printf("🔧 Executing command via database %s: %s", connection.ServiceID, request.RequestId)
+	startedAt := time.Now()
+
+	// Команда, не попавшая в очередь БД, не выполнялась, поэтому ее можно повторить в любом случае
+	if err := connection.sendCommand(attemptContext, request); err != nil {
+		connection.recordFailure()
+		if ctx.Err() != nil {
+			return nil, false, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, true, status.Errorf(codes.Unavailable, "failed to send command to database: %v", err)
+	}
+
+	// Ожидаем ответ от БД, ее отключение или истечение контекста
+	select {
+	case response := <-responseChan:
+		connection.recordSuccess(time.Since(startedAt))
+		if errorResponse := response.GetError(); errorResponse != nil {
+			service.metrics.recordDatabaseError(errorResponse.Code)
+			// Останавливающийся воркер отклоняет команды, не начиная их: можно выполнить на другой БД
+			return nil, errorResponse.Code == "UNAVAILABLE", errorResponseToStatus(errorResponse)
+		}
+		return response, false, nil
+	case <-connection.Done():
+		return nil, idempotent, status.Errorf(codes.Unavailable,
+			"database %s disconnected while processing request %s", connection.ServiceID, request.RequestId)
+	case <-attemptContext.Done():
+		connection.recordFailure()
+		log.Printf("⏰ No response from database %s for request %s: %v", connection.ServiceID, request.RequestId, attemptContext.Err())
+		if ctx.Err() != nil {
+			return nil, false, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, true, status.FromContextError(attemptContext.Err()).Err()
+	}
+}
+
+// errorResponseToStatus преобразует ErrorResponse базы данных в gRPC статус
+func errorResponseToStatus(errorResponse *api.ErrorResponse) error {
+	code := codes.Internal
+	switch errorResponse.Code {
+	case "NOT_FOUND":
+		code = codes.NotFound
+	case "INVALID_ARGUMENT":
+		code = codes.InvalidArgument
+	case "ALREADY_EXISTS":
+		code = codes.AlreadyExists
+	case "FAILED_PRECONDITION":
+		code = codes.FailedPrecondition
+	case "PERMISSION_DENIED":
+		code = codes.PermissionDenied
+	case "DEADLINE_EXCEEDED":
+		code = codes.DeadlineExceeded
+	case "UNAVAILABLE":
+		code = codes.Unavailable
+	case "UNIMPLEMENTED":
+		code = codes.Unimplemented
+	case "":
+		// Старые версии сервиса БД не заполняют код ошибки
+		if strings.Contains(errorResponse.Message, "no rows in result set") {
+			code = codes.NotFound
+		}
+	}
+
+	if errorResponse.Details != "" {
+		return status.Errorf(code, "%s: %s", errorResponse.Message, errorResponse.Details)
+	}
+	return status.Error(code, errorResponse.Message)
+}
+
+// newRequestID генерирует уникальный идентификатор запроса
+func (service *UserDataService) newRequestID(prefix string) string {
+	return fmt.Sprintf("%s_%d_%d", prefix, time.Now().UnixNano(), service.requestCounter.Add(1))
+}
+
+// tryGetFromCache пытается получить результат из кэша. Отрицательная запись
+// возвращается как ошибка NotFound; истекшая организация отдается один раз,
+// пока в фоне запрашивается свежая.
+func (service *UserDataService) tryGetFromCache(request *api.CommandRequest) (*api.CommandResponse, error, bool) {
+	cacheKey := requestCacheKey(request)
 	if cacheKey == "" {
-		return nil, false
+		return nil, nil, false
 	}
 	
-	if cached, found := service.cache.Get(cacheKey); found {
-		if response, ok := cached.(*api.CommandResponse); ok {
-			return response, true
-		}
+	cached, fresh, found := service.cache.GetStale(cacheKey)
+	if !found {
+		return nil, nil, false
+	}
+	if !fresh && !service.startRevalidation(cacheKey, request) {
+		return nil, nil, false
+	}
+	
+	switch entry := cached.(type) {
+	case *api.CommandResponse:
+		return entry, nil, true
+	case notFoundEntry:
+		return nil, status.Error(codes.NotFound, entry.message), true
 	}
 	
-	return nil, false
+	return nil, nil, false
 }
 
 // Методы DataService - теперь они используют ExecuteCommand для отправки команд БД
 func (service *UserDataService) GetOrganization(ctx context.Context, request *api.GetOrganizationRequest) (*api.OrganizationResponse, error) {
 	command := &api.CommandRequest{
-		RequestId: fmt.Sprintf("org_%d", time.Now().UnixNano()),
+		RequestId: service.newRequestID("org"),
 		Command: &api.CommandRequest_GetOrganization{
 			GetOrganization: request,
 		},
@@ -521,12 +726,12 @@ func (service *UserDataService) GetOrganization(ctx context.Context, request *ap
 		return orgResponse, nil
 	}
 
-	return nil, fmt.Errorf("invalid response type")
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
 }
 
 func (service *UserDataService) GetUser(ctx context.Context, request *api.GetUserRequest) (*api.UserResponse, error) {
 	command := &api.CommandRequest{
-		RequestId: fmt.Sprintf("user_%d", time.Now().UnixNano()),
+		RequestId: service.newRequestID("user"),
 		Command: &api.CommandRequest_GetUser{
 			GetUser: request,
 		},
@@ -541,7 +746,367 @@ func (service *UserDataService) GetUser(ctx context.Context, request *api.GetUse
 		return userResponse, nil
 	}
 
-	return nil, fmt.Errorf("invalid response type")
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) Create(ctx context.Context, request *api.CreateRequest) (*api.EntityResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("create"),
+		Command: &api.CommandRequest_Create{
+			Create: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if entityResponse := response.GetEntity(); entityResponse != nil {
+		return entityResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) Get(ctx context.Context, request *api.GetRequest) (*api.EntityResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("get"),
+		Command: &api.CommandRequest_Get{
+			Get: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if entityResponse := response.GetEntity(); entityResponse != nil {
+		return entityResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) Update(ctx context.Context, request *api.UpdateRequest) (*api.EntityResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("update"),
+		Command: &api.CommandRequest_Update{
+			Update: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if entityResponse := response.GetEntity(); entityResponse != nil {
+		return entityResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) Delete(ctx context.Context, request *api.DeleteRequest) (*api.DeleteResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("delete"),
+		Command: &api.CommandRequest_Delete{
+			Delete: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleteResponse := response.GetDelete(); deleteResponse != nil {
+		return deleteResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) List(ctx context.Context, request *api.ListRequest) (*api.ListResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("list"),
+		Command: &api.CommandRequest_List{
+			List: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if listResponse := response.GetList(); listResponse != nil {
+		return listResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) Search(ctx context.Context, request *api.SearchRequest) (*api.ListResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("search"),
+		Command: &api.CommandRequest_Search{
+			Search: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if listResponse := response.GetList(); listResponse != nil {
+		return listResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) ListOrganizations(ctx context.Context, request *api.ListOrganizationsRequest) (*api.ListOrganizationsResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("orgs"),
+		Command: &api.CommandRequest_ListOrganizations{
+			ListOrganizations: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationsResponse := response.GetOrganizations(); organizationsResponse != nil {
+		return organizationsResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) SearchOrganizations(ctx context.Context, request *api.SearchOrganizationsRequest) (*api.ListOrganizationsResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("org_search"),
+		Command: &api.CommandRequest_SearchOrganizations{
+			SearchOrganizations: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationsResponse := response.GetOrganizations(); organizationsResponse != nil {
+		return organizationsResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) CreateUser(ctx context.Context, request *api.CreateUserRequest) (*api.UserResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("user_create"),
+		Command: &api.CommandRequest_CreateUser{
+			CreateUser: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if userResponse := response.GetUser(); userResponse != nil {
+		return userResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) UpdateUser(ctx context.Context, request *api.UpdateUserRequest) (*api.UserResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("user_update"),
+		Command: &api.CommandRequest_UpdateUser{
+			UpdateUser: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if userResponse := response.GetUser(); userResponse != nil {
+		return userResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) CreateInvite(ctx context.Context, request *api.CreateInviteRequest) (*api.InviteResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("invite_create"),
+		Command: &api.CommandRequest_CreateInvite{
+			CreateInvite: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if inviteResponse := response.GetInvite(); inviteResponse != nil {
+		return inviteResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) ValidateInvite(ctx context.Context, request *api.ValidateInviteRequest) (*api.InviteResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("invite_validate"),
+		Command: &api.CommandRequest_ValidateInvite{
+			ValidateInvite: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if inviteResponse := response.GetInvite(); inviteResponse != nil {
+		return inviteResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) UseInvite(ctx context.Context, request *api.UseInviteRequest) (*api.InviteResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("invite_use"),
+		Command: &api.CommandRequest_UseInvite{
+			UseInvite: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if inviteResponse := response.GetInvite(); inviteResponse != nil {
+		return inviteResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) SubmitForm(ctx context.Context, request *api.SubmitFormRequest) (*api.FormResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("form"),
+		Command: &api.CommandRequest_SubmitForm{
+			SubmitForm: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if formResponse := response.GetForm(); formResponse != nil {
+		return formResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) GetFinancialData(ctx context.Context, request *api.GetFinancialDataRequest) (*api.FinancialDataResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("financial"),
+		Command: &api.CommandRequest_GetFinancialData{
+			GetFinancialData: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if financialResponse := response.GetFinancialData(); financialResponse != nil {
+		return financialResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) GetStaffData(ctx context.Context, request *api.GetStaffDataRequest) (*api.StaffDataResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("staff"),
+		Command: &api.CommandRequest_GetStaffData{
+			GetStaffData: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if staffResponse := response.GetStaffData(); staffResponse != nil {
+		return staffResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) BatchCreate(ctx context.Context, request *api.BatchCreateRequest) (*api.BatchResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("batch_create"),
+		Command: &api.CommandRequest_BatchCreate{
+			BatchCreate: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchResponse := response.GetBatch(); batchResponse != nil {
+		return batchResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
+}
+
+func (service *UserDataService) BatchUpdate(ctx context.Context, request *api.BatchUpdateRequest) (*api.BatchResponse, error) {
+	command := &api.CommandRequest{
+		RequestId: service.newRequestID("batch_update"),
+		Command: &api.CommandRequest_BatchUpdate{
+			BatchUpdate: request,
+		},
+	}
+
+	response, err := service.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchResponse := response.GetBatch(); batchResponse != nil {
+		return batchResponse, nil
+	}
+
+	return nil, status.Errorf(codes.Internal, "invalid response type %T", response.Response)
 }
 
 // GetCacheMetrics возвращает метрики кэша (для мониторинга)
@@ -570,15 +1135,16 @@ func (service *UserDataService) RemoveFromCache(key string) {
 }
 
 // loadTLSCredentials загружает TLS сертификаты для сервера
-func loadTLSCredentials() (credentials.TransportCredentials, error) {
-	// Загружаем сертификат сервера
-	serverCertificate, serverError := tls.LoadX509KeyPair("certs/mainservice/mainservice-fullchain.crt", "certs/mainservice/mainservice.key")
+func loadTLSCredentials(files config.TLSFiles, revocationChecker *RevocationChecker) (credentials.TransportCredentials, error) {
+	// Загружаем сертификат сервера; при обновлении файлов он перечитывается без перезапуска
+	certificateReloader, serverError := tlsreload.NewCertificateReloader(files.Certificate, files.Key)
 	if serverError != nil {
 		return nil, fmt.Errorf("failed to load server certificates: %v", serverError)
 	}
+	go certificateReloader.Watch(files.ReloadInterval)
 
 	// Загружаем CA сертификат
-	caCertificate, caError := os.ReadFile("certs/ca/ca.crt")
+	caCertificate, caError := os.ReadFile(files.CA)
 	if caError != nil {
 		return nil, fmt.Errorf("failed to read CA certificate: %v", caError)
 	}
@@ -590,46 +1156,102 @@ func loadTLSCredentials() (credentials.TransportCredentials, error) {
 
 	// Настраиваем TLS конфигурацию с правильной верификацией
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{serverCertificate},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    certificatePool,
-		MinVersion:   tls.VersionTLS12,
+		// Новые рукопожатия получают актуальный сертификат, открытые потоки не разрываются
+		GetCertificate: certificateReloader.GetCertificate,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		ClientCAs:      certificatePool,
+		MinVersion:     tls.VersionTLS12,
+		// Отозванные сертификаты отклоняются уже при рукопожатии
+		VerifyPeerCertificate: revocationChecker.VerifyPeerCertificate,
 	}
 
 	return credentials.NewTLS(tlsConfig), nil
 }
 
 func main() {
+	// Загружаем настройки: файл, переменные окружения, флаги
+	configuration := defaultConfig()
+	printConfig, configError := config.Load("mainservice", configuration, os.Args[1:])
+	if configError != nil {
+		log.Fatalf("❌ Failed to load configuration: %v", configError)
+	}
+	if printConfig {
+		if printError := config.Print(os.Stdout, configuration); printError != nil {
+			log.Fatalf("❌ Failed to print configuration: %v", printError)
+		}
+		return
+	}
+
+	// Загружаем списки отозванных сертификатов
+	revocationChecker, revocationError := NewRevocationChecker(configuration.TLS.CA, configuration.Revocation.CRL, configuration.Revocation.DenyList)
+	if revocationError != nil {
+		log.Fatalf("❌ Failed to load revocation lists: %v", revocationError)
+	}
+
 	// Загружаем TLS credentials
-	tlsCredentials, credentialsError := loadTLSCredentials()
+	tlsCredentials, credentialsError := loadTLSCredentials(configuration.TLS, revocationChecker)
 	if credentialsError != nil {
 		log.Fatalf("❌ Failed to load TLS credentials: %v", credentialsError)
 	}
 
+	userDataService := NewUserDataService(configuration)
+
+	// После обновления CRL отключаем уже подключенные БД с отозванными сертификатами
+	revocationChecker.onReload = func() {
+		userDataService.databaseRegistry.DisconnectRevoked(revocationChecker)
+	}
+	go revocationChecker.Watch(configuration.Revocation.ReloadInterval)
+
+	// Загружаем политику доступа ролей клиентов к методам
+	authorizer, authorizationError := NewAuthorizer(configuration.AuthorizationPolicy)
+	if authorizationError != nil {
+		log.Fatalf("❌ Failed to load authorization policy: %v", authorizationError)
+	}
+
 	// Создаем gRPC сервер с TLS
 	grpcServer := grpc.NewServer(
 		grpc.Creds(tlsCredentials),
+		// Метрики первыми, чтобы отказы в доступе тоже учитывались
+		grpc.ChainUnaryInterceptor(userDataService.metrics.UnaryInterceptor, authorizer.UnaryInterceptor,
+			userDataService.DrainInterceptor),
+		grpc.ChainStreamInterceptor(userDataService.metrics.StreamInterceptor, authorizer.StreamInterceptor,
+			userDataService.DrainStreamInterceptor),
+		// HTTP/2 пинги обнаруживают оборванные соединения воркеров, у которых поток команд простаивает
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             20 * time.Second,
+			PermitWithoutStream: true,
+		}),
 	)
-
-	userDataService := NewUserDataService()
 	
 	// Регистрируем оба сервиса
 	api.RegisterDataServiceServer(grpcServer, userDataService)
 	api.RegisterDatabaseServiceServer(grpcServer, userDataService)
+	api.RegisterManagementServiceServer(grpcServer, NewManagementService(userDataService))
+
+	if policyError := authorizer.Validate(grpcServer.GetServiceInfo()); policyError != nil {
+		log.Fatalf("❌ Invalid authorization policy: %v", policyError)
+	}
 
 	// Запускаем сервер
-	listener, listenerError := net.Listen("tcp", ":5051")
+	listener, listenerError := net.Listen("tcp", configuration.ListenAddress)
 	if listenerError != nil {
 		log.Fatalf("❌ Failed to listen: %v", listenerError)
 	}
 
-	log.Println("🔐 User Data Service (gRPC Server with mTLS) running on :5051")
+	log.Printf("🔐 User Data Service (gRPC Server with mTLS) running on %s", configuration.ListenAddress)
 	log.Println("   TLS: Enabled (mutual authentication required)")
 	log.Println("   Database authentication: Certificate-based (DNS Names)")
-	log.Println("   Cache: FIFO3 with metrics enabled")
+	log.Printf("   Cache: %s with metrics enabled", userDataService.cacheConfig.Type)
 	log.Println("   Available commands:")
 	log.Println("   - GetOrganization, GetUser, CreateUser, ListOrganizations, etc.")
-	log.Println("   Registered services: DataService, DatabaseService")
+	log.Println("   Registered services: DataService, DatabaseService, ManagementService")
+
+	// Метрики в формате Prometheus
+	go userDataService.metrics.Serve(configuration.MetricsAddress)
 
 	// Запускаем горутину для логирования метрик кэша
 	go func() {
@@ -642,7 +1264,24 @@ func main() {
 		}
 	}()
 
+	// По SIGINT/SIGTERM дожидаемся выполняемых вызовов и закрытия потоков воркеров
+	shutdownComplete := make(chan struct{})
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		received := <-signals
+		log.Printf("🛑 Received %s, shutting down (timeout %s)", received, configuration.ShutdownTimeout)
+
+		shutdownContext, cancel := context.WithTimeout(context.Background(), configuration.ShutdownTimeout)
+		defer cancel()
+		userDataService.Shutdown(shutdownContext)
+		stopServer(shutdownContext, grpcServer)
+		close(shutdownComplete)
+	}()
+
 	if serveError := grpcServer.Serve(listener); serveError != nil {
 		log.Fatalf("❌ Failed to serve: %v", serveError)
 	}
+	<-shutdownComplete
+	log.Println("👋 User Data Service stopped")
 }
\ No newline at end of file
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"industrialregistrysystem/base/api"
+	"industrialregistrysystem/mainservice/cache"
+)
+
+// ManagementService реализует ManagementService: управление кэшем и подключенными БД
+// для административного сервиса
+type ManagementService struct {
+	api.UnimplementedManagementServiceServer
+	dataService *UserDataService
+}
+
+func NewManagementService(dataService *UserDataService) *ManagementService {
+	return &ManagementService{dataService: dataService}
+}
+
+// ClearCache очищает кэш ответов БД
+func (management *ManagementService) ClearCache(ctx context.Context, request *api.ClearCacheRequest) (*api.ClearCacheResponse, error) {
+	// Размер до очистки приблизительный: параллельные вызовы могут успеть добавить записи
+	removedEntries := management.dataService.cache.Size()
+	management.dataService.ClearCache()
+	return &api.ClearCacheResponse{RemovedEntries: int32(removedEntries)}, nil
+}
+
+// RemoveFromCache удаляет из кэша один ключ
+func (management *ManagementService) RemoveFromCache(ctx context.Context, request *api.RemoveFromCacheRequest) (*api.RemoveFromCacheResponse, error) {
+	if request.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "cache key is required")
+	}
+	management.dataService.RemoveFromCache(request.Key)
+	return &api.RemoveFromCacheResponse{Key: request.Key}, nil
+}
+
+// GetCacheMetrics возвращает метрики кэша
+func (management *ManagementService) GetCacheMetrics(ctx context.Context, request *api.GetCacheMetricsRequest) (*api.CacheMetricsResponse, error) {
+	metricsCache, ok := management.dataService.cache.(cache.CacheWithMetrics)
+	if !ok {
+		level1, level2, level3, total := management.dataService.cache.GetStats()
+		return &api.CacheMetricsResponse{
+			Type:       string(management.dataService.cacheConfig.Type),
+			Level1Size: int32(level1),
+			Level2Size: int32(level2),
+			Level3Size: int32(level3),
+			TotalSize:  int32(total),
+			MaxSize:    int32(management.dataService.cache.MaxSize()),
+		}, nil
+	}
+
+	metrics := metricsCache.GetMetrics()
+	return &api.CacheMetricsResponse{
+		Type:                string(metrics.Type),
+		Level1Size:          int32(metrics.Level1Size),
+		Level2Size:          int32(metrics.Level2Size),
+		Level3Size:          int32(metrics.Level3Size),
+		TotalSize:           int32(metrics.TotalSize),
+		MaxSize:             int32(metrics.MaxSize),
+		HitRate:             metrics.HitRate,
+		HitCount:            metrics.HitCount,
+		MissCount:           metrics.MissCount,
+		EvictionCount:       int64(metrics.EvictionCount),
+		AverageAccessTimeNs: metrics.AverageAccessTime.Nanoseconds(),
+		UsedBytes:           metrics.UsedBytes,
+		MaxBytes:            metrics.MaxBytes,
+	}, nil
+}
+
+// ListDatabases возвращает подключенные базы данных
+func (management *ManagementService) ListDatabases(ctx context.Context, request *api.ListDatabasesRequest) (*api.ListDatabasesResponse, error) {
+	connections := management.dataService.databaseRegistry.ListDatabases()
+
+	databases := make([]*api.DatabaseInfo, 0, len(connections))
+	for _, connection := range connections {
+		info := &api.DatabaseInfo{
+			ServiceId:        connection.ServiceID,
+			InstanceId:       connection.InstanceID,
+			DnsName:          connection.DNSName,
+			CertSerial:       connection.CertSerial,
+			ConnectedAt:      timestamppb.New(connection.ConnectedAt),
+			InFlight:         connection.InFlight(),
+			QueueDepth:       int32(len(connection.CommandChan)),
+			Healthy:          connection.Healthy(),
+			AverageLatencyMs: float64(connection.AverageLatency().Microseconds()) / 1000,
+			MissedHeartbeats: connection.MissedHeartbeats(),
+		}
+		if lastHeartbeatAt, health := connection.LastHeartbeat(); health != nil {
+			info.LastHeartbeatAt = timestamppb.New(lastHeartbeatAt)
+			info.Health = health.Data
+		}
+		if connection.PeerInfo != nil && connection.PeerInfo.Addr != nil {
+			info.PeerAddress = connection.PeerInfo.Addr.String()
+		}
+		databases = append(databases, info)
+	}
+
+	return &api.ListDatabasesResponse{Databases: databases}, nil
+}
+
+// DisconnectDatabase закрывает поток команд базы данных; воркер переподключится сам
+func (management *ManagementService) DisconnectDatabase(ctx context.Context, request *api.DisconnectDatabaseRequest) (*api.DisconnectDatabaseResponse, error) {
+	connection, exists := management.dataService.databaseRegistry.GetDatabase(request.ServiceId)
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "database %s is not connected", request.ServiceId)
+	}
+
+	management.dataService.databaseRegistry.RemoveDatabase(connection)
+	log.Printf("🔌 Database %s disconnected by administrator", request.ServiceId)
+	return &api.DisconnectDatabaseResponse{Disconnected: true}, nil
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"industrialregistrysystem/mainservice/cache"
+)
+
+// ServiceMetrics метрики mainservice, отдаваемые на /metrics
+type ServiceMetrics struct {
+	registry *prometheus.Registry
+
+	rpcDuration       *prometheus.HistogramVec
+	streamDuration    *prometheus.HistogramVec
+	streamMessages    *prometheus.CounterVec
+	databaseErrors    *prometheus.CounterVec
+	streamConnects    prometheus.Counter
+	streamDisconnects prometheus.Counter
+}
+
+// NewServiceMetrics регистрирует метрики сервиса, кэша и подключенных БД
+func NewServiceMetrics(service *UserDataService) *ServiceMetrics {
+	metrics := &ServiceMetrics{
+		registry: prometheus.NewRegistry(),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "irs_grpc_request_duration_seconds",
+			Help:    "Duration of unary gRPC calls handled by mainservice.",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"method", "code"}),
+		// Потоки живут от долей секунды (Export небольшой таблицы) до часов (CommandStream воркера)
+		streamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "irs_grpc_stream_duration_seconds",
+			Help:    "Duration of streaming gRPC calls handled by mainservice.",
+			Buckets: []float64{0.01, 0.1, 0.5, 1, 5, 15, 60, 300, 900, 3600, 14400},
+		}, []string{"method", "code"}),
+		streamMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "irs_grpc_stream_messages_sent_total",
+			Help: "Messages sent by mainservice on streaming gRPC calls.",
+		}, []string{"method"}),
+		databaseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "irs_database_errors_total",
+			Help: "Error responses returned by database workers, by ErrorResponse.code.",
+		}, []string{"code"}),
+		streamConnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "irs_database_stream_connects_total",
+			Help: "Database command streams registered (including reconnects).",
+		}),
+		streamDisconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "irs_database_stream_disconnects_total",
+			Help: "Database command streams closed.",
+		}),
+	}
+
+	metrics.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.rpcDuration,
+		metrics.streamDuration,
+		metrics.streamMessages,
+		metrics.databaseErrors,
+		metrics.streamConnects,
+		metrics.streamDisconnects,
+		&databaseCollector{registry: service.databaseRegistry},
+	)
+	if metricsCache, ok := service.cache.(cache.CacheWithMetrics); ok {
+		metrics.registry.MustRegister(&cacheCollector{cache: metricsCache})
+	}
+	return metrics
+}
+
+// UnaryInterceptor измеряет длительность unary вызовов
+func (metrics *ServiceMetrics) UnaryInterceptor(ctx context.Context, request interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	startedAt := time.Now()
+	response, err := handler(ctx, request)
+	metrics.rpcDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(startedAt).Seconds())
+	return response, err
+}
+
+// StreamInterceptor измеряет длительность потоковых вызовов и число отправленных сообщений
+func (metrics *ServiceMetrics) StreamInterceptor(server interface{}, stream grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	startedAt := time.Now()
+	err := handler(server, &countingServerStream{
+		ServerStream: stream,
+		sent:         metrics.streamMessages.WithLabelValues(info.FullMethod),
+	})
+	metrics.streamDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(startedAt).Seconds())
+	return err
+}
+
+// countingServerStream считает сообщения, отправленные клиенту потока
+type countingServerStream struct {
+	grpc.ServerStream
+	sent prometheus.Counter
+}
+
+func (stream *countingServerStream) SendMsg(message interface{}) error {
+	err := stream.ServerStream.SendMsg(message)
+	if err == nil {
+		stream.sent.Inc()
+	}
+	return err
+}
+
+// recordDatabaseError учитывает ErrorResponse, полученный от БД
+func (metrics *ServiceMetrics) recordDatabaseError(code string) {
+	if code == "" {
+		code = "UNKNOWN"
+	}
+	metrics.databaseErrors.WithLabelValues(code).Inc()
+}
+
+// Serve запускает HTTP сервер с /metrics
+func (metrics *ServiceMetrics) Serve(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{}))
+
+	log.Printf("📈 Metrics endpoint running on %s/metrics", address)
+	if err := http.ListenAndServe(address, mux); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("❌ Metrics server failed: %v", err)
+	}
+}
+
+var (
+	cacheEntriesDesc = prometheus.NewDesc("irs_cache_entries",
+		"Cache entries by level (for ARC: 1 - frequent, 2 - recent, 3 - ghost keys).", []string{"type", "level"}, nil)
+	cacheMaxEntriesDesc = prometheus.NewDesc("irs_cache_max_entries",
+		"Maximum number of cache entries.", []string{"type"}, nil)
+	cacheHitRatioDesc = prometheus.NewDesc("irs_cache_hit_ratio",
+		"Share of cache lookups that were hits since start.", []string{"type"}, nil)
+	cacheHitsDesc = prometheus.NewDesc("irs_cache_hits_total",
+		"Cache lookups that found a fresh entry.", []string{"type"}, nil)
+	cacheMissesDesc = prometheus.NewDesc("irs_cache_misses_total",
+		"Cache lookups that found nothing or an expired entry.", []string{"type"}, nil)
+	cacheEvictionsDesc = prometheus.NewDesc("irs_cache_evictions_total",
+		"Entries evicted to make room for new ones.", []string{"type"}, nil)
+	cacheBytesDesc = prometheus.NewDesc("irs_cache_bytes",
+		"Estimated memory used by cache entries.", []string{"type"}, nil)
+	cacheMaxBytesDesc = prometheus.NewDesc("irs_cache_max_bytes",
+		"Cache memory budget (0 - unlimited).", []string{"type"}, nil)
+)
+
+// cacheCollector снимает cache.Metrics в момент опроса
+type cacheCollector struct {
+	cache cache.CacheWithMetrics
+}
+
+func (collector *cacheCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- cacheEntriesDesc
+	descriptions <- cacheMaxEntriesDesc
+	descriptions <- cacheHitRatioDesc
+	descriptions <- cacheHitsDesc
+	descriptions <- cacheMissesDesc
+	descriptions <- cacheEvictionsDesc
+	descriptions <- cacheBytesDesc
+	descriptions <- cacheMaxBytesDesc
+}
+
+func (collector *cacheCollector) Collect(metrics chan<- prometheus.Metric) {
+	snapshot := collector.cache.GetMetrics()
+	cacheType := string(snapshot.Type)
+
+	metrics <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(snapshot.Level1Size), cacheType, "1")
+	metrics <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(snapshot.Level2Size), cacheType, "2")
+	metrics <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(snapshot.Level3Size), cacheType, "3")
+	metrics <- prometheus.MustNewConstMetric(cacheMaxEntriesDesc, prometheus.GaugeValue, float64(snapshot.MaxSize), cacheType)
+	metrics <- prometheus.MustNewConstMetric(cacheHitRatioDesc, prometheus.GaugeValue, snapshot.HitRate, cacheType)
+	metrics <- prometheus.MustNewConstMetric(cacheHitsDesc, prometheus.CounterValue, float64(snapshot.HitCount), cacheType)
+	metrics <- prometheus.MustNewConstMetric(cacheMissesDesc, prometheus.CounterValue, float64(snapshot.MissCount), cacheType)
+	metrics <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(snapshot.EvictionCount), cacheType)
+	metrics <- prometheus.MustNewConstMetric(cacheBytesDesc, prometheus.GaugeValue, float64(snapshot.UsedBytes), cacheType)
+	metrics <- prometheus.MustNewConstMetric(cacheMaxBytesDesc, prometheus.GaugeValue, float64(snapshot.MaxBytes), cacheType)
+}
+
+var (
+	databasesConnectedDesc = prometheus.NewDesc("irs_databases_connected",
+		"Database workers with an open command stream.", nil, nil)
+	databaseQueueDepthDesc = prometheus.NewDesc("irs_database_command_queue_depth",
+		"Commands waiting in CommandChan to be sent to the database.", []string{"service_id"}, nil)
+	databaseInFlightDesc = prometheus.NewDesc("irs_database_in_flight",
+		"Commands sent to the database and awaiting a response.", []string{"service_id"}, nil)
+	databaseHealthyDesc = prometheus.NewDesc("irs_database_healthy",
+		"Whether the database currently receives commands (1) or is cooling down after failures (0).", []string{"service_id"}, nil)
+	databaseLatencyDesc = prometheus.NewDesc("irs_database_latency_seconds",
+		"Smoothed response latency of the database.", []string{"service_id"}, nil)
+	databaseMissedHeartbeatsDesc = prometheus.NewDesc("irs_database_missed_heartbeats",
+		"Consecutive health_check commands the database did not answer.", []string{"service_id"}, nil)
+)
+
+// databaseCollector снимает состояние подключенных БД в момент опроса
+type databaseCollector struct {
+	registry *DatabaseRegistry
+}
+
+func (collector *databaseCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- databasesConnectedDesc
+	descriptions <- databaseQueueDepthDesc
+	descriptions <- databaseInFlightDesc
+	descriptions <- databaseHealthyDesc
+	descriptions <- databaseLatencyDesc
+	descriptions <- databaseMissedHeartbeatsDesc
+}
+
+func (collector *databaseCollector) Collect(metrics chan<- prometheus.Metric) {
+	connections := collector.registry.ListDatabases()
+	metrics <- prometheus.MustNewConstMetric(databasesConnectedDesc, prometheus.GaugeValue, float64(len(connections)))
+
+	for _, connection := range connections {
+		healthy := 0.0
+		if connection.Healthy() {
+			healthy = 1
+		}
+		metrics <- prometheus.MustNewConstMetric(databaseQueueDepthDesc, prometheus.GaugeValue, float64(len(connection.CommandChan)), connection.ServiceID)
+		metrics <- prometheus.MustNewConstMetric(databaseInFlightDesc, prometheus.GaugeValue, float64(connection.InFlight()), connection.ServiceID)
+		metrics <- prometheus.MustNewConstMetric(databaseHealthyDesc, prometheus.GaugeValue, healthy, connection.ServiceID)
+		metrics <- prometheus.MustNewConstMetric(databaseLatencyDesc, prometheus.GaugeValue, connection.AverageLatency().Seconds(), connection.ServiceID)
+		metrics <- prometheus.MustNewConstMetric(databaseMissedHeartbeatsDesc, prometheus.GaugeValue, float64(connection.MissedHeartbeats()), connection.ServiceID)
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RevocationChecker отклоняет отозванные сертификаты клиентов при TLS рукопожатии.
+// Списки перечитываются с диска без перезапуска; при ошибке чтения остаются прежние.
+type RevocationChecker struct {
+	crlPath       string
+	denyListPath  string
+	caCertificate *x509.Certificate
+
+	mu            sync.RWMutex
+	revoked       map[string]time.Time // Серийный номер (hex) -> время отзыва по CRL
+	denied        map[string]bool      // Серийные номера из локального списка
+	crlNextUpdate time.Time
+
+	// Файлы, которые уже загружались: их исчезновение считается ошибкой, а не снятием отзыва
+	crlLoaded      bool
+	denyListLoaded bool
+
+	// onReload вызывается после каждой перезагрузки, например чтобы отключить
+	// уже подключенных клиентов с отозванными сертификатами
+	onReload func()
+}
+
+// NewRevocationChecker загружает CRL и список запрещенных номеров.
+// Отсутствующие файлы не считаются ошибкой: отозванных сертификатов просто нет,
+// пока файл не появится.
+func NewRevocationChecker(caPath, crlPath, denyListPath string) (*RevocationChecker, error) {
+	caPEM, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %v", err)
+	}
+	caBlock, _ := pem.Decode(caPEM)
+	if caBlock == nil {
+		return nil, fmt.Errorf("failed to decode CA certificate %s", caPath)
+	}
+	caCertificate, err := x509.ParseCertificate(caBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %v", err)
+	}
+
+	checker := &RevocationChecker{
+		crlPath:       crlPath,
+		denyListPath:  denyListPath,
+		caCertificate: caCertificate,
+		revoked:       make(map[string]time.Time),
+		denied:        make(map[string]bool),
+	}
+	if err := checker.Reload(); err != nil {
+		return nil, err
+	}
+	return checker, nil
+}
+
+// Reload перечитывает CRL и список запрещенных номеров. Файл, пропавший после
+// загрузки (например, на время ротации), - ошибка: иначе отзыв сертификатов снимался бы.
+func (checker *RevocationChecker) Reload() error {
+	checker.mu.RLock()
+	crlRequired, denyListRequired := checker.crlLoaded, checker.denyListLoaded
+	checker.mu.RUnlock()
+
+	revoked, nextUpdate, crlFound, err := checker.loadCRL(crlRequired)
+	if err != nil {
+		return err
+	}
+	denied, denyListFound, err := loadDenyList(checker.denyListPath, denyListRequired)
+	if err != nil {
+		return err
+	}
+
+	checker.mu.Lock()
+	checker.revoked = revoked
+	checker.denied = denied
+	checker.crlNextUpdate = nextUpdate
+	checker.crlLoaded = crlFound
+	checker.denyListLoaded = denyListFound
+	checker.mu.Unlock()
+
+	if !nextUpdate.IsZero() && time.Now().After(nextUpdate) {
+		log.Printf("⚠️ CRL %s is stale: next update was due %s", checker.crlPath, nextUpdate.Format(time.RFC3339))
+	}
+	log.Printf("🛡️ Revocation lists loaded: %d revoked by CRL, %d denied locally", len(revoked), len(denied))
+
+	if checker.onReload != nil {
+		checker.onReload()
+	}
+	return nil
+}
+
+// Watch периодически перезагружает списки до завершения процесса
+func (checker *RevocationChecker) Watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := checker.Reload(); err != nil {
+			log.Printf("❌ Failed to reload revocation lists, keeping previous ones: %v", err)
+		}
+	}
+}
+
+// VerifyPeerCertificate вызывается из tls.Config после проверки цепочки
+// и отклоняет рукопожатие, если какой-либо сертификат цепочки отозван
+func (checker *RevocationChecker) VerifyPeerCertificate(rawCertificates [][]byte, verifiedChains [][]*x509.Certificate) error {
+	for _, chain := range verifiedChains {
+		for _, certificate := range chain {
+			if reason, revoked := checker.IsRevoked(certificate.SerialNumber.Text(16)); revoked {
+				log.Printf("🚫 Rejected certificate %s (serial %s): %s",
+					certificate.Subject.CommonName, certificate.SerialNumber.Text(16), reason)
+				return fmt.Errorf("certificate %s is revoked", certificate.SerialNumber.Text(16))
+			}
+		}
+	}
+	return nil
+}
+
+// IsRevoked сообщает, отозван ли сертификат с таким серийным номером (hex), и почему
+func (checker *RevocationChecker) IsRevoked(serial string) (string, bool) {
+	checker.mu.RLock()
+	defer checker.mu.RUnlock()
+
+	if revokedAt, found := checker.revoked[serial]; found {
+		return "revoked by CRL at " + revokedAt.Format(time.RFC3339), true
+	}
+	if checker.denied[serial] {
+		return "listed in " + checker.denyListPath, true
+	}
+	return "", false
+}
+
+// loadCRL читает CRL и проверяет, что он подписан нашим CA. Без required отсутствие
+// файла не ошибка; found сообщает, был ли файл.
+func (checker *RevocationChecker) loadCRL(required bool) (revoked map[string]time.Time, nextUpdate time.Time, found bool, err error) {
+	revoked = make(map[string]time.Time)
+
+	data, err := os.ReadFile(checker.crlPath)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return revoked, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to read CRL: %v", err)
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+
+	revocationList, err := x509.ParseRevocationList(data)
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("failed to parse CRL: %v", err)
+	}
+	if err := revocationList.CheckSignatureFrom(checker.caCertificate); err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("CRL is not signed by the CA: %v", err)
+	}
+
+	for _, entry := range revocationList.RevokedCertificateEntries {
+		revoked[entry.SerialNumber.Text(16)] = entry.RevocationTime
+	}
+	return revoked, revocationList.NextUpdate, true, nil
+}
+
+// loadDenyList читает серийные номера в hex (допускаются ":" и префикс 0x),
+// пустые строки и комментарии после # пропускаются. Без required отсутствие файла
+// не ошибка; found сообщает, был ли файл.
+func loadDenyList(path string, required bool) (denied map[string]bool, found bool, err error) {
+	denied = make(map[string]bool)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return denied, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read deny list: %v", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		line = strings.TrimPrefix(strings.ToLower(line), "0x")
+		serial, ok := new(big.Int).SetString(strings.ReplaceAll(line, ":", ""), 16)
+		if !ok {
+			return nil, false, fmt.Errorf("invalid serial number on line %d of %s", lineNumber, path)
+		}
+		denied[serial.Text(16)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false, err
+	}
+	return denied, true, nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCA самоподписанный CA для выпуска CRL в тестах
+type testCA struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create CA certificate: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse CA certificate: %v", err)
+	}
+	return &testCA{certificate: certificate, key: key}
+}
+
+// writeCertificate сохраняет сертификат CA в PEM
+func (ca *testCA) writeCertificate(t *testing.T, path string) {
+	t.Helper()
+	writeFile(t, path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.certificate.Raw}))
+}
+
+// writeCRL выпускает CRL с отозванными серийными номерами и сохраняет его в PEM
+func (ca *testCA) writeCRL(t *testing.T, path string, serials ...int64) {
+	t.Helper()
+	template := &x509.RevocationList{
+		Number:     big.NewInt(time.Now().UnixNano()),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: time.Now().Add(time.Hour),
+	}
+	for _, serial := range serials {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.certificate, ca.key)
+	if err != nil {
+		t.Fatalf("create CRL: %v", err)
+	}
+	writeFile(t, path, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}))
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// revocationFiles пути к файлам проверки отзыва во временном каталоге
+type revocationFiles struct {
+	ca, crl, denyList string
+}
+
+func newRevocationFiles(t *testing.T, ca *testCA) revocationFiles {
+	t.Helper()
+	directory := t.TempDir()
+	files := revocationFiles{
+		ca:       filepath.Join(directory, "ca.crt"),
+		crl:      filepath.Join(directory, "ca.crl"),
+		denyList: filepath.Join(directory, "denied_serials.txt"),
+	}
+	ca.writeCertificate(t, files.ca)
+	return files
+}
+
+func assertRevoked(t *testing.T, checker *RevocationChecker, serial int64, want bool) {
+	t.Helper()
+	hexSerial := big.NewInt(serial).Text(16)
+	if reason, revoked := checker.IsRevoked(hexSerial); revoked != want {
+		t.Errorf("IsRevoked(%s) = %t (%s), want %t", hexSerial, revoked, reason, want)
+	}
+}
+
+func TestRevocationCheckerLists(t *testing.T) {
+	ca := newTestCA(t, "IRS Test CA")
+
+	tests := []struct {
+		name     string
+		crl      []int64 // nil - файла CRL нет
+		denyList string  // Пусто - файла нет
+		revoked  []int64
+		allowed  []int64
+	}{
+		{name: "no files", allowed: []int64{10, 11}},
+		{name: "serial revoked by CRL", crl: []int64{10, 0x1f}, revoked: []int64{10, 0x1f}, allowed: []int64{11}},
+		{
+			name:     "deny list entries",
+			denyList: "# выведены из эксплуатации\n0x0B\n\n1F:00 # с разделителями\n",
+			revoked:  []int64{11, 0x1f00},
+			allowed:  []int64{10},
+		},
+		{name: "CRL and deny list together", crl: []int64{10}, denyList: "b\n", revoked: []int64{10, 11}, allowed: []int64{12}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := newRevocationFiles(t, ca)
+			if test.crl != nil {
+				ca.writeCRL(t, files.crl, test.crl...)
+			}
+			if test.denyList != "" {
+				writeFile(t, files.denyList, []byte(test.denyList))
+			}
+
+			checker, err := NewRevocationChecker(files.ca, files.crl, files.denyList)
+			if err != nil {
+				t.Fatalf("NewRevocationChecker: %v", err)
+			}
+			for _, serial := range test.revoked {
+				assertRevoked(t, checker, serial, true)
+			}
+			for _, serial := range test.allowed {
+				assertRevoked(t, checker, serial, false)
+			}
+		})
+	}
+}
+
+func TestRevocationCheckerRejectsForeignCRL(t *testing.T) {
+	ca := newTestCA(t, "IRS Test CA")
+	foreign := newTestCA(t, "Foreign CA")
+	files := newRevocationFiles(t, ca)
+
+	foreign.writeCRL(t, files.crl, 10)
+	if _, err := NewRevocationChecker(files.ca, files.crl, files.denyList); err == nil ||
+		!strings.Contains(err.Error(), "CRL is not signed by the CA") {
+		t.Fatalf("NewRevocationChecker error = %v, want CRL signature error", err)
+	}
+
+	// При перезагрузке CRL чужого CA не заменяет прежний
+	ca.writeCRL(t, files.crl, 11)
+	checker, err := NewRevocationChecker(files.ca, files.crl, files.denyList)
+	if err != nil {
+		t.Fatalf("NewRevocationChecker: %v", err)
+	}
+	foreign.writeCRL(t, files.crl)
+	if err := checker.Reload(); err == nil {
+		t.Fatal("Reload accepted a CRL signed by another CA")
+	}
+	assertRevoked(t, checker, 11, true)
+}
+
+func TestRevocationCheckerReloadKeepsListsWhenFilesDisappear(t *testing.T) {
+	ca := newTestCA(t, "IRS Test CA")
+	files := newRevocationFiles(t, ca)
+	ca.writeCRL(t, files.crl, 10)
+	writeFile(t, files.denyList, []byte("b\n"))
+
+	checker, err := NewRevocationChecker(files.ca, files.crl, files.denyList)
+	if err != nil {
+		t.Fatalf("NewRevocationChecker: %v", err)
+	}
+	reloads := 0
+	checker.onReload = func() { reloads++ }
+
+	for _, path := range []string{files.crl, files.denyList} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read %s: %v", path, err)
+			}
+			if err := os.Remove(path); err != nil {
+				t.Fatalf("remove %s: %v", path, err)
+			}
+
+			if err := checker.Reload(); err == nil {
+				t.Error("Reload succeeded without a previously loaded file")
+			}
+			assertRevoked(t, checker, 10, true)
+			assertRevoked(t, checker, 11, true)
+
+			// Файл вернулся после ротации: перезагрузка снова проходит
+			writeFile(t, path, data)
+			if err := checker.Reload(); err != nil {
+				t.Fatalf("Reload after the file returned: %v", err)
+			}
+		})
+	}
+	if reloads != 2 {
+		t.Errorf("onReload called %d times, want 2 (only successful reloads)", reloads)
+	}
+}
+
+func TestRevocationCheckerPicksUpNewFiles(t *testing.T) {
+	ca := newTestCA(t, "IRS Test CA")
+	files := newRevocationFiles(t, ca)
+
+	checker, err := NewRevocationChecker(files.ca, files.crl, files.denyList)
+	if err != nil {
+		t.Fatalf("NewRevocationChecker: %v", err)
+	}
+	// Пока файлов нет, перезагрузка не ошибка
+	if err := checker.Reload(); err != nil {
+		t.Fatalf("Reload without files: %v", err)
+	}
+	assertRevoked(t, checker, 10, false)
+
+	ca.writeCRL(t, files.crl, 10)
+	if err := checker.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	assertRevoked(t, checker, 10, true)
+
+	leaf := &x509.Certificate{SerialNumber: big.NewInt(10), Subject: pkix.Name{CommonName: "database-1"}}
+	if err := checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{leaf, ca.certificate}}); err == nil {
+		t.Error("VerifyPeerCertificate accepted a revoked certificate")
+	}
+	leaf.SerialNumber = big.NewInt(12)
+	if err := checker.VerifyPeerCertificate(nil, [][]*x509.Certificate{{leaf, ca.certificate}}); err != nil {
+		t.Errorf("VerifyPeerCertificate rejected a valid certificate: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"industrialregistrysystem/base/api"
+)
+
+// Системные команды воркера БД для работы со схемой
+const (
+	schemaCommand        = "schema"
+	schemaRefreshCommand = "schema_refresh"
+)
+
+// GetSchema возвращает таблицы и колонки, доступные универсальным CRUD командам,
+// по данным одной из подключенных БД
+func (management *ManagementService) GetSchema(ctx context.Context, request *api.GetSchemaRequest) (*api.GetSchemaResponse, error) {
+	service := management.dataService
+	connection := service.databaseRegistry.SelectDatabase(service.strategy, nil)
+	if connection == nil {
+		return nil, status.Error(codes.Unavailable, "no databases available")
+	}
+
+	schema, err := service.requestSchema(ctx, connection, schemaCommand)
+	if err != nil {
+		return nil, err
+	}
+	return &api.GetSchemaResponse{ServiceId: connection.ServiceID, Schema: schema}, nil
+}
+
+// RefreshSchema перечитывает information_schema на всех подключенных БД: у каждого
+// воркера собственный реестр схемы
+func (management *ManagementService) RefreshSchema(ctx context.Context, request *api.RefreshSchemaRequest) (*api.RefreshSchemaResponse, error) {
+	service := management.dataService
+	connections := service.databaseRegistry.ListDatabases()
+	results := make([]*api.RefreshSchemaResult, len(connections))
+
+	var workers sync.WaitGroup
+	for index, connection := range connections {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			result := &api.RefreshSchemaResult{ServiceId: connection.ServiceID}
+			schema, err := service.requestSchema(ctx, connection, schemaRefreshCommand)
+			if err != nil {
+				result.Error = err.Error()
+				log.Printf("⚠️ Schema refresh failed on database %s: %v", connection.ServiceID, err)
+			} else {
+				result.Schema = schema
+				log.Printf("🗂️ Database %s reloaded schema: %d tables", connection.ServiceID, len(schema.Tables))
+			}
+			results[index] = result
+		}()
+	}
+	workers.Wait()
+
+	return &api.RefreshSchemaResponse{Databases: results}, nil
+}
+
+// requestSchema отправляет системную команду схемы именно этой БД, минуя балансировку и кэш
+func (service *UserDataService) requestSchema(ctx context.Context, connection *DatabaseConnection, command string) (*api.SchemaResponse, error) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultCommandTimeout)
+		defer cancel()
+	}
+
+	request := &api.CommandRequest{
+		RequestId: service.newRequestID(command),
+		Command: &api.CommandRequest_SystemCommand{
+			SystemCommand: command,
+		},
+	}
+
+	responseChan := make(chan *api.CommandResponse, 1)
+	service.pendingRequests.Store(request.RequestId, responseChan)
+	defer service.pendingRequests.Delete(request.RequestId)
+
+	if err := connection.sendCommand(ctx, request); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to send command to database: %v", err)
+	}
+
+	select {
+	case response := <-responseChan:
+		if errorResponse := response.GetError(); errorResponse != nil {
+			return nil, errorResponseToStatus(errorResponse)
+		}
+		schema := response.GetSchema()
+		if schema == nil {
+			return nil, fmt.Errorf("invalid schema response type %T", response.Response)
+		}
+		return schema, nil
+	case <-connection.Done():
+		return nil, status.Errorf(codes.Unavailable, "database %s disconnected", connection.ServiceID)
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
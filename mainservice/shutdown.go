@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"industrialregistrysystem/base/api"
+)
+
+// shutdownCommand системная команда, по которой воркер дожидается выполняемых команд
+// и закрывает поток; после этого он переподключается, когда mainservice снова запустится
+const shutdownCommand = "shutdown"
+
+// drainState отслеживает выполняемые вызовы DataService, чтобы остановка их дождалась
+type drainState struct {
+	mu          sync.RWMutex
+	draining    bool
+	activeCalls sync.WaitGroup
+}
+
+// DrainInterceptor отклоняет новые вызовы DataService после начала остановки
+// и учитывает выполняемые, чтобы Shutdown дождался их ответов
+func (service *UserDataService) DrainInterceptor(ctx context.Context, request interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, "/api.DataService/") {
+		return handler(ctx, request)
+	}
+
+	service.drain.mu.RLock()
+	if service.drain.draining {
+		service.drain.mu.RUnlock()
+		return nil, status.Error(codes.Unavailable, "service is shutting down")
+	}
+	service.drain.activeCalls.Add(1)
+	service.drain.mu.RUnlock()
+	defer service.drain.activeCalls.Done()
+
+	return handler(ctx, request)
+}
+
+// DrainStreamInterceptor то же для потоковых вызовов DataService (Export)
+func (service *UserDataService) DrainStreamInterceptor(server interface{}, stream grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, "/api.DataService/") {
+		return handler(server, stream)
+	}
+
+	service.drain.mu.RLock()
+	if service.drain.draining {
+		service.drain.mu.RUnlock()
+		return status.Error(codes.Unavailable, "service is shutting down")
+	}
+	service.drain.activeCalls.Add(1)
+	service.drain.mu.RUnlock()
+	defer service.drain.activeCalls.Done()
+
+	return handler(server, stream)
+}
+
+// Shutdown останавливает прием вызовов DataService, ждет ответы на выполняемые
+// до дедлайна ctx и просит воркеры завершить свои команды и закрыть потоки
+func (service *UserDataService) Shutdown(ctx context.Context) {
+	service.drain.mu.Lock()
+	service.drain.draining = true
+	service.drain.mu.Unlock()
+	log.Println("🛑 Draining: new DataService calls are rejected")
+
+	drained := make(chan struct{})
+	go func() {
+		service.drain.activeCalls.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		log.Println("✅ All pending DataService calls completed")
+	case <-ctx.Done():
+		log.Println("⚠️ Shutdown deadline reached with DataService calls still pending")
+	}
+
+	var workers sync.WaitGroup
+	for _, connection := range service.databaseRegistry.ListDatabases() {
+		workers.Add(1)
+		go func(connection *DatabaseConnection) {
+			defer workers.Done()
+			if err := service.sendShutdown(ctx, connection); err != nil {
+				log.Printf("⚠️ Database %s did not acknowledge shutdown: %v", connection.ServiceID, err)
+				return
+			}
+			log.Printf("👋 Database %s acknowledged shutdown", connection.ServiceID)
+		}(connection)
+	}
+	workers.Wait()
+}
+
+// sendShutdown отправляет воркеру команду shutdown и ждет подтверждения
+func (service *UserDataService) sendShutdown(ctx context.Context, connection *DatabaseConnection) error {
+	request := &api.CommandRequest{
+		RequestId: service.newRequestID("shutdown"),
+		Command: &api.CommandRequest_SystemCommand{
+			SystemCommand: shutdownCommand,
+		},
+	}
+
+	responseChan := make(chan *api.CommandResponse, 1)
+	service.pendingRequests.Store(request.RequestId, responseChan)
+	defer service.pendingRequests.Delete(request.RequestId)
+
+	if err := connection.sendCommand(ctx, request); err != nil {
+		return err
+	}
+
+	select {
+	case response := <-responseChan:
+		if errorResponse := response.GetError(); errorResponse != nil {
+			return fmt.Errorf("%s: %s", errorResponse.Code, errorResponse.Message)
+		}
+		return nil
+	case <-connection.Done():
+		// Воркер уже закрыл поток
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stopServer ждет закрытия всех потоков и вызовов; по дедлайну разрывает оставшиеся
+func stopServer(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Println("⚠️ Shutdown deadline reached, closing remaining connections")
+		grpcServer.Stop()
+		<-stopped
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"industrialregistrysystem/base/config"
+)
+
+// Config настройки парсера: config/universalParser.yaml, переменные IRS_UNIVERSALPARSER_* и флаги
+type Config struct {
+	// ListenAddress адрес веб-интерфейса загрузки; :8080 занят админкой
+	ListenAddress string `yaml:"listen_address" usage:"upload web UI listen address"`
+
+	UploadDir string `yaml:"upload_dir" usage:"directory for uploaded files"`
+	StaticDir string `yaml:"static_dir" usage:"directory with HTML templates and static files"`
+
+	// Postgres подключение к БД; если не задано, строка подключения читается из DSNFile
+	Postgres config.Postgres `yaml:"postgres"`
+
+	// DSNFile файл со строкой подключения (прежний db.conf)
+	DSNFile string `yaml:"dsn_file" usage:"file with the PostgreSQL connection string, used when postgres is not set"`
+
+	// DBCheckInterval как часто проверять соединение с БД
+	DBCheckInterval time.Duration `yaml:"db_check_interval" usage:"how often to check the database connection"`
+}
+
+var configuration = defaultConfig()
+
+func defaultConfig() *Config {
+	return &Config{
+		ListenAddress:   ":8081",
+		UploadDir:       "./uploads",
+		StaticDir:       "./static",
+		DSNFile:         "db.conf",
+		DBCheckInterval: 5 * time.Minute,
+	}
+}
+
+// postgresConfigured сообщает, задано ли подключение в самой конфигурации
+func (configuration *Config) postgresConfigured() bool {
+	return configuration.Postgres.DSN != "" || configuration.Postgres.Host != ""
+}
+
+// ConnectionString строка подключения из конфигурации или из DSNFile
+func (configuration *Config) ConnectionString() (string, error) {
+	if configuration.postgresConfigured() {
+		return configuration.Postgres.ConnectionString(), nil
+	}
+	content, err := os.ReadFile(configuration.DSNFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// Validate проверяет настройки при запуске
+func (configuration *Config) Validate() error {
+	if err := config.CheckAddress("listen_address", configuration.ListenAddress); err != nil {
+		return err
+	}
+	if configuration.UploadDir == "" || configuration.StaticDir == "" {
+		return fmt.Errorf("upload_dir and static_dir must be set")
+	}
+	if configuration.postgresConfigured() {
+		if err := configuration.Postgres.Validate(); err != nil {
+			return err
+		}
+	} else if err := config.CheckFile("dsn_file", configuration.DSNFile); err != nil {
+		return fmt.Errorf("postgres is not configured and %v", err)
+	}
+	return config.CheckPositive("db_check_interval", configuration.DBCheckInterval)
+}
@@ -3,7 +3,6 @@ package main
 import (
     "database/sql"
     "fmt"
-    "os"
     "strings"
     "time"
 
@@ -185,16 +184,8 @@ func SaveDocument(db *sql.DB, doc *Document) (int64, error) {
 	return id, nil
 }
 
-func readFileToString(filename string) (string, error) {
-    content, err := os.ReadFile(filename)
-    if err != nil {
-        return "", err
-    }
-    return string(content), nil
-}
-
 func connectToDB() (*sql.DB, error) {
-    connStr, err := readFileToString("db.conf")
+    connStr, err := configuration.ConnectionString()
     if err != nil {
         return nil, err
     }
@@ -4,6 +4,8 @@ go 1.25.3
 
 replace industrialregistrysystem/base/api => ../base/api
 
+replace industrialregistrysystem/base/config => ../base/config
+
 require (
 	github.com/lib/pq v1.10.9
 	github.com/xuri/excelize/v2 v2.8.0
@@ -11,18 +13,19 @@ require (
 )
 
 require (
+	github.com/ledongthuc/pdf v0.0.0-20250511090121-5959a4027728
 	golang.org/x/net v0.42.0
 	golang.org/x/sys v0.35.0
 	golang.org/x/text v0.27.0
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b
 	google.golang.org/protobuf v1.36.10
 	industrialregistrysystem/base/api v0.0.0
+	industrialregistrysystem/base/config v0.0.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
-	github.com/ledongthuc/pdf v0.0.0-20250511090121-5959a4027728 // indirect
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/richardlehane/mscfb v1.0.4 // indirect
@@ -9,30 +9,24 @@ import (
 	"path/filepath"
 )
 
-const (
-	uploadDir = "./uploads"
-	staticDir = "./static"
-	port      = ":8080"
-)
-
 func runServer() {
 	// Создаем необходимые директории
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+	if err := os.MkdirAll(configuration.UploadDir, 0755); err != nil {
 		log.Fatal("Ошибка создания директории uploads:", err)
 	}
-	if err := os.MkdirAll(staticDir, 0755); err != nil {
+	if err := os.MkdirAll(configuration.StaticDir, 0755); err != nil {
 		log.Fatal("Ошибка создания директории static:", err)
 	}
 
 	// Настраиваем маршруты
-	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir(staticDir))))
+	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir(configuration.StaticDir))))
 	http.HandleFunc("/", indexHandler)
 	http.HandleFunc("/upload", uploadFileHandler)
-	http.Handle("/download/", http.StripPrefix("/download/", http.FileServer(http.Dir(uploadDir))))
+	http.Handle("/download/", http.StripPrefix("/download/", http.FileServer(http.Dir(configuration.UploadDir))))
 
-	log.Printf("Сервер запущен на http://localhost%s", port)
-	log.Printf("Статические файлы обслуживаются из: %s", staticDir)
-	log.Fatal(http.ListenAndServe(port, nil))
+	log.Printf("Сервер запущен на %s", configuration.ListenAddress)
+	log.Printf("Статические файлы обслуживаются из: %s", configuration.StaticDir)
+	log.Fatal(http.ListenAndServe(configuration.ListenAddress, nil))
 }
 
 // Обработчик главной страницы
@@ -43,12 +37,12 @@ func indexHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Отдаем index.html из статической папки
-	http.ServeFile(w, r, filepath.Join(staticDir, "index.html"))
+	http.ServeFile(w, r, filepath.Join(configuration.StaticDir, "index.html"))
 }
 
 // Показывает страницу успеха
 func showSuccessPage(w http.ResponseWriter, fileName, originalName string) {
-	tmplPath := filepath.Join(staticDir, "success.html")
+	tmplPath := filepath.Join(configuration.StaticDir, "success.html")
 	tmpl, err := template.ParseFiles(tmplPath)
 	if err != nil {
 		http.Error(w, "Ошибка загрузки шаблона", http.StatusInternalServerError)
@@ -69,7 +63,7 @@ func showSuccessPage(w http.ResponseWriter, fileName, originalName string) {
 
 // Показывает страницу ошибки
 func showErrorPage(w http.ResponseWriter, errorMessage string) {
-	tmplPath := filepath.Join(staticDir, "error.html")
+	tmplPath := filepath.Join(configuration.StaticDir, "error.html")
 	tmpl, err := template.ParseFiles(tmplPath)
 	if err != nil {
 		// Если шаблон ошибки не найден, показываем простую ошибку
@@ -13,6 +13,7 @@ import (
 
     "github.com/xuri/excelize/v2"
     _ "github.com/lib/pq"
+    "industrialregistrysystem/base/config"
 )
 
 var db *sql.DB
@@ -46,7 +47,18 @@ type IncomeData struct {
 }
 
 func main() {
-    var err error
+    // Загружаем настройки: файл, переменные окружения, флаги
+    printConfig, err := config.Load("universalParser", configuration, os.Args[1:])
+    if err != nil {
+        log.Fatalf("Ошибка конфигурации: %v", err)
+    }
+    if printConfig {
+        if err := config.Print(os.Stdout, configuration); err != nil {
+            log.Fatal(err)
+        }
+        return
+    }
+
     db, err = connectToDB()
     if err != nil {
         log.Fatal("Не удалось подключиться к БД:", err)
@@ -60,7 +72,7 @@ func main() {
 }
 
 func checkDBConnection() {
-    ticker := time.NewTicker(5 * time.Minute)
+    ticker := time.NewTicker(configuration.DBCheckInterval)
     defer ticker.Stop()
     
     for range ticker.C {
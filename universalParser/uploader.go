@@ -69,7 +69,7 @@ func uploadFileHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Генерируем уникальное имя файла
 	newFileName := generateFileName(header.Filename)
-	filePath := filepath.Join(uploadDir, newFileName)
+	filePath := filepath.Join(configuration.UploadDir, newFileName)
 
 	// Создаем файл на сервере
 	dst, err := os.Create(filePath)